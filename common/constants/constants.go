@@ -72,6 +72,12 @@ const (
 	SourceRelationshipImage            = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/image"
 	SourceRelationshipOfficeDocument   = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"
 	SourceRelationshipHyperLink        = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/hyperlink"
+	SourceRelationshipHeader           = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/header"
+	SourceRelationshipFooter           = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/footer"
+	SourceRelationshipFootnotes        = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/footnotes"
+	SourceRelationshipEndnotes         = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/endnotes"
+	SourceRelationshipTheme            = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/theme"
+	SourceRelationshipNumbering        = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/numbering"
 )
 
 const (
@@ -0,0 +1,315 @@
+package docx
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"godocx/internal"
+	"godocx/wml/ctypes"
+)
+
+// StyleConflict selects how AppendDocument merges a style of the appended document when the
+// document has a different style with the same ID.
+type StyleConflict int
+
+const (
+	// UseDestinationStyle formats the appended content with the style of the document.
+	UseDestinationStyle StyleConflict = iota
+	// KeepSourceStyle replaces the style of the document with the style of the appended document.
+	KeepSourceStyle
+	// RenameSourceStyle adds the style of the appended document under a new ID and name.
+	RenameSourceStyle
+)
+
+// AppendOptions configures AppendDocument. The zero value keeps the styles of the document and
+// appends the content to the last section.
+type AppendOptions struct {
+	// StyleConflict selects how conflicting styles are merged.
+	StyleConflict StyleConflict
+
+	// SectionBreak starts the appended content on a new page, in a section with the page setup
+	// of the appended document. The headers and footers of the document are kept.
+	SectionBreak bool
+}
+
+// AppendDocument appends a copy of the body of another document to the document.
+//
+// The hyperlinks and images of the content are added to the document, the images being numbered
+// after those of the document. The styles used by the content are added with the styles they
+// are based on; a style whose ID is taken by a different style of the document is merged as
+// selected by opts.StyleConflict. The lists of the content are copied with new numbering IDs,
+// so they do not continue the lists of the document. Footnotes, comments, headers and footers
+// of the other document are not copied.
+//
+// Either all the content is appended or, when the content refers to a missing part, the
+// document is left unchanged.
+//
+// Example:
+//
+//	appendix, err := godocx.OpenDocument("appendix.docx")
+//	if err != nil {
+//		return err
+//	}
+//	err = document.AppendDocument(appendix, &docx.AppendOptions{SectionBreak: true})
+func (rd *RootDoc) AppendDocument(other *RootDoc, opts *AppendOptions) error {
+	if opts == nil {
+		opts = &AppendOptions{}
+	}
+
+	children, err := rd.importContent(other, other.Document.Body.Children, opts.StyleConflict)
+	if err != nil {
+		return fmt.Errorf("append document: %w", err)
+	}
+
+	body := rd.Document.Body
+	if opts.SectionBreak {
+		// The last paragraph of a section holds its properties
+		p := newParagraph(rd)
+		p.ensureProp()
+		p.GetCT().Property.SectPr = body.SectPr
+		if p.GetCT().Property.SectPr == nil {
+			p.GetCT().Property.SectPr = ctypes.NewSectionProper()
+		}
+		body.Children = append(body.Children, DocumentChild{Para: p})
+
+		sectPr := body.SectPr
+		if other.Document.Body.SectPr != nil {
+			sectPr = other.Document.Body.SectPr
+		}
+		if sectPr != nil {
+			sectPr = internal.DeepCopy(sectPr)
+			// Header and footer references are relationships of their document, without them
+			// the section continues the headers and footers of the previous one
+			sectPr.HeaderReference = nil
+			sectPr.FooterReference = nil
+		}
+		body.SectPr = sectPr
+	}
+	body.Children = append(body.Children, children...)
+	return nil
+}
+
+// numberingDefs returns a copy of the numbering definitions of the document, nil if they cannot
+// be parsed.
+func (rd *RootDoc) numberingDefs() *ctypes.Numbering {
+	if rd.Numbering != nil {
+		return rd.Numbering.definitions()
+	}
+	return NewNumberingManager(rd).definitions()
+}
+
+// styleMerger merges the styles used by content copied from a source document into the styles
+// of a destination document.
+type styleMerger struct {
+	src, dst *RootDoc
+	conflict StyleConflict
+
+	styles   []*ctypes.Style       // Copies of the source styles to add to the destination
+	replaces map[*ctypes.Style]int // Index of the destination style replaced by a copy
+	renamed  map[string]string     // Source style ID to destination style ID
+}
+
+// plan selects the source styles to copy for the content and the styles styleIDs, and their
+// destination IDs, without changing the destination.
+func (sm *styleMerger) plan(children []DocumentChild, styleIDs ...string) {
+	sm.replaces = map[*ctypes.Style]int{}
+	sm.renamed = map[string]string{}
+	if sm.src.DocStyles == nil || sm.dst.DocStyles == nil {
+		return
+	}
+
+	// Styles used by the content, the styles they derive from, are linked to or are followed by
+	used := map[string]bool{}
+	var queue []string
+	use := func(ref *ctypes.CTString) {
+		if ref != nil && ref.Val != "" && !used[ref.Val] {
+			used[ref.Val] = true
+			queue = append(queue, ref.Val)
+		}
+	}
+	eachStyleRef(children, use)
+	for _, id := range styleIDs {
+		use(&ctypes.CTString{Val: id})
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		if style := sm.srcStyle(id); style != nil {
+			use(style.BasedOn)
+			use(style.Link)
+			use(style.Next)
+		}
+	}
+
+	taken := map[string]bool{}
+	for _, style := range sm.dst.DocStyles.StyleList {
+		if style.ID != nil {
+			taken[*style.ID] = true
+		}
+		if style.Name != nil {
+			taken[style.Name.Val] = true
+		}
+	}
+
+	for _, style := range sm.src.DocStyles.StyleList {
+		if style.ID == nil || !used[*style.ID] {
+			continue
+		}
+		index := sm.dstStyle(*style.ID)
+		if index < 0 {
+			copied := internal.DeepCopy(&style)
+			copied.Default = nil
+			sm.styles = append(sm.styles, copied)
+			continue
+		}
+
+		existing := sm.dst.DocStyles.StyleList[index]
+		if reflect.DeepEqual(existing, style) {
+			continue
+		}
+		sameType := existing.Type != nil && style.Type != nil && *existing.Type == *style.Type
+		switch {
+		case sameType && sm.conflict == UseDestinationStyle:
+		case sameType && sm.conflict == KeepSourceStyle:
+			copied := internal.DeepCopy(&style)
+			copied.Default = existing.Default
+			sm.styles = append(sm.styles, copied)
+			sm.replaces[copied] = index
+		default:
+			// Renamed as well when the types differ, a style cannot stand in for another type
+			copied := internal.DeepCopy(&style)
+			copied.Default = nil
+			for n := 1; ; n++ {
+				id := fmt.Sprintf("%s_%d", *style.ID, n)
+				if taken[id] {
+					continue
+				}
+				copied.ID = &id
+				if style.Name != nil {
+					name := fmt.Sprintf("%s %d", style.Name.Val, n)
+					if taken[name] {
+						continue
+					}
+					copied.Name = &ctypes.CTString{Val: name}
+					taken[name] = true
+				}
+				taken[id] = true
+				break
+			}
+			sm.styles = append(sm.styles, copied)
+			sm.renamed[*style.ID] = *copied.ID
+		}
+	}
+}
+
+// apply adds the planned styles to the destination, with their references to other styles and
+// to the numbering instances updated.
+func (sm *styleMerger) apply(numIDs map[int]int) {
+	for _, style := range sm.styles {
+		for _, ref := range []*ctypes.CTString{style.BasedOn, style.Link, style.Next} {
+			if ref == nil {
+				continue
+			}
+			if newID, ok := sm.renamed[ref.Val]; ok {
+				ref.Val = newID
+			}
+		}
+		if prop := style.ParaProp; prop != nil && prop.NumProp != nil && prop.NumProp.NumID != nil {
+			if newID, ok := numIDs[prop.NumProp.NumID.Val]; ok {
+				prop.NumProp.NumID.Val = newID
+			}
+		}
+
+		if index, ok := sm.replaces[style]; ok {
+			sm.dst.DocStyles.StyleList[index] = *style
+		} else {
+			sm.dst.DocStyles.StyleList = append(sm.dst.DocStyles.StyleList, *style)
+		}
+	}
+}
+
+// srcStyle returns the source style with the given ID, nil if there is none.
+func (sm *styleMerger) srcStyle(id string) *ctypes.Style {
+	for i, style := range sm.src.DocStyles.StyleList {
+		if style.ID != nil && *style.ID == id {
+			return &sm.src.DocStyles.StyleList[i]
+		}
+	}
+	return nil
+}
+
+// dstStyle returns the index of the destination style with the given ID, -1 if there is none.
+func (sm *styleMerger) dstStyle(id string) int {
+	for i, style := range sm.dst.DocStyles.StyleList {
+		if style.ID != nil && *style.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// eachStyleRef calls fn with the paragraph, run and table style references of the content.
+func eachStyleRef(children []DocumentChild, fn func(ref *ctypes.CTString)) {
+	visit := func(ref *ctypes.CTString) {
+		if ref != nil {
+			fn(ref)
+		}
+	}
+	var table func(t *ctypes.Table)
+	table = func(t *ctypes.Table) {
+		visit(t.TableProp.Style)
+		for _, rc := range t.RowContents {
+			if rc.Row == nil {
+				continue
+			}
+			for _, cc := range rc.Row.Contents {
+				if cc.Cell == nil {
+					continue
+				}
+				for _, block := range cc.Cell.Contents {
+					if block.Table != nil {
+						table(block.Table)
+					}
+				}
+			}
+		}
+	}
+	for _, child := range children {
+		if child.Table != nil {
+			table(child.Table.GetCT())
+		}
+	}
+
+	forEachPara(children, func(p *ctypes.Paragraph) bool {
+		if p.Property != nil {
+			visit(p.Property.Style)
+			if p.Property.RunProperty != nil {
+				visit(p.Property.RunProperty.Style)
+			}
+		}
+		for run := range (&Paragraph{ref: p}).Runs() {
+			if run.ct.Property != nil {
+				visit(run.ct.Property.Style)
+			}
+		}
+		// Deleted runs keep their formatting until the deletion is accepted
+		children := p.Children
+		for _, child := range p.Children {
+			if child.Link != nil {
+				children = append(slices.Clip(children), child.Link.Children...)
+			}
+		}
+		for _, child := range children {
+			if child.Del == nil {
+				continue
+			}
+			for _, run := range child.Del.Runs {
+				if run != nil && run.Property != nil {
+					visit(run.Property.Style)
+				}
+			}
+		}
+		return true
+	})
+}
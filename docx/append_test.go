@@ -0,0 +1,172 @@
+package docx
+
+import (
+	"testing"
+
+	"godocx/common/units"
+	"godocx/internal"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const appendTestNumbering = `<?xml version="1.0" encoding="UTF-8"?>` +
+	`<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+	`<w:abstractNum w:abstractNumId="0"><w:nsid w:val="1A2B3C4D"/><w:lvl w:ilvl="0"><w:start w:val="1"/><w:numFmt w:val="decimal"/><w:lvlText w:val="%1."/><w:pStyle w:val="Quote"/></w:lvl></w:abstractNum>` +
+	`<w:num w:numId="1"><w:abstractNumId w:val="0"/></w:num>` +
+	`</w:numbering>`
+
+// savedNumbering returns the numbering part as written when the document is saved.
+func savedNumbering(t *testing.T, rd *RootDoc) string {
+	t.Helper()
+	if rd.Numbering != nil {
+		require.NoError(t, rd.Numbering.applyToFileMap())
+	}
+	content, ok := rd.FileMap.Load(numberingPath)
+	require.True(t, ok)
+	return string(content.([]byte))
+}
+
+func appendTestDoc(t *testing.T, bold bool) *RootDoc {
+	rd := setupRootDoc(t)
+	rd.FileMap.Store(numberingPath, []byte(appendTestNumbering))
+	heading := ctypes.Style{
+		ID:      internal.ToPtr("Heading1"),
+		Name:    &ctypes.CTString{Val: "heading 1"},
+		Type:    internal.ToPtr(stypes.StyleTypeParagraph),
+		RunProp: &ctypes.RunProperty{},
+	}
+	if bold {
+		heading.RunProp.Bold = &ctypes.OnOff{}
+	} else {
+		heading.RunProp.Italic = &ctypes.OnOff{}
+	}
+	rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, heading)
+	return rd
+}
+
+func TestAppendDocument(t *testing.T) {
+	for _, tc := range []struct {
+		conflict StyleConflict
+		style    string
+		italic   bool
+	}{
+		{UseDestinationStyle, "Heading1", false},
+		{KeepSourceStyle, "Heading1", true},
+		{RenameSourceStyle, "Heading1_1", true},
+	} {
+		rd := appendTestDoc(t, true)
+		rd.Numbering = NewNumberingManager(rd)
+		rd.AddParagraph("first")
+		rd.NewListInstance(1)
+
+		other := appendTestDoc(t, false)
+		other.DocStyles.StyleList = append(other.DocStyles.StyleList, ctypes.Style{
+			ID:      internal.ToPtr("Quote"),
+			Name:    &ctypes.CTString{Val: "Quote"},
+			Type:    internal.ToPtr(stypes.StyleTypeParagraph),
+			BasedOn: &ctypes.CTString{Val: "Heading1"},
+		})
+		other.AddParagraph("title").Style("Heading1")
+		quote := other.AddParagraph("quote ")
+		quote.Style("Quote")
+		quote.Numbering(1, 0)
+		quote.AddLink("link", "https://example.com")
+		_, err := other.AddImage([]byte("GIF89a"), units.Emu(9525), units.Emu(9525))
+		require.NoError(t, err)
+		imageCount := rd.ImageCount
+
+		require.NoError(t, rd.AppendDocument(other, &AppendOptions{StyleConflict: tc.conflict, SectionBreak: true}))
+		assert.Equal(t, "first\n\ntitle\nquote link\n\n", bodyText(t, rd))
+		assert.Equal(t, imageCount+1, rd.ImageCount)
+
+		children := rd.Document.Body.Children
+		require.NotNil(t, children[1].Para.GetCT().Property.SectPr)
+		assert.Equal(t, tc.style, children[2].Para.GetCT().Property.Style.Val)
+		heading := rd.GetStyleByID(tc.style, stypes.StyleTypeParagraph)
+		require.NotNil(t, heading)
+		assert.Equal(t, tc.italic, heading.RunProp.Italic != nil)
+		quoteStyle := rd.GetStyleByID("Quote", stypes.StyleTypeParagraph)
+		require.NotNil(t, quoteStyle)
+		assert.Equal(t, tc.style, quoteStyle.BasedOn.Val)
+
+		rel := rd.Document.relationship(children[3].Para.GetCT().Children[1].Link.ID)
+		require.NotNil(t, rel)
+		assert.Equal(t, "https://example.com", rel.Target)
+
+		// The list is copied after the instances of the document
+		numID := children[3].Para.GetCT().Property.NumProp.NumID.Val
+		assert.Equal(t, 3, numID)
+		numbering := savedNumbering(t, rd)
+		assert.Contains(t, numbering,
+			`<w:abstractNum w:abstractNumId="203"><w:lvl w:ilvl="0"><w:start w:val="1"></w:start><w:numFmt w:val="decimal"></w:numFmt><w:pStyle w:val="Quote"></w:pStyle><w:lvlText w:val="%1."></w:lvlText></w:lvl></w:abstractNum>`)
+		assert.Contains(t, numbering, `<w:num w:numId="3"><w:abstractNumId w:val="203"></w:abstractNumId></w:num></w:numbering>`)
+		assert.Equal(t, "1.", newListLabeler(rd).label(children[3].Para.GetCT()))
+	}
+}
+
+func TestAppendDocumentNextStyle(t *testing.T) {
+	rd := appendTestDoc(t, true)
+	other := appendTestDoc(t, true)
+	other.AddParagraphStyle("LeadBody", "Lead Body").Italic(true)
+	require.NoError(t, other.AddParagraphStyle("Lead", "Lead").Next("LeadBody").Err())
+	other.AddParagraph("lead").Style("Lead")
+
+	require.NoError(t, rd.AppendDocument(other, nil))
+	lead := rd.GetStyleByID("Lead", stypes.StyleTypeParagraph)
+	require.NotNil(t, lead)
+	assert.Equal(t, "LeadBody", lead.Next.Val)
+	assert.NotNil(t, rd.GetStyleByID("LeadBody", stypes.StyleTypeParagraph))
+}
+
+func TestAppendDocumentMissingPart(t *testing.T) {
+	rd := appendTestDoc(t, true)
+	other := appendTestDoc(t, true)
+	other.AddParagraph("broken").AddLink("x", "https://example.com").ct.ID = "rId99"
+	assert.ErrorContains(t, rd.AppendDocument(other, nil), "relationship rId99 not found")
+	assert.Empty(t, rd.Document.Body.Children)
+	content, _ := rd.FileMap.Load(numberingPath)
+	assert.Equal(t, appendTestNumbering, string(content.([]byte)))
+}
+
+func TestImportElement(t *testing.T) {
+	rd := appendTestDoc(t, true)
+	anchor := rd.AddParagraph("first")
+
+	library := appendTestDoc(t, false)
+	library.DocStyles.StyleList = append(library.DocStyles.StyleList, ctypes.Style{
+		ID:   internal.ToPtr("Clause"),
+		Type: internal.ToPtr(stypes.StyleTypeParagraph),
+	})
+	tbl := library.AddTable()
+	clause := tbl.AddRow().AddCell().AddParagraph("clause ")
+	clause.Style("Clause")
+	clause.Numbering(1, 0)
+	clause.AddLink("link", "https://example.com")
+	library.AddParagraph("title").Style("Heading1")
+
+	el, err := rd.ImportElement(tbl, library)
+	require.NoError(t, err)
+	imported := el.(*Table)
+	require.NoError(t, imported.MoveAfter(anchor))
+	assert.Equal(t, "first\nclause link\n", bodyText(t, rd))
+	assert.NotNil(t, rd.GetStyleByID("Clause", stypes.StyleTypeParagraph))
+
+	p := imported.GetCT().RowContents[0].Row.Contents[0].Cell.Contents[0].Paragraph
+	assert.Equal(t, 2, p.Property.NumProp.NumID.Val)
+	rel := rd.Document.relationship(p.Children[1].Link.ID)
+	require.NotNil(t, rel)
+	assert.Equal(t, "https://example.com", rel.Target)
+
+	// The destination style is kept
+	el, err = rd.ImportElement(library.Document.Body.Children[1].Para, library)
+	require.NoError(t, err)
+	assert.Equal(t, "Heading1", el.(*Paragraph).GetCT().Property.Style.Val)
+	assert.Nil(t, rd.GetStyleByID("Heading1", stypes.StyleTypeParagraph).RunProp.Italic)
+
+	el, err = rd.ImportElement(anchor, rd)
+	require.NoError(t, err)
+	assert.Equal(t, "first", el.(*Paragraph).Text())
+}
@@ -0,0 +1,303 @@
+package docx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"godocx/internal"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+)
+
+// DirectFormattingOptions configures RootDoc.ConvertDirectFormatting.
+type DirectFormattingOptions struct {
+	// MinOccurrences is the number of paragraphs or runs that must share the same direct
+	// formatting for a style to be created, 2 by default.
+	MinOccurrences int
+
+	// Prefix starts the names of the created styles, "Auto" by default: paragraph styles are
+	// named "Auto Paragraph 1", "Auto Paragraph 2"... and character styles "Auto Character 1"...
+	// Their IDs are the names without spaces.
+	Prefix string
+}
+
+// ConvertDirectFormatting moves the direct formatting of the paragraphs and runs of the body
+// into styles. Paragraphs, respectively runs, sharing the same style and the same direct
+// formatting are given a new paragraph, respectively character, style based on their style and
+// holding their formatting, and their direct formatting is removed. It returns the IDs of the
+// created styles.
+//
+// The appearance of the document is kept: a paragraph or run whose effective properties would
+// change, such as bold text in a bold paragraph style where a bold character style would turn
+// the text regular, keeps its direct formatting. The numbering, the formatting of the paragraph
+// mark and the revisions stay on the paragraphs and runs. Headers and footers are left
+// unchanged.
+//
+// Example:
+//
+//	created, err := document.ConvertDirectFormatting(&docx.DirectFormattingOptions{MinOccurrences: 3})
+//	if err != nil {
+//		return err
+//	}
+//	fmt.Println(len(created), "styles created")
+func (rd *RootDoc) ConvertDirectFormatting(opts *DirectFormattingOptions) ([]string, error) {
+	if rd.DocStyles == nil {
+		return nil, fmt.Errorf("convert direct formatting: the document has no styles part")
+	}
+	minCount, prefix := 2, "Auto"
+	if opts != nil {
+		if opts.MinOccurrences > 0 {
+			minCount = opts.MinOccurrences
+		}
+		if opts.Prefix != "" {
+			prefix = opts.Prefix
+		}
+	}
+	// The new styles are based on the styles of the content, which must be defined
+	rd.addMissingBuiltinStyles()
+
+	var paras, runs []*formattedItem
+	var err error
+	rd.Walk(func(el Element, parents []Element) WalkAction {
+		var item *formattedItem
+		switch el := el.(type) {
+		case *Paragraph:
+			item = &formattedItem{para: el.GetCT(), cell: parentCell(parents)}
+			paras = append(paras, item)
+		case *Run:
+			item = &formattedItem{para: parentParagraph(parents), run: el.ct, cell: parentCell(parents)}
+			runs = append(runs, item)
+		default:
+			return WalkContinue
+		}
+		if err = rd.classifyFormatting(item); err != nil {
+			return WalkStop
+		}
+		return WalkContinue
+	})
+	if err != nil {
+		return nil, fmt.Errorf("convert direct formatting: %w", err)
+	}
+
+	var created []string
+	for _, items := range [][]*formattedItem{paras, runs} {
+		clusters := map[string][]*formattedItem{}
+		var keys []string
+		for _, item := range items {
+			if item.key == "" {
+				continue
+			}
+			if _, ok := clusters[item.key]; !ok {
+				keys = append(keys, item.key)
+			}
+			clusters[item.key] = append(clusters[item.key], item)
+		}
+
+		for _, key := range keys {
+			cluster := clusters[key]
+			if len(cluster) < minCount {
+				continue
+			}
+			id, err := rd.addFormattingStyle(cluster[0], prefix)
+			if err != nil {
+				return created, fmt.Errorf("convert direct formatting: %w", err)
+			}
+			var applied []*formattedItem
+			for _, item := range cluster {
+				ok, err := rd.applyFormattingStyle(item, id)
+				if err != nil {
+					return created, fmt.Errorf("convert direct formatting: %w", err)
+				}
+				if ok {
+					applied = append(applied, item)
+				}
+			}
+			if len(applied) < minCount {
+				for _, item := range applied {
+					item.restore()
+				}
+				rd.removeStyle(id)
+				continue
+			}
+			created = append(created, id)
+		}
+	}
+	return created, nil
+}
+
+// formattedItem is a paragraph or a run of the body with the direct formatting a style may hold.
+type formattedItem struct {
+	para *ctypes.Paragraph
+	run  *ctypes.Run // nil for a paragraph
+	cell *cellPosition
+
+	base       string                // Style the item has, or the default style
+	key        string                // Base style and direct formatting, empty without direct formatting
+	paraFormat *ctypes.ParagraphProp // Direct formatting of a paragraph
+	runFormat  *ctypes.RunProperty   // Direct formatting of a run
+	effective  []byte                // Effective properties before the conversion
+	paraProp   *ctypes.ParagraphProp // Properties of the paragraph before the conversion
+	runProp    *ctypes.RunProperty   // Properties of the run before the conversion
+}
+
+// classifyFormatting sets the base style, the direct formatting and the effective properties
+// of the item.
+func (rd *RootDoc) classifyFormatting(item *formattedItem) error {
+	var format any
+	if item.run == nil {
+		item.paraProp = item.para.Property
+		item.base = paraStyleID(item.para)
+		if item.base == "" {
+			item.base = rd.defaultStyleID(stypes.StyleTypeParagraph)
+		}
+		item.paraFormat = paraFormatting(item.para.Property)
+		if item.paraFormat == nil {
+			return nil
+		}
+		format = item.paraFormat
+	} else {
+		item.runProp = item.run.Property
+		if item.run.Property != nil && item.run.Property.Style != nil {
+			item.base = item.run.Property.Style.Val
+		} else {
+			item.base = rd.defaultStyleID(stypes.StyleTypeCharacter)
+		}
+		item.runFormat = runFormatting(item.run.Property)
+		if item.runFormat == nil {
+			return nil
+		}
+		format = item.runFormat
+	}
+
+	content, err := xml.Marshal(format)
+	if err != nil {
+		return err
+	}
+	item.key = item.base + "\x00" + string(content)
+	item.effective, err = rd.effectiveFormatting(item)
+	return err
+}
+
+// effectiveFormatting returns the effective properties of the item, without its style.
+func (rd *RootDoc) effectiveFormatting(item *formattedItem) ([]byte, error) {
+	if item.run == nil {
+		prop := rd.resolvedParaProp(item.para, item.cell)
+		prop.Style = nil
+		return xml.Marshal(prop)
+	}
+	prop := rd.resolvedRunProp(item.para, item.cell, item.run)
+	prop.Style = nil
+	return xml.Marshal(prop)
+}
+
+// paraFormatting returns a copy of the direct formatting of the paragraph a paragraph style
+// may hold, nil if there is none. The style, the numbering, the formatting of the paragraph
+// mark, the section and revision properties stay on the paragraph.
+func paraFormatting(prop *ctypes.ParagraphProp) *ctypes.ParagraphProp {
+	if prop == nil {
+		return nil
+	}
+	format := internal.DeepCopy(prop)
+	format.Style, format.NumProp, format.DivID, format.CnfStyle = nil, nil, nil, nil
+	format.RunProperty, format.SectPr, format.PPrChange = nil, nil, nil
+	if reflect.ValueOf(*format).IsZero() {
+		return nil
+	}
+	return format
+}
+
+// runFormatting returns a copy of the direct formatting of the run a character style may
+// hold, nil if there is none. The style, the revision marks and the math flag stay on the run.
+func runFormatting(prop *ctypes.RunProperty) *ctypes.RunProperty {
+	if prop == nil {
+		return nil
+	}
+	format := internal.DeepCopy(prop)
+	format.Ins, format.Del, format.Style, format.OMath = nil, nil, nil, nil
+	if reflect.ValueOf(*format).IsZero() {
+		return nil
+	}
+	return format
+}
+
+// addFormattingStyle adds the style holding the direct formatting of the item, based on its
+// style, and returns its ID. The style is numbered after the styles with the same prefix.
+func (rd *RootDoc) addFormattingStyle(item *formattedItem, prefix string) (string, error) {
+	styleType, label := stypes.StyleTypeParagraph, "Paragraph"
+	if item.run != nil {
+		styleType, label = stypes.StyleTypeCharacter, "Character"
+	}
+	var id, name string
+	for n := 1; ; n++ {
+		name = fmt.Sprintf("%s %s %d", prefix, label, n)
+		id = strings.ReplaceAll(name, " ", "")
+		if rd.styleIndex(id) < 0 && rd.StyleByName(name) == nil {
+			break
+		}
+	}
+
+	b := rd.addStyle(id, name, styleType)
+	if b.err != nil {
+		return "", b.err
+	}
+	style := b.style()
+	// Generated styles are left out of the style gallery
+	style.QFormat = nil
+	if item.base != "" {
+		style.BasedOn = ctypes.NewCTString(item.base)
+	}
+	if item.run == nil {
+		style.ParaProp = internal.DeepCopy(item.paraFormat)
+	} else {
+		style.RunProp = internal.DeepCopy(item.runFormat)
+	}
+	return id, nil
+}
+
+// applyFormattingStyle replaces the direct formatting of the item by the style with the given
+// ID and reports whether it did. The direct formatting is restored when the effective
+// properties of the item change.
+func (rd *RootDoc) applyFormattingStyle(item *formattedItem, id string) (bool, error) {
+	if item.run == nil {
+		prop := item.paraProp
+		item.para.Property = &ctypes.ParagraphProp{
+			Style:       ctypes.NewParagraphStyle(id),
+			NumProp:     prop.NumProp,
+			DivID:       prop.DivID,
+			CnfStyle:    prop.CnfStyle,
+			RunProperty: prop.RunProperty,
+			SectPr:      prop.SectPr,
+			PPrChange:   prop.PPrChange,
+		}
+	} else {
+		prop := item.runProp
+		item.run.Property = &ctypes.RunProperty{
+			Ins:   prop.Ins,
+			Del:   prop.Del,
+			Style: ctypes.NewRunStyle(id),
+			OMath: prop.OMath,
+		}
+	}
+
+	effective, err := rd.effectiveFormatting(item)
+	if err != nil {
+		item.restore()
+		return false, err
+	}
+	if string(effective) != string(item.effective) {
+		item.restore()
+		return false, nil
+	}
+	return true, nil
+}
+
+// restore sets back the properties of the item before the conversion.
+func (item *formattedItem) restore() {
+	if item.run == nil {
+		item.para.Property = item.paraProp
+	} else {
+		item.run.Property = item.runProp
+	}
+}
@@ -0,0 +1,74 @@
+package docx
+
+import (
+	"testing"
+
+	"godocx/internal"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvertDirectFormatting(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, ctypes.Style{
+		ID:      internal.ToPtr("Normal"),
+		Name:    ctypes.NewCTString("Normal"),
+		Type:    internal.ToPtr(stypes.StyleTypeParagraph),
+		Default: internal.ToPtr(stypes.OnOffOne),
+	})
+	require.NoError(t, rd.AddParagraphStyle("Strong", "Strong Paragraph").Bold(true).Err())
+
+	var runs []*Run
+	for i := range 3 {
+		p := rd.AddParagraph("")
+		p.Justification(stypes.JustificationCenter)
+		runs = append(runs, p.AddText("red").Color("FF0000").Size(14))
+		if i == 0 {
+			p.Numbering(5, 0)
+		}
+	}
+	rd.AddParagraph("single").Justification(stypes.JustificationRight)
+	strong := rd.AddParagraph("")
+	strong.Style("Strong")
+	bold := []*Run{strong.AddText("bold").Bold(true), strong.AddText("bold").Bold(true)}
+	table := rd.AddTable()
+	cellRun := table.AddRow().AddCell().AddParagraph("").AddText("red").Color("FF0000").Size(14)
+
+	before := runs[0].EffectiveProperties()
+	created, err := rd.ConvertDirectFormatting(nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"AutoParagraph1", "AutoCharacter1"}, created)
+
+	para := rd.GetStyleByID("AutoParagraph1", stypes.StyleTypeParagraph)
+	assert.Equal(t, "Normal", para.BasedOn.Val)
+	assert.Equal(t, stypes.JustificationCenter, para.ParaProp.Justification.Val)
+	assert.Nil(t, para.QFormat)
+	first := rd.Document.Body.Children[0].Para.GetCT().Property
+	assert.Equal(t, "AutoParagraph1", first.Style.Val)
+	assert.Nil(t, first.Justification)
+	assert.Equal(t, 5, first.NumProp.NumID.Val)
+	// A single paragraph keeps its formatting
+	assert.Equal(t, stypes.JustificationRight, rd.Document.Body.Children[3].Para.GetCT().Property.Justification.Val)
+
+	for _, run := range append(runs, &Run{root: rd, ct: cellRun.ct}) {
+		assert.Equal(t, "AutoCharacter1", run.ct.Property.Style.Val)
+		assert.Nil(t, run.ct.Property.Color)
+	}
+	after := runs[0].EffectiveProperties()
+	assert.Equal(t, before.Color, after.Color)
+	assert.Equal(t, before.Size, after.Size)
+	// A bold character style would turn the text of the bold paragraph regular
+	for _, run := range bold {
+		assert.Nil(t, run.ct.Property.Style)
+		assert.True(t, run.EffectiveProperties().Bold.Bool())
+	}
+	assert.Nil(t, rd.GetStyleByID("AutoCharacter2", stypes.StyleTypeCharacter))
+
+	// Converting again finds no shared formatting, the numbering continues after existing styles
+	created, err = rd.ConvertDirectFormatting(&DirectFormattingOptions{MinOccurrences: 1, Prefix: "Imported"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ImportedParagraph1"}, created)
+}
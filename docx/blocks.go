@@ -0,0 +1,409 @@
+package docx
+
+import (
+	"errors"
+	"slices"
+
+	"godocx/wml/ctypes"
+)
+
+// Block is a paragraph or a table, the block level content of the document body and of table cells.
+type Block interface {
+	Element
+	blockChild() DocumentChild
+}
+
+func (p *Paragraph) blockChild() DocumentChild { return DocumentChild{Para: p} }
+func (t *Table) blockChild() DocumentChild     { return DocumentChild{Table: t} }
+
+// ErrNotInDocument is returned when an element is not part of the document body.
+var ErrNotInDocument = errors.New("element not found in the document body")
+
+// blockList is the list of paragraphs and tables of the document body or of a table cell.
+type blockList struct {
+	body *Body
+	cell *ctypes.Cell
+}
+
+// index returns the position of the block in the list, -1 if it is not in the list.
+func (bl blockList) index(b DocumentChild) int {
+	if bl.body != nil {
+		return slices.IndexFunc(bl.body.Children, func(child DocumentChild) bool {
+			return sameBlock(child.Para, child.Table, b)
+		})
+	}
+	return slices.IndexFunc(bl.cell.Contents, func(content ctypes.TCBlockContent) bool {
+		return sameBlockCT(content.Paragraph, content.Table, b)
+	})
+}
+
+// insert inserts the block at the given position.
+func (bl blockList) insert(i int, b DocumentChild) {
+	if bl.body != nil {
+		bl.body.Children = slices.Insert(bl.body.Children, i, b)
+		return
+	}
+	content := ctypes.TCBlockContent{}
+	if b.Para != nil {
+		content.Paragraph = b.Para.GetCT()
+	}
+	if b.Table != nil {
+		content.Table = b.Table.GetCT()
+	}
+	bl.cell.Contents = slices.Insert(bl.cell.Contents, i, content)
+}
+
+// remove removes the block at the given position. A cell left empty gets an empty paragraph
+// as a cell must hold at least one paragraph.
+func (bl blockList) remove(i int) {
+	if bl.body != nil {
+		bl.body.Children = slices.Delete(bl.body.Children, i, i+1)
+		return
+	}
+	bl.cell.Contents = slices.Delete(bl.cell.Contents, i, i+1)
+	if len(bl.cell.Contents) == 0 {
+		bl.cell.Contents = append(bl.cell.Contents, ctypes.TCBlockContent{Paragraph: &ctypes.Paragraph{}})
+	}
+}
+
+func sameBlock(p *Paragraph, t *Table, b DocumentChild) bool {
+	return (p != nil && b.Para != nil && p.GetCT() == b.Para.GetCT()) || (t != nil && b.Table != nil && t.GetCT() == b.Table.GetCT())
+}
+
+func sameBlockCT(p *ctypes.Paragraph, t *ctypes.Table, b DocumentChild) bool {
+	return (p != nil && b.Para != nil && p == b.Para.GetCT()) || (t != nil && b.Table != nil && t == b.Table.GetCT())
+}
+
+// locateBlock returns the list holding the block in the document body, searching the cells of
+// the tables, and its position in the list.
+func (rd *RootDoc) locateBlock(b DocumentChild) (blockList, int, error) {
+	if rd == nil {
+		return blockList{}, -1, ErrNotInDocument
+	}
+	body := blockList{body: rd.Document.Body}
+	if i := body.index(b); i >= 0 {
+		return body, i, nil
+	}
+	for _, child := range rd.Document.Body.Children {
+		if child.Table == nil {
+			continue
+		}
+		if bl, i := locateInTable(child.Table.GetCT(), b); i >= 0 {
+			return bl, i, nil
+		}
+	}
+	return blockList{}, -1, ErrNotInDocument
+}
+
+func locateInTable(t *ctypes.Table, b DocumentChild) (blockList, int) {
+	for _, rc := range t.RowContents {
+		if rc.Row == nil {
+			continue
+		}
+		for _, cc := range rc.Row.Contents {
+			if cc.Cell == nil {
+				continue
+			}
+			cell := blockList{cell: cc.Cell}
+			if i := cell.index(b); i >= 0 {
+				return cell, i
+			}
+			for _, content := range cc.Cell.Contents {
+				if content.Table == nil {
+					continue
+				}
+				if bl, i := locateInTable(content.Table, b); i >= 0 {
+					return bl, i
+				}
+			}
+		}
+	}
+	return blockList{}, -1
+}
+
+// insertBlock inserts a block before (offset 0) or after (offset 1) the anchor block.
+func (rd *RootDoc) insertBlock(anchor, b DocumentChild, offset int) error {
+	bl, i, err := rd.locateBlock(anchor)
+	if err != nil {
+		return err
+	}
+	bl.insert(i+offset, b)
+	return nil
+}
+
+// moveBlock moves a block before (offset 0) or after (offset 1) the anchor block. A block
+// outside the document is inserted.
+func (rd *RootDoc) moveBlock(b, anchor DocumentChild, offset int) error {
+	if sameBlock(anchor.Para, anchor.Table, b) {
+		return nil
+	}
+	if _, _, err := rd.locateBlock(anchor); err != nil {
+		return err
+	}
+	if b.Table != nil {
+		if _, i := locateInTable(b.Table.GetCT(), anchor); i >= 0 {
+			return errors.New("cannot move a table into itself")
+		}
+	}
+
+	if from, i, err := rd.locateBlock(b); err == nil {
+		from.remove(i)
+	}
+	return rd.insertBlock(anchor, b, offset)
+}
+
+// InsertParagraphBefore adds a paragraph with the given text before the paragraph, in the
+// document body or in the table cell holding the paragraph.
+//
+// Example:
+//
+//	p := document.FindParagraphs(docx.ContainingText("Signature"))[0]
+//	note, err := p.InsertParagraphBefore("Please sign below.")
+func (p *Paragraph) InsertParagraphBefore(text string) (*Paragraph, error) {
+	return insertParagraph(p, text, 0)
+}
+
+// InsertParagraphAfter adds a paragraph with the given text after the paragraph, in the
+// document body or in the table cell holding the paragraph.
+func (p *Paragraph) InsertParagraphAfter(text string) (*Paragraph, error) {
+	return insertParagraph(p, text, 1)
+}
+
+// InsertTableBefore adds an empty table before the paragraph.
+func (p *Paragraph) InsertTableBefore() (*Table, error) {
+	return insertTable(p, 0)
+}
+
+// InsertTableAfter adds an empty table after the paragraph.
+func (p *Paragraph) InsertTableAfter() (*Table, error) {
+	return insertTable(p, 1)
+}
+
+// Remove removes the paragraph from the document body or from the table cell holding it. A
+// cell left empty gets an empty paragraph.
+func (p *Paragraph) Remove() error {
+	return removeBlock(p)
+}
+
+// MoveBefore moves the paragraph before the anchor paragraph or table. A paragraph which is
+// not part of the document, like one returned by a previous call to Remove, is inserted.
+func (p *Paragraph) MoveBefore(anchor Block) error {
+	return blockRoot(anchor).moveBlock(p.blockChild(), anchor.blockChild(), 0)
+}
+
+// MoveAfter moves the paragraph after the anchor paragraph or table. A paragraph which is not
+// part of the document is inserted.
+func (p *Paragraph) MoveAfter(anchor Block) error {
+	return blockRoot(anchor).moveBlock(p.blockChild(), anchor.blockChild(), 1)
+}
+
+// InsertParagraphBefore adds a paragraph with the given text before the table, in the
+// document body or in the table cell holding the table.
+func (t *Table) InsertParagraphBefore(text string) (*Paragraph, error) {
+	return insertParagraph(t, text, 0)
+}
+
+// InsertParagraphAfter adds a paragraph with the given text after the table.
+func (t *Table) InsertParagraphAfter(text string) (*Paragraph, error) {
+	return insertParagraph(t, text, 1)
+}
+
+// InsertTableBefore adds an empty table before the table.
+func (t *Table) InsertTableBefore() (*Table, error) {
+	return insertTable(t, 0)
+}
+
+// InsertTableAfter adds an empty table after the table.
+func (t *Table) InsertTableAfter() (*Table, error) {
+	return insertTable(t, 1)
+}
+
+// Remove removes the table from the document body or from the table cell holding it.
+func (t *Table) Remove() error {
+	return removeBlock(t)
+}
+
+// MoveBefore moves the table before the anchor paragraph or table. A table which is not part
+// of the document, like one created with NewTable, is inserted.
+func (t *Table) MoveBefore(anchor Block) error {
+	return blockRoot(anchor).moveBlock(t.blockChild(), anchor.blockChild(), 0)
+}
+
+// MoveAfter moves the table after the anchor paragraph or table. A table which is not part of
+// the document is inserted.
+func (t *Table) MoveAfter(anchor Block) error {
+	return blockRoot(anchor).moveBlock(t.blockChild(), anchor.blockChild(), 1)
+}
+
+func blockRoot(b Block) *RootDoc {
+	child := b.blockChild()
+	if child.Para != nil {
+		return child.Para.root
+	}
+	return child.Table.root
+}
+
+func insertParagraph(anchor Block, text string, offset int) (*Paragraph, error) {
+	root := blockRoot(anchor)
+	p := newParagraph(root)
+	if text != "" {
+		p.AddText(text)
+	}
+	if err := root.insertBlock(anchor.blockChild(), p.blockChild(), offset); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func insertTable(anchor Block, offset int) (*Table, error) {
+	root := blockRoot(anchor)
+	t := &Table{root: root, ct: *ctypes.DefaultTable()}
+	if err := root.insertBlock(anchor.blockChild(), t.blockChild(), offset); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func removeBlock(b Block) error {
+	bl, i, err := blockRoot(b).locateBlock(b.blockChild())
+	if err != nil {
+		return err
+	}
+	bl.remove(i)
+	return nil
+}
+
+// InsertParagraph adds a paragraph with the given text to the cell at the given position
+// among its paragraphs and tables, len(contents) appending it.
+func (c *Cell) InsertParagraph(index int, text string) (*Paragraph, error) {
+	if index < 0 || index > len(c.GetCT().Contents) {
+		return nil, errors.New("cell position out of range")
+	}
+	p := newParagraph(c.root)
+	if text != "" {
+		p.AddText(text)
+	}
+	blockList{cell: c.GetCT()}.insert(index, p.blockChild())
+	return p, nil
+}
+
+// Blocks returns the paragraphs and tables of the cell.
+func (c *Cell) Blocks() []Block {
+	var blocks []Block
+	for _, content := range c.GetCT().Contents {
+		if content.Paragraph != nil {
+			blocks = append(blocks, &Paragraph{root: c.root, ref: content.Paragraph})
+		}
+		if content.Table != nil {
+			blocks = append(blocks, &Table{root: c.root, ref: content.Table})
+		}
+	}
+	return blocks
+}
+
+// runList is the list holding a run: the children of a paragraph or of a hyperlink.
+type runList struct {
+	children *[]ctypes.ParagraphChild
+}
+
+// locateRun returns the list holding the run in the paragraph and its position in the list.
+func (p *Paragraph) locateRun(r *Run) (runList, int, error) {
+	if r == nil {
+		return runList{}, -1, errors.New("nil run")
+	}
+	list := runList{children: &p.GetCT().Children}
+	if i := list.index(r.ct); i >= 0 {
+		return list, i, nil
+	}
+	for _, child := range p.GetCT().Children {
+		link := child.Link
+		if link == nil {
+			continue
+		}
+		if link.Run == r.ct {
+			return runList{}, -1, errors.New("run held apart from the children of its hyperlink")
+		}
+		list := runList{children: &link.Children}
+		if i := list.index(r.ct); i >= 0 {
+			return list, i, nil
+		}
+	}
+	return runList{}, -1, errors.New("run not found in the paragraph")
+}
+
+func (rl runList) index(r *ctypes.Run) int {
+	return slices.IndexFunc(*rl.children, func(child ctypes.ParagraphChild) bool {
+		return child.Run == r
+	})
+}
+
+// InsertRunBefore adds a run with the given text before the anchor run of the paragraph,
+// within the same hyperlink if the anchor is part of one.
+//
+// Example:
+//
+//	run, err := p.InsertRunBefore(anchor, "Dear ")
+//	run.Bold(true)
+func (p *Paragraph) InsertRunBefore(anchor *Run, text string) (*Run, error) {
+	return p.insertRun(anchor, text, 0)
+}
+
+// InsertRunAfter adds a run with the given text after the anchor run of the paragraph, within
+// the same hyperlink if the anchor is part of one.
+func (p *Paragraph) InsertRunAfter(anchor *Run, text string) (*Run, error) {
+	return p.insertRun(anchor, text, 1)
+}
+
+func (p *Paragraph) insertRun(anchor *Run, text string, offset int) (*Run, error) {
+	rl, i, err := p.locateRun(anchor)
+	if err != nil {
+		return nil, err
+	}
+	run := &ctypes.Run{}
+	if text != "" {
+		run.Children = append(run.Children, ctypes.RunChild{Text: ctypes.TextFromString(text)})
+	}
+	*rl.children = slices.Insert(*rl.children, i+offset, ctypes.ParagraphChild{Run: run})
+	return newRun(p.root, p.GetCT(), run), nil
+}
+
+// RemoveRun removes the run from the paragraph.
+func (p *Paragraph) RemoveRun(r *Run) error {
+	rl, i, err := p.locateRun(r)
+	if err != nil {
+		return err
+	}
+	*rl.children = slices.Delete(*rl.children, i, i+1)
+	return nil
+}
+
+// MoveRunBefore moves a run of the paragraph before the anchor run. A run which is not part of
+// the paragraph is inserted.
+func (p *Paragraph) MoveRunBefore(r, anchor *Run) error {
+	return p.moveRun(r, anchor, 0)
+}
+
+// MoveRunAfter moves a run of the paragraph after the anchor run. A run which is not part of
+// the paragraph is inserted.
+func (p *Paragraph) MoveRunAfter(r, anchor *Run) error {
+	return p.moveRun(r, anchor, 1)
+}
+
+func (p *Paragraph) moveRun(r, anchor *Run, offset int) error {
+	if r == nil || anchor == nil {
+		return errors.New("nil run")
+	}
+	if r.ct == anchor.ct {
+		return nil
+	}
+	if _, _, err := p.locateRun(anchor); err != nil {
+		return err
+	}
+	if rl, i, err := p.locateRun(r); err == nil {
+		*rl.children = slices.Delete(*rl.children, i, i+1)
+	}
+	rl, i, _ := p.locateRun(anchor)
+	*rl.children = slices.Insert(*rl.children, i+offset, ctypes.ParagraphChild{Run: r.ct})
+	r.para = p.GetCT()
+	return nil
+}
@@ -0,0 +1,166 @@
+package docx
+
+import (
+	"testing"
+
+	"godocx/common/units"
+	"godocx/wml/ctypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func bodyText(t *testing.T, rd *RootDoc) string {
+	t.Helper()
+	text, err := rd.Text(nil)
+	require.NoError(t, err)
+	return text
+}
+
+func TestInsertRemoveMoveBlocks(t *testing.T) {
+	rd := setupRootDoc(t)
+	first := rd.AddParagraph("first")
+	last := rd.AddParagraph("last")
+
+	middle, err := first.InsertParagraphAfter("middle")
+	require.NoError(t, err)
+	_, err = first.InsertParagraphBefore("start")
+	require.NoError(t, err)
+	assert.Equal(t, "start\nfirst\nmiddle\nlast\n", bodyText(t, rd))
+
+	tbl, err := middle.InsertTableAfter()
+	require.NoError(t, err)
+	cell := tbl.AddRow().AddCell()
+	inner := cell.AddParagraph("in cell")
+	assert.Equal(t, "start\nfirst\nmiddle\nin cell\nlast\n", bodyText(t, rd))
+
+	// Cell paragraphs are found like body paragraphs
+	_, err = inner.InsertParagraphAfter("below")
+	require.NoError(t, err)
+	assert.Equal(t, "in cell\nbelow", cell.Text())
+	require.NoError(t, last.MoveBefore(inner))
+	assert.Equal(t, "last\nin cell\nbelow", cell.Text())
+	assert.Equal(t, "start\nfirst\nmiddle\nlast in cell below\n", bodyText(t, rd))
+
+	require.NoError(t, tbl.MoveBefore(first))
+	require.NoError(t, middle.Remove())
+	assert.Equal(t, "start\nlast in cell below\nfirst\n", bodyText(t, rd))
+	assert.ErrorIs(t, middle.Remove(), ErrNotInDocument)
+
+	// A removed paragraph can be inserted again
+	require.NoError(t, middle.MoveAfter(tbl))
+	assert.Equal(t, "start\nlast in cell below\nmiddle\nfirst\n", bodyText(t, rd))
+
+	// Cells keep a paragraph
+	for _, block := range cell.Blocks() {
+		require.NoError(t, block.(*Paragraph).Remove())
+	}
+	require.Len(t, cell.Blocks(), 1)
+	assert.Empty(t, cell.Text())
+
+	assert.Error(t, tbl.MoveBefore(cell.Blocks()[0]))
+
+	p, err := cell.InsertParagraph(0, "again")
+	require.NoError(t, err)
+	assert.Equal(t, "again\n", cell.Text())
+	assert.Equal(t, "again", p.Text())
+}
+
+func TestInsertRemoveMoveRuns(t *testing.T) {
+	rd := setupRootDoc(t)
+	p := rd.AddParagraph("")
+	world := p.AddText("world")
+	link := p.AddLink("link", "https://example.com")
+
+	hello, err := p.InsertRunBefore(world, "hello ")
+	require.NoError(t, err)
+	assert.Equal(t, "hello worldlink", p.Text())
+
+	var linkRun *Run
+	for run := range p.Runs() {
+		linkRun = run
+	}
+	_, err = p.InsertRunAfter(linkRun, "!")
+	require.NoError(t, err)
+	assert.Len(t, link.ct.Runs(), 2)
+
+	require.NoError(t, p.MoveRunBefore(world, hello))
+	assert.Equal(t, "worldhello link!", p.Text())
+	require.NoError(t, p.RemoveRun(hello))
+	assert.Equal(t, "worldlink!", p.Text())
+	assert.Error(t, p.RemoveRun(hello))
+	assert.Error(t, p.MoveRunAfter(world, nil))
+}
+
+func TestLocateRunKeepsHyperlinks(t *testing.T) {
+	rd := setupRootDoc(t)
+	p := rd.AddParagraph("")
+	link := &ctypes.Hyperlink{Run: &ctypes.Run{}}
+	p.GetCT().Children = append(p.GetCT().Children, ctypes.ParagraphChild{Link: link})
+
+	_, err := p.InsertRunAfter(newRun(rd, p.GetCT(), link.Run), "x")
+	assert.Error(t, err)
+	// A failed lookup leaves the hyperlink as it was
+	assert.NotNil(t, link.Run)
+	assert.Empty(t, link.Children)
+}
+
+func TestCloneBlocks(t *testing.T) {
+	rd := setupRootDoc(t)
+	p := rd.AddParagraph("item")
+	tbl := rd.AddTable()
+	tbl.AddRow().AddCell().AddParagraph("cell")
+
+	copied := p.Clone()
+	copied.AddText(" copy")
+	assert.Equal(t, "item", p.Text())
+	assert.ErrorIs(t, copied.Remove(), ErrNotInDocument)
+	require.NoError(t, copied.MoveAfter(tbl))
+
+	tblCopy := tbl.Clone()
+	require.NoError(t, tblCopy.MoveBefore(p))
+	assert.Equal(t, "cell\nitem\ncell\nitem copy\n", bodyText(t, rd))
+}
+
+func TestCloneBlocks_IDs(t *testing.T) {
+	rd := setupRootDoc(t)
+	pic, err := rd.AddImage([]byte("GIF89a"), units.Emu(9525), units.Emu(9525))
+	require.NoError(t, err)
+	p := pic.Para
+	p.ct.Children = append([]ctypes.ParagraphChild{{BookmarkStart: &ctypes.BookmarkStart{ID: 3, Name: "logo"}}}, p.ct.Children...)
+	p.ct.Children = append(p.ct.Children,
+		ctypes.ParagraphChild{BookmarkEnd: &ctypes.BookmarkEnd{ID: 3}},
+		ctypes.ParagraphChild{BookmarkStart: &ctypes.BookmarkStart{ID: 4, Name: "open"}})
+
+	first, second := p.Clone(), p.Clone()
+	tbl := rd.AddTable()
+	tbl.AddRow().AddCell().AddParagraph("cell").GetCT().Children = append([]ctypes.ParagraphChild{},
+		ctypes.ParagraphChild{BookmarkStart: &ctypes.BookmarkStart{ID: 5, Name: "cell"}},
+		ctypes.ParagraphChild{BookmarkEnd: &ctypes.BookmarkEnd{ID: 5}})
+	tblCopy := tbl.Clone()
+
+	docPrID := func(p *Paragraph) uint64 {
+		for run := range p.Runs() {
+			for _, child := range run.ct.Children {
+				if child.Drawing != nil {
+					return child.Drawing.Inline[0].DocProp.ID
+				}
+			}
+		}
+		return 0
+	}
+	ids := []uint64{docPrID(p), docPrID(first), docPrID(second)}
+	assert.NotEqual(t, ids[0], ids[1])
+	assert.NotEqual(t, ids[0], ids[2])
+	assert.NotEqual(t, ids[1], ids[2])
+
+	// The open bookmark is dropped from the copies, the others are renumbered and renamed
+	children := first.GetCT().Children
+	require.NotNil(t, children[0].BookmarkStart)
+	assert.Equal(t, ctypes.BookmarkStart{ID: 5, Name: "logo_5"}, *children[0].BookmarkStart)
+	assert.Equal(t, ctypes.BookmarkEnd{ID: 5}, *children[len(children)-1].BookmarkEnd)
+	assert.Equal(t, 6, second.GetCT().Children[0].BookmarkStart.ID)
+	cell := tblCopy.GetCT().RowContents[0].Row.Contents[0].Cell.Contents[0].Paragraph
+	assert.Equal(t, ctypes.BookmarkStart{ID: 7, Name: "cell_7"}, *cell.Children[0].BookmarkStart)
+	assert.Equal(t, 7, cell.Children[1].BookmarkEnd.ID)
+}
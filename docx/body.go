@@ -36,21 +36,8 @@ func (b *Body) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
 		return
 	}
 
-	if b.Children != nil {
-		for _, child := range b.Children {
-			if child.Para != nil {
-				if err = child.Para.ct.MarshalXML(e, xml.StartElement{}); err != nil {
-					return
-				}
-			}
-
-			if child.Table != nil {
-				if err = child.Table.ct.MarshalXML(e, xml.StartElement{}); err != nil {
-					return
-				}
-			}
-
-		}
+	if err = marshalBlockChildren(e, b.Children); err != nil {
+		return
 	}
 
 	if b.SectPr != nil {
@@ -75,18 +62,12 @@ func (b *Body) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error)
 		switch elem := currentToken.(type) {
 		case xml.StartElement:
 			switch elem.Name.Local {
-			case "p":
-				para := newParagraph(b.root)
-				if err = para.unmarshalXML(d, elem); err != nil {
-					return err
-				}
-				b.Children = append(b.Children, DocumentChild{Para: para})
-			case "tbl":
-				tbl := NewTable(b.root)
-				if err = tbl.unmarshalXML(d, elem); err != nil {
+			case "p", "tbl":
+				var child DocumentChild
+				if child, err = decodeBlockChild(b.root, d, elem); err != nil {
 					return err
 				}
-				b.Children = append(b.Children, DocumentChild{Table: tbl})
+				b.Children = append(b.Children, child)
 			case "sectPr":
 				if b.SectPr != nil {
 					return errors.New("unexpected two sections in the body")
@@ -105,3 +86,42 @@ func (b *Body) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error)
 		}
 	}
 }
+
+// decodeBlockChild decodes a paragraph (w:p) or table (w:tbl) element into a document child.
+func decodeBlockChild(root *RootDoc, d *xml.Decoder, elem xml.StartElement) (child DocumentChild, err error) {
+	switch elem.Name.Local {
+	case "p":
+		para := newParagraph(root)
+		if err = para.unmarshalXML(d, elem); err != nil {
+			return
+		}
+		child.Para = para
+	case "tbl":
+		tbl := NewTable(root)
+		if err = tbl.unmarshalXML(d, elem); err != nil {
+			return
+		}
+		child.Table = tbl
+	default:
+		err = d.Skip()
+	}
+	return
+}
+
+// marshalBlockChildren encodes the paragraphs and tables of a block level container.
+func marshalBlockChildren(e *xml.Encoder, children []DocumentChild) (err error) {
+	for _, child := range children {
+		if child.Para != nil {
+			if err = child.Para.GetCT().MarshalXML(e, xml.StartElement{}); err != nil {
+				return
+			}
+		}
+
+		if child.Table != nil {
+			if err = child.Table.GetCT().MarshalXML(e, xml.StartElement{}); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,164 @@
+package docx
+
+import (
+	_ "embed"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"godocx/internal"
+	"godocx/wml/ctypes"
+)
+
+// builtinStylesXML holds the definitions of the Word built-in styles: headings, lists, captions,
+// table of contents levels, quotes, emphasis and the grid, list and shading table styles.
+//
+//go:embed builtin_styles.xml
+var builtinStylesXML []byte
+
+// builtinStyles returns the catalogue of the Word built-in styles.
+var builtinStyles = sync.OnceValue(func() []ctypes.Style {
+	var styles ctypes.Styles
+	if err := xml.Unmarshal(builtinStylesXML, &styles); err != nil {
+		panic(fmt.Sprintf("built-in styles: %v", err))
+	}
+	return styles.StyleList
+})
+
+// builtinStyle returns the built-in style with the given ID, or with the given name ignoring
+// case, nil if there is none.
+func builtinStyle(idOrName string) *ctypes.Style {
+	styles := builtinStyles()
+	for i, style := range styles {
+		if *style.ID == idOrName {
+			return &styles[i]
+		}
+	}
+	for i, style := range styles {
+		if style.Name != nil && strings.EqualFold(style.Name.Val, idOrName) {
+			return &styles[i]
+		}
+	}
+	return nil
+}
+
+// listStyleRe matches the IDs of the built-in list styles, numbered by a list of the document.
+var listStyleRe = regexp.MustCompile(`^List(Bullet|Number)([2-5]?)$`)
+
+// AddBuiltinStyle adds the definition of a Word built-in style to the document, unless the
+// document already defines it, and returns its ID. A style defined by the document under a
+// localised ID, such as "berschrift1" for "heading 1", is found by its name. The style is
+// given by its ID, such as "ListBullet", or its name, such as "List Bullet". The styles it is
+// based on, linked to or followed by are added as well. The list styles are numbered by new
+// lists of the document.
+//
+// Built-in styles referenced by the content but missing from the document are added when the
+// document is saved, so that calling AddBuiltinStyle is only needed to read the style before.
+//
+// Example:
+//
+//	id, err := document.AddBuiltinStyle("Intense Quote")
+//	if err != nil {
+//		return err
+//	}
+//	document.AddParagraph("Notice").Style(id)
+func (rd *RootDoc) AddBuiltinStyle(idOrName string) (string, error) {
+	if rd.DocStyles == nil {
+		return "", fmt.Errorf("add built-in style %q: the document has no styles part", idOrName)
+	}
+	style := builtinStyle(idOrName)
+	if style == nil {
+		return "", fmt.Errorf("add built-in style %q: not a built-in style", idOrName)
+	}
+	return rd.addBuiltinStyle(style), nil
+}
+
+// addBuiltinStyle adds a copy of the built-in style with the styles it refers to, unless the
+// document defines it, and returns its ID in the document.
+func (rd *RootDoc) addBuiltinStyle(style *ctypes.Style) string {
+	id := *style.ID
+	if found := rd.lookupStyle(id, *style.Type); found != nil {
+		return *found.ID
+	}
+
+	copied := internal.DeepCopy(style)
+	if isDefaultStyle(copied) && rd.defaultStyleID(*copied.Type) != "" {
+		copied.Default = nil
+	}
+	if m := listStyleRe.FindStringSubmatch(id); m != nil {
+		abstractNumID := DecimalListID
+		if m[1] == "Bullet" {
+			abstractNumID = BulletListID
+		}
+		level, _ := strconv.Atoi(m[2])
+		if rd.Numbering == nil {
+			rd.Numbering = NewNumberingManager(rd)
+		}
+		if copied.ParaProp == nil {
+			copied.ParaProp = &ctypes.ParagraphProp{}
+		}
+		copied.ParaProp.NumProp = &ctypes.NumProp{
+			ILvl:  ctypes.NewDecimalNum(max(level-1, 0)),
+			NumID: ctypes.NewDecimalNum(rd.NewListInstance(abstractNumID)),
+		}
+	}
+	rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, *copied)
+
+	// The styles referred to map to their ID in the document, empty for a style the document lacks
+	refs := map[string]string{}
+	for _, ref := range []*ctypes.CTString{copied.BasedOn, copied.Link, copied.Next} {
+		if ref == nil || ref.Val == id || rd.styleIndex(ref.Val) >= 0 {
+			continue
+		}
+		if target := builtinStyle(ref.Val); target != nil && *target.ID == ref.Val {
+			refs[ref.Val] = rd.addBuiltinStyle(target)
+		} else {
+			refs[ref.Val] = ""
+		}
+	}
+
+	// The style list may have moved while adding the styles referred to
+	added := &rd.DocStyles.StyleList[rd.styleIndex(id)]
+	for _, ref := range []**ctypes.CTString{&added.BasedOn, &added.Link, &added.Next} {
+		if *ref == nil {
+			continue
+		}
+		if target, ok := refs[(*ref).Val]; ok && target == "" {
+			*ref = nil
+		} else if ok {
+			(*ref).Val = target
+		}
+	}
+	return id
+}
+
+// addMissingBuiltinStyles adds the built-in styles referenced by the body and the headers and
+// footers that the document does not define. A reference to a built-in style by name is
+// replaced by its ID, and a reference to a built-in style the document defines under a
+// localised ID by that ID.
+func (rd *RootDoc) addMissingBuiltinStyles() {
+	if rd.DocStyles == nil {
+		return
+	}
+	add := func(ref *ctypes.CTString) {
+		if rd.styleIndex(ref.Val) >= 0 {
+			return
+		}
+		style := builtinStyle(ref.Val)
+		if style == nil {
+			return
+		}
+		if found := rd.lookupStyle(ref.Val, *style.Type); found != nil {
+			ref.Val = *found.ID
+			return
+		}
+		ref.Val = rd.addBuiltinStyle(style)
+	}
+	eachStyleRef(rd.Document.Body.Children, add)
+	for _, hf := range rd.hdrFtr {
+		eachStyleRef(hf.Children, add)
+	}
+}
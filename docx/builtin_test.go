@@ -0,0 +1,62 @@
+package docx
+
+import (
+	"testing"
+
+	"godocx/wml/stypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddBuiltinStyle(t *testing.T) {
+	rd := setupRootDoc(t)
+
+	id, err := rd.AddBuiltinStyle("Quote")
+	require.NoError(t, err)
+	assert.Equal(t, "Quote", id)
+	// The style it is based on and its linked style are added, Normal staying the default style
+	assert.Equal(t, []string{"Quote", "Normal", "QuoteChar", "DefaultParagraphFont"}, styleIDs(rd))
+	assert.Equal(t, "Normal", rd.defaultStyleID(stypes.StyleTypeParagraph))
+
+	id, err = rd.AddBuiltinStyle("list number 2")
+	require.NoError(t, err)
+	assert.Equal(t, "ListNumber2", id)
+	numProp := rd.GetStyleByID("ListNumber2", stypes.StyleTypeParagraph).ParaProp.NumProp
+	assert.Equal(t, 1, numProp.ILvl.Val)
+	assert.NotZero(t, numProp.NumID.Val)
+
+	id, err = rd.AddBuiltinStyle("TOC3")
+	require.NoError(t, err)
+	assert.Equal(t, 440, *rd.GetStyleByID(id, stypes.StyleTypeParagraph).ParaProp.Indent.Left)
+
+	// A style of the document is kept
+	count := len(rd.DocStyles.StyleList)
+	_, err = rd.AddBuiltinStyle("Quote")
+	require.NoError(t, err)
+	assert.Len(t, rd.DocStyles.StyleList, count)
+
+	_, err = rd.AddBuiltinStyle("Fancy Heading")
+	assert.ErrorContains(t, err, "not a built-in style")
+}
+
+func TestAddMissingBuiltinStyles(t *testing.T) {
+	rd := setupRootDoc(t)
+	require.NoError(t, rd.AddParagraphStyle("Normal", "Normal").Err())
+
+	rd.AddParagraph("item").Style("List Bullet")
+	rd.AddParagraph("custom").Style("Custom")
+	rd.AddParagraph("").AddText("link").Style("Hyperlink")
+	table := rd.AddTable()
+	table.Style("LightList-Accent4")
+
+	rd.addMissingBuiltinStyles()
+	assert.Equal(t, "ListBullet", rd.Document.Body.Children[0].Para.GetCT().Property.Style.Val)
+	assert.Equal(t, "Custom", rd.Document.Body.Children[1].Para.GetCT().Property.Style.Val)
+	assert.Equal(t, []string{"Normal", "LightList-Accent4", "TableNormal", "ListBullet", "Hyperlink", "DefaultParagraphFont"}, styleIDs(rd))
+
+	grid := rd.GetStyleByID("LightList-Accent4", stypes.StyleTypeTable)
+	require.NotNil(t, grid)
+	assert.NotEmpty(t, grid.TableStylePr)
+	assert.Equal(t, "TableNormal", grid.BasedOn.Val)
+}
@@ -0,0 +1,207 @@
+package docx
+
+import (
+	"slices"
+	"strconv"
+
+	"godocx/dml"
+	"godocx/dml/dmlpic"
+	"godocx/internal"
+	"godocx/wml/ctypes"
+)
+
+// Clone returns an independent copy of the document, so that a template parsed once can be
+// cloned for each document generated from it.
+//
+// The body, styles, numbering instances, relationships and content types are copied. The
+// content of the other parts of the package, such as images, is shared: parts are replaced
+// rather than changed in place, so a change to a part of one document is not seen by the
+// other. Clone may be called concurrently on a document that is not being changed.
+//
+// Example:
+//
+//	template, err := godocx.OpenDocument("letter.docx")
+//	...
+//	for _, customer := range customers {
+//		letter := template.Clone()
+//		letter.AddParagraph("Dear " + customer.Name)
+//		err = letter.SaveTo(customer.ID + ".docx")
+//	}
+func (rd *RootDoc) Clone() *RootDoc {
+	doc := rd.clonePackage()
+	doc.Path = rd.Path
+	doc.Document.Body.Children = cloneChildren(doc, rd.Document.Body.Children)
+	if rd.Document.Body.SectPr != nil {
+		doc.Document.Body.SectPr = internal.DeepCopy(rd.Document.Body.SectPr)
+	}
+	if rd.DocStyles != nil {
+		doc.DocStyles.StyleList = internal.DeepCopy(rd.DocStyles.StyleList)
+	}
+
+	if rd.Numbering != nil {
+		doc.Numbering.copyFrom(rd.Numbering)
+	}
+	return doc
+}
+
+// Clone returns a copy of the paragraph, detached from the document body, to be inserted with
+// MoveBefore or MoveAfter. The pictures and bookmarks of the copy get new IDs, see renumberCopy.
+//
+// Example:
+//
+//	line := document.FindParagraphs(docx.ContainingText("Item"))[0]
+//	copied := line.Clone()
+//	err := copied.MoveAfter(line)
+func (p *Paragraph) Clone() *Paragraph {
+	copied := &Paragraph{root: p.root, ct: *internal.DeepCopy(p.GetCT())}
+	p.root.renumberCopy([]DocumentChild{copied.blockChild()})
+	return copied
+}
+
+// Clone returns a copy of the table, detached from the document body, to be inserted with
+// MoveBefore or MoveAfter. The pictures and bookmarks of the copy get new IDs.
+func (t *Table) Clone() *Table {
+	copied := &Table{root: t.root, ct: *internal.DeepCopy(t.GetCT())}
+	t.root.renumberCopy([]DocumentChild{copied.blockChild()})
+	return copied
+}
+
+// renumberCopy gives new IDs to the pictures and bookmarks of content copied within the
+// document, the IDs of a document being distinct. Pictures are numbered after the images of
+// the document. Bookmarks are numbered after the bookmarks of the document and their name gets
+// the new ID as suffix; the bookmarks not both started and ended within the copy are dropped.
+func (rd *RootDoc) renumberCopy(copies []DocumentChild) {
+	if rd == nil {
+		return
+	}
+	ci := &contentImporter{children: copies}
+	ci.eachDrawing(func(docProp *dml.DocProp, _ *dmlpic.Blip) {
+		rd.ImageCount++
+		docProp.ID = uint64(rd.ImageCount)
+	})
+
+	started, ended := map[int]bool{}, map[int]bool{}
+	forEachPara(copies, func(p *ctypes.Paragraph) bool {
+		for _, child := range p.Children {
+			if child.BookmarkStart != nil {
+				started[child.BookmarkStart.ID] = true
+			}
+			if child.BookmarkEnd != nil {
+				ended[child.BookmarkEnd.ID] = true
+			}
+		}
+		return true
+	})
+	if len(started) == 0 && len(ended) == 0 {
+		return
+	}
+
+	children := rd.Document.Body.Children
+	for _, hf := range rd.hdrFtr {
+		children = append(slices.Clip(children), hf.Children...)
+	}
+	forEachPara(children, func(p *ctypes.Paragraph) bool {
+		for _, child := range p.Children {
+			if child.BookmarkStart != nil {
+				rd.bookmarkID = max(rd.bookmarkID, child.BookmarkStart.ID)
+			}
+		}
+		return true
+	})
+
+	ids := map[int]int{}
+	forEachPara(copies, func(p *ctypes.Paragraph) bool {
+		p.Children = slices.DeleteFunc(p.Children, func(child ctypes.ParagraphChild) bool {
+			switch {
+			case child.BookmarkStart != nil:
+				if !ended[child.BookmarkStart.ID] {
+					return true
+				}
+				rd.bookmarkID++
+				ids[child.BookmarkStart.ID] = rd.bookmarkID
+				child.BookmarkStart.ID = rd.bookmarkID
+				child.BookmarkStart.Name += "_" + strconv.Itoa(rd.bookmarkID)
+			case child.BookmarkEnd != nil:
+				newID, ok := ids[child.BookmarkEnd.ID]
+				if !ok {
+					return true
+				}
+				child.BookmarkEnd.ID = newID
+			}
+			return false
+		})
+		return true
+	})
+}
+
+// clonePackage returns a copy of the document with an empty body, no styles and no numbering
+// instances. The parts of the file map are shared with the document.
+func (rd *RootDoc) clonePackage() *RootDoc {
+	doc := &RootDoc{
+		RootRels:    internal.DeepCopy(rd.RootRels),
+		ContentType: internal.DeepCopy(rd.ContentType),
+		rID:         rd.rID,
+		ImageCount:  rd.ImageCount,
+		bookmarkID:  rd.bookmarkID,
+	}
+	rd.FileMap.Range(func(key, value any) bool {
+		doc.FileMap.Store(key, value)
+		return true
+	})
+	doc.Numbering = NewNumberingManager(doc)
+	doc.Document = &Document{
+		Root:         doc,
+		Body:         NewBody(doc),
+		DocRels:      internal.DeepCopy(rd.Document.DocRels),
+		RID:          rd.Document.RID,
+		relativePath: rd.Document.relativePath,
+	}
+	if rd.Document.Background != nil {
+		doc.Document.Background = internal.DeepCopy(rd.Document.Background)
+	}
+
+	if rd.DocStyles != nil {
+		doc.DocStyles = &ctypes.Styles{
+			RelativePath: rd.DocStyles.RelativePath,
+			Attr:         slices.Clone(rd.DocStyles.Attr),
+		}
+		if rd.DocStyles.DocDefaults != nil {
+			doc.DocStyles.DocDefaults = internal.DeepCopy(rd.DocStyles.DocDefaults)
+		}
+		if rd.DocStyles.LatentStyle != nil {
+			doc.DocStyles.LatentStyle = internal.DeepCopy(rd.DocStyles.LatentStyle)
+		}
+	}
+
+	if rd.hdrFtrLoaded {
+		for _, hf := range rd.hdrFtr {
+			children := cloneChildren(doc, hf.Children)
+			doc.hdrFtr = append(doc.hdrFtr, &HeaderFooter{
+				root:     doc,
+				Path:     hf.Path,
+				RelID:    hf.RelID,
+				IsFooter: hf.IsFooter,
+				Children: children,
+				read:     hf.read,
+				nsAttrs:  hf.nsAttrs,
+				raw:      hf.cloneRaw(children),
+			})
+		}
+		doc.hdrFtrLoaded = true
+	}
+	return doc
+}
+
+// cloneChildren returns copies of the paragraphs and tables, belonging to the document root.
+func cloneChildren(root *RootDoc, children []DocumentChild) []DocumentChild {
+	copies := make([]DocumentChild, 0, len(children))
+	for _, child := range children {
+		if child.Para != nil {
+			copies = append(copies, DocumentChild{Para: &Paragraph{root: root, ct: *internal.DeepCopy(child.Para.GetCT())}})
+		}
+		if child.Table != nil {
+			copies = append(copies, DocumentChild{Table: &Table{root: root, ct: *internal.DeepCopy(child.Table.GetCT())}})
+		}
+	}
+	return copies
+}
@@ -0,0 +1,41 @@
+package docx_test
+
+import (
+	"bytes"
+	"testing"
+
+	"godocx"
+	"godocx/common/units"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClone(t *testing.T) {
+	template, err := godocx.NewDocument()
+	require.NoError(t, err)
+	template.AddParagraph("Dear").Numbering(template.NewListInstance(1), 0)
+	_, err = template.AddImage([]byte("GIF89a"), units.Emu(9525), units.Emu(9525))
+	require.NoError(t, err)
+
+	var original bytes.Buffer
+	require.NoError(t, template.Write(&original))
+
+	clone := template.Clone()
+	var cloned bytes.Buffer
+	require.NoError(t, clone.Write(&cloned))
+	assert.Equal(t, original.Bytes(), cloned.Bytes())
+
+	// Changes to the clone are not seen by the template
+	clone.Document.Body.Children[0].Para.AddText(" customer")
+	clone.AddParagraph("Thanks").Numbering(clone.NewListInstance(2), 0)
+	_, err = clone.AddImage([]byte("GIF89a"), units.Emu(9525), units.Emu(9525))
+	require.NoError(t, err)
+	clone.DocStyles.StyleList[0].Name.Val = "changed"
+	clone.Document.DocRels.Relationships[0].Target = "changed.xml"
+
+	var after bytes.Buffer
+	require.NoError(t, template.Write(&after))
+	assert.Equal(t, original.Bytes(), after.Bytes())
+	assert.Equal(t, template.ImageCount+1, clone.ImageCount)
+}
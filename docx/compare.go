@@ -0,0 +1,472 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"godocx/wml/ctypes"
+)
+
+// Compare returns a new document showing the changes made from the original document to the
+// revised one as tracked changes by the author, like the Compare feature of Word.
+//
+// The document is a copy of the revised document. Paragraphs and tables of the original
+// document missing from the revised one are shown as deleted and new ones as inserted. Changed
+// paragraphs are compared word by word and changed tables row by row. Paragraphs holding other
+// content than text, such as images or hyperlinks, are shown deleted and inserted as a whole.
+// Formatting changes are not tracked.
+//
+// Example:
+//
+//	redline, err := docx.Compare(sent, returned, "Legal")
+//	if err == nil {
+//		err = redline.SaveTo("changes.docx")
+//	}
+func Compare(original, revised *RootDoc, author string) (*RootDoc, error) {
+	result := revised.Clone()
+	result.Path = ""
+	c := &comparer{
+		result: result,
+		author: author,
+		date:   time.Now().UTC().Format(time.RFC3339),
+	}
+
+	origChildren := original.Document.Body.Children
+	revChildren := result.Document.Body.Children
+	pairs := lcs(len(origChildren), len(revChildren), func(i, j int) bool {
+		return blockKey(origChildren[i]) == blockKey(revChildren[j])
+	})
+
+	// Only the original content shown as deleted is copied, with what it refers to
+	var deleted []DocumentChild
+	prev := [2]int{-1, -1}
+	for _, pair := range append(pairs, [2]int{len(origChildren), len(revChildren)}) {
+		deleted = append(deleted, origChildren[prev[0]+1:pair[0]]...)
+		prev = pair
+	}
+	copies, err := result.importContent(original, deleted, UseDestinationStyle)
+	if err != nil {
+		return nil, fmt.Errorf("compare: %w", err)
+	}
+
+	// The tracked changes are numbered after those the documents already hold
+	if c.lastID, err = maxRevisionID(copies); err != nil {
+		return nil, fmt.Errorf("compare: %w", err)
+	}
+	err = result.eachPart(false, func(_ string, children []DocumentChild) {
+		id, markErr := maxRevisionID(children)
+		err = errors.Join(err, markErr)
+		c.lastID = max(c.lastID, id)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("compare: %w", err)
+	}
+
+	var children []DocumentChild
+	prev = [2]int{-1, -1}
+	for _, pair := range append(pairs, [2]int{len(origChildren), len(revChildren)}) {
+		removed := copies[:pair[0]-prev[0]-1]
+		copies = copies[len(removed):]
+		children = append(children, c.blocks(removed, revChildren[prev[1]+1:pair[1]])...)
+		if pair[1] < len(revChildren) {
+			children = append(children, revChildren[pair[1]])
+		}
+		prev = pair
+	}
+	result.Document.Body.Children = children
+	return result, nil
+}
+
+// comparer builds the tracked changes of a compared document.
+type comparer struct {
+	result *RootDoc
+	author string
+	date   string
+	lastID int // ID of the last tracked change
+}
+
+// change returns a new tracked change.
+func (c *comparer) change() *ctypes.TrackChange {
+	c.lastID++
+	return &ctypes.TrackChange{ID: c.lastID, Author: c.author, Date: &c.date}
+}
+
+// blocks returns the content replacing removed blocks of the original document by added blocks
+// of the revised document. Paragraphs and tables are paired in order and compared, the blocks
+// left over are deleted or inserted.
+func (c *comparer) blocks(removed, added []DocumentChild) []DocumentChild {
+	var children []DocumentChild
+	changed := func(removed, added []DocumentChild) {
+		for _, b := range removed {
+			c.markBlock(b, false)
+			children = append(children, b)
+		}
+		for _, b := range added {
+			c.markBlock(b, true)
+			children = append(children, b)
+		}
+	}
+
+	pairs := lcs(len(removed), len(added), func(i, j int) bool {
+		return (removed[i].Para != nil) == (added[j].Para != nil)
+	})
+	prev := [2]int{-1, -1}
+	for _, pair := range append(pairs, [2]int{len(removed), len(added)}) {
+		changed(removed[prev[0]+1:pair[0]], added[prev[1]+1:pair[1]])
+		prev = pair
+		if pair[1] == len(added) {
+			break
+		}
+
+		orig, rev := removed[pair[0]], added[pair[1]]
+		if rev.Table != nil {
+			c.table(orig.Table.GetCT(), rev.Table.GetCT())
+			children = append(children, rev)
+		} else if p := c.paragraph(orig.Para.GetCT(), rev.Para.GetCT()); p != nil {
+			children = append(children, DocumentChild{Para: &Paragraph{root: c.result, ref: p}})
+		} else {
+			changed([]DocumentChild{orig}, []DocumentChild{rev})
+		}
+	}
+	return children
+}
+
+// diffToken is a word, a space or a punctuation character of a paragraph with its formatting.
+type diffToken struct {
+	text string
+	prop *ctypes.RunProperty
+}
+
+var diffTokenRe = regexp.MustCompile(`(?s)[\p{L}\p{N}_]+|\s+|.`)
+
+// paragraph returns the revised paragraph with the differences from the original paragraph as
+// tracked changes, nil if the paragraphs cannot be compared word by word.
+func (c *comparer) paragraph(orig, rev *ctypes.Paragraph) *ctypes.Paragraph {
+	origTokens, ok := paraTokens(orig)
+	if !ok {
+		return nil
+	}
+	revTokens, ok := paraTokens(rev)
+	if !ok {
+		return nil
+	}
+
+	p := &ctypes.Paragraph{Property: rev.Property}
+	var lastProp *ctypes.RunProperty
+	add := func(tok diffToken, op int) {
+		// Tokens of the same kind and formatting share a run
+		if n := len(p.Children); n > 0 && lastProp == tok.prop {
+			last := p.Children[n-1]
+			var text **ctypes.Text
+			switch {
+			case op == 0 && last.Run != nil:
+				text = &last.Run.Children[0].Text
+			case op < 0 && last.Del != nil:
+				text = &last.Del.Runs[0].Children[0].DelText
+			case op > 0 && last.Ins != nil:
+				text = &last.Ins.Runs[0].Children[0].Text
+			}
+			if text != nil {
+				*text = ctypes.TextFromString((*text).Text + tok.text)
+				return
+			}
+		}
+
+		run := &ctypes.Run{Property: tok.prop}
+		var child ctypes.ParagraphChild
+		switch {
+		case op < 0:
+			run.Children = []ctypes.RunChild{{DelText: ctypes.TextFromString(tok.text)}}
+			child.Del = &ctypes.RunTrackChange{TrackChange: *c.change(), Runs: []*ctypes.Run{run}}
+		case op > 0:
+			run.Children = []ctypes.RunChild{{Text: ctypes.TextFromString(tok.text)}}
+			child.Ins = &ctypes.RunTrackChange{TrackChange: *c.change(), Runs: []*ctypes.Run{run}}
+		default:
+			run.Children = []ctypes.RunChild{{Text: ctypes.TextFromString(tok.text)}}
+			child.Run = run
+		}
+		p.Children = append(p.Children, child)
+		lastProp = tok.prop
+	}
+
+	pairs := lcs(len(origTokens), len(revTokens), func(i, j int) bool {
+		return origTokens[i].text == revTokens[j].text
+	})
+	prev := [2]int{-1, -1}
+	for _, pair := range append(pairs, [2]int{len(origTokens), len(revTokens)}) {
+		for _, tok := range origTokens[prev[0]+1 : pair[0]] {
+			add(tok, -1)
+		}
+		for _, tok := range revTokens[prev[1]+1 : pair[1]] {
+			add(tok, 1)
+		}
+		if pair[1] < len(revTokens) {
+			add(revTokens[pair[1]], 0)
+		}
+		prev = pair
+	}
+	return p
+}
+
+// paraTokens splits the text of the paragraph into tokens, false if the paragraph holds other
+// content than runs of text.
+func paraTokens(p *ctypes.Paragraph) ([]diffToken, bool) {
+	var tokens []diffToken
+	for _, child := range p.Children {
+		if child.Run == nil {
+			return nil, false
+		}
+		for _, rc := range child.Run.Children {
+			if rc.Text == nil {
+				return nil, false
+			}
+			for _, word := range diffTokenRe.FindAllString(rc.Text.Text, -1) {
+				tokens = append(tokens, diffToken{text: word, prop: child.Run.Property})
+			}
+		}
+	}
+	return tokens, true
+}
+
+// table changes the revised table to show the rows of the original table missing from it as
+// deleted and its new rows as inserted.
+func (c *comparer) table(orig, rev *ctypes.Table) {
+	origRows, revRows := tableRows(orig), tableRows(rev)
+	pairs := lcs(len(origRows), len(revRows), func(i, j int) bool {
+		return rowKey(origRows[i]) == rowKey(revRows[j])
+	})
+
+	var rows []ctypes.RowContent
+	prev := [2]int{-1, -1}
+	for _, pair := range append(pairs, [2]int{len(origRows), len(revRows)}) {
+		for _, row := range origRows[prev[0]+1 : pair[0]] {
+			c.markRow(row, false)
+			rows = append(rows, ctypes.RowContent{Row: row})
+		}
+		for _, row := range revRows[prev[1]+1 : pair[1]] {
+			c.markRow(row, true)
+			rows = append(rows, ctypes.RowContent{Row: row})
+		}
+		if pair[1] < len(revRows) {
+			rows = append(rows, ctypes.RowContent{Row: revRows[pair[1]]})
+		}
+		prev = pair
+	}
+	rev.RowContents = rows
+}
+
+// markBlock marks a paragraph or a table as inserted, or deleted.
+func (c *comparer) markBlock(b DocumentChild, inserted bool) {
+	if b.Para != nil {
+		c.markParagraph(b.Para.GetCT(), inserted)
+	}
+	if b.Table != nil {
+		for _, row := range tableRows(b.Table.GetCT()) {
+			c.markRow(row, inserted)
+		}
+	}
+}
+
+// markRow marks a table row and its content as inserted, or deleted.
+func (c *comparer) markRow(row *ctypes.Row, inserted bool) {
+	if row.Property == nil {
+		row.Property = ctypes.DefaultRowProperty()
+	}
+	if inserted {
+		row.Property.Ins = c.change()
+	} else {
+		row.Property.Del = c.change()
+	}
+	forEachTablePara(&ctypes.Table{RowContents: []ctypes.RowContent{{Row: row}}}, func(p *ctypes.Paragraph) bool {
+		c.markParagraph(p, inserted)
+		return true
+	})
+}
+
+// markParagraph marks the runs and the mark of a paragraph as inserted, or deleted.
+func (c *comparer) markParagraph(p *ctypes.Paragraph, inserted bool) {
+	wrap := func(runs []*ctypes.Run) ctypes.ParagraphChild {
+		change := &ctypes.RunTrackChange{TrackChange: *c.change(), Runs: runs}
+		if inserted {
+			return ctypes.ParagraphChild{Ins: change}
+		}
+		for _, r := range runs {
+			for i, rc := range r.Children {
+				// Deleted text is held by dedicated elements
+				if rc.Text != nil {
+					r.Children[i].Text, r.Children[i].DelText = nil, rc.Text
+				}
+				if rc.InstrText != nil {
+					r.Children[i].InstrText, r.Children[i].DelInstrText = nil, rc.InstrText
+				}
+			}
+		}
+		return ctypes.ParagraphChild{Del: change}
+	}
+
+	for i, child := range p.Children {
+		switch {
+		case child.Run != nil:
+			p.Children[i] = wrap([]*ctypes.Run{child.Run})
+		case child.Link != nil:
+			if runs := child.Link.Runs(); len(runs) > 0 {
+				child.Link.Run = nil
+				child.Link.Children = []ctypes.ParagraphChild{wrap(runs)}
+			}
+		}
+	}
+
+	if p.Property == nil {
+		p.Property = &ctypes.ParagraphProp{}
+	}
+	if p.Property.RunProperty == nil {
+		p.Property.RunProperty = &ctypes.RunProperty{}
+	}
+	if inserted {
+		p.Property.RunProperty.Ins = c.change()
+	} else {
+		p.Property.RunProperty.Del = c.change()
+	}
+}
+
+// tableRows returns the rows of the table.
+func tableRows(t *ctypes.Table) []*ctypes.Row {
+	var rows []*ctypes.Row
+	for _, rc := range t.RowContents {
+		if rc.Row != nil {
+			rows = append(rows, rc.Row)
+		}
+	}
+	return rows
+}
+
+// rowKey returns the text of the cells of the row, compared to match rows.
+func rowKey(row *ctypes.Row) string {
+	var cells []string
+	for _, cc := range row.Contents {
+		if cc.Cell != nil {
+			cells = append(cells, cellText(cc.Cell))
+		}
+	}
+	return strings.Join(cells, "\x00")
+}
+
+// blockKey returns the style and text of a paragraph, or the text of a table, compared to match
+// blocks of the compared documents.
+func blockKey(b DocumentChild) string {
+	if b.Para != nil {
+		return "p" + paraStyleID(b.Para.GetCT()) + "\x00" + paragraphText(b.Para.GetCT())
+	}
+	tw := textWriter{}
+	tw.writeTable(b.Table.GetCT())
+	return "t" + tw.sb.String()
+}
+
+// revisionIDRe matches the ID of a tracked change, written before its author.
+var revisionIDRe = regexp.MustCompile(`w:id="(\d+)" w:author=`)
+
+// maxRevisionID returns the highest ID of the tracked changes of the content, 0 if there is none.
+func maxRevisionID(children []DocumentChild) (int, error) {
+	var buf bytes.Buffer
+	e := xml.NewEncoder(&buf)
+	if err := marshalBlockChildren(e, children); err != nil {
+		return 0, err
+	}
+	if err := e.Flush(); err != nil {
+		return 0, err
+	}
+	highest := 0
+	for _, m := range revisionIDRe.FindAllSubmatch(buf.Bytes(), -1) {
+		if id, err := strconv.Atoi(string(m[1])); err == nil {
+			highest = max(highest, id)
+		}
+	}
+	return highest, nil
+}
+
+// lcs returns the index pairs of a longest common subsequence of two sequences of lengths n and
+// m, equal reporting whether the elements at i and j are equal. It follows the O(ND) algorithm
+// of Myers, D being the number of elements deleted and inserted.
+func lcs(n, m int, equal func(i, j int) bool) [][2]int {
+	// Common ends are matched directly
+	var prefix [][2]int
+	for len(prefix) < min(n, m) && equal(len(prefix), len(prefix)) {
+		prefix = append(prefix, [2]int{len(prefix), len(prefix)})
+	}
+	start := len(prefix)
+	suffix := 0
+	for suffix < min(n, m)-start && equal(n-1-suffix, m-1-suffix) {
+		suffix++
+	}
+
+	// v[off+k] is the furthest x reached on diagonal k = x-y, x and y counting the elements from
+	// start of each sequence. trace[d] holds the diagonals -d to d after d edits.
+	rows, cols := n-start-suffix, m-start-suffix
+	off := rows + cols + 1
+	v := make([]int, 2*off+1)
+	var trace [][]int
+	for d := 0; ; d++ {
+		done := false
+		for k := -d; k <= d && !done; k += 2 {
+			var x int
+			if k == -d || (k != d && v[off+k-1] < v[off+k+1]) {
+				x = v[off+k+1]
+			} else {
+				x = v[off+k-1] + 1
+			}
+			y := x - k
+			for x < rows && y < cols && equal(start+x, start+y) {
+				x++
+				y++
+			}
+			v[off+k] = x
+			done = x >= rows && y >= cols
+		}
+		trace = append(trace, slices.Clone(v[off-d:off+d+1]))
+		if done {
+			break
+		}
+	}
+
+	// Walk the edits back from the end, collecting the diagonals as matched pairs
+	var middle [][2]int
+	x, y := rows, cols
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d-1] // Diagonal k at prev[k+d-1]
+		k := x - y
+		var prevK, startX int
+		if k == -d || (k != d && prev[k-1+d-1] < prev[k+1+d-1]) {
+			prevK = k + 1
+			startX = prev[prevK+d-1]
+		} else {
+			prevK = k - 1
+			startX = prev[prevK+d-1] + 1
+		}
+		for x > startX {
+			x--
+			y--
+			middle = append(middle, [2]int{start + x, start + y})
+		}
+		x = prev[prevK+d-1]
+		y = x - prevK
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		middle = append(middle, [2]int{start + x, start + y})
+	}
+	slices.Reverse(middle)
+
+	pairs := append(prefix, middle...)
+	for k := suffix; k > 0; k-- {
+		pairs = append(pairs, [2]int{n - k, m - k})
+	}
+	return pairs
+}
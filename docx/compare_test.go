@@ -0,0 +1,172 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"regexp"
+	"testing"
+
+	"godocx/wml/ctypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	original := setupRootDoc(t)
+	original.AddParagraph("Intro")
+	original.AddParagraph("Pay ten dollars.")
+	original.AddParagraph("Removed clause")
+	tbl := original.AddTable()
+	for _, cells := range [][]string{{"a", "b"}, {"c", "d"}} {
+		row := tbl.AddRow()
+		for _, text := range cells {
+			row.AddCell().AddParagraph(text)
+		}
+	}
+
+	revised := setupRootDoc(t)
+	revised.AddParagraph("Intro")
+	revised.AddParagraph("Pay five dollars now.")
+	revised.AddParagraph("")
+	revised.AddParagraph("").AddLink("Added clause", "https://example.com")
+	tbl = revised.AddTable()
+	for _, cells := range [][]string{{"a", "b"}, {"x", "y"}} {
+		row := tbl.AddRow()
+		for _, text := range cells {
+			row.AddCell().AddParagraph(text)
+		}
+	}
+
+	result, err := Compare(original, revised, "Ann")
+	require.NoError(t, err)
+	assert.Equal(t, "Intro\nPay five dollars now.\n\nAdded clause\na\tb\n\t\nx\ty\n", bodyText(t, result))
+	assert.Len(t, revised.Document.Body.Children, 5)
+
+	marshal := func(v any) string {
+		out, err := xml.Marshal(v)
+		require.NoError(t, err)
+		return string(out)
+	}
+	children := result.Document.Body.Children
+	require.Len(t, children, 5)
+	assert.Equal(t, "<w:p><w:r><w:t xml:space=\"preserve\">Pay </w:t></w:r>"+
+		"<w:del w:id=\"1\" w:author=\"Ann\" w:date=\"DATE\"><w:r><w:delText>ten</w:delText></w:r></w:del>"+
+		"<w:ins w:id=\"2\" w:author=\"Ann\" w:date=\"DATE\"><w:r><w:t>five</w:t></w:r></w:ins>"+
+		"<w:r><w:t xml:space=\"preserve\"> dollars</w:t></w:r>"+
+		"<w:ins w:id=\"3\" w:author=\"Ann\" w:date=\"DATE\"><w:r><w:t xml:space=\"preserve\"> now</w:t></w:r></w:ins>"+
+		"<w:r><w:t>.</w:t></w:r></w:p>",
+		replaceDate(marshal(children[1].Para.GetCT())))
+
+	// The removed clause is paired with the empty paragraph, the link paragraph is inserted
+	assert.Contains(t, marshal(children[2].Para.GetCT()), "<w:delText>Removed clause</w:delText>")
+	link := marshal(children[3].Para.GetCT())
+	assert.Contains(t, link, "<w:rPr><w:ins ")
+	assert.Contains(t, link, "<w:hyperlink r:id=\""+children[3].Para.GetCT().Children[1].Link.ID+"\"><w:ins ")
+
+	rows := children[4].Table.GetCT().RowContents
+	require.Len(t, rows, 3)
+	assert.Nil(t, rows[0].Row.Property.Del)
+	assert.Nil(t, rows[0].Row.Property.Ins)
+	assert.NotNil(t, rows[1].Row.Property.Del)
+	assert.Contains(t, marshal(rows[1].Row), "<w:delText>c</w:delText>")
+	assert.NotNil(t, rows[2].Row.Property.Ins)
+}
+
+var regexpDate = regexp.MustCompile(`w:date="[^"]*"`)
+
+func replaceDate(s string) string {
+	return regexpDate.ReplaceAllString(s, `w:date="DATE"`)
+}
+
+func TestCompare_RevisionIDs(t *testing.T) {
+	original := setupRootDoc(t)
+	original.AddParagraph("One two")
+
+	// The revised document already holds tracked changes
+	revised := setupRootDoc(t)
+	p := revised.AddParagraph("One three")
+	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Ins: &ctypes.RunTrackChange{
+		TrackChange: ctypes.TrackChange{ID: 7, Author: "Bob"},
+		Runs:        []*ctypes.Run{{Children: []ctypes.RunChild{{Text: ctypes.TextFromString("!")}}}},
+	}})
+	revised.AddParagraph("Four")
+
+	result, err := Compare(original, revised, "Ann")
+	require.NoError(t, err)
+	var ids []int
+	for _, child := range result.Document.Body.Children {
+		for _, pc := range child.Para.GetCT().Children {
+			if pc.Ins != nil && pc.Ins.Author == "Ann" {
+				ids = append(ids, pc.Ins.ID)
+			}
+			if pc.Del != nil {
+				ids = append(ids, pc.Del.ID)
+			}
+		}
+	}
+	require.NotEmpty(t, ids)
+	for _, id := range ids {
+		assert.Greater(t, id, 7)
+	}
+}
+
+func TestLCS(t *testing.T) {
+	// Lengths of a longest common subsequence computed by dynamic programming
+	lcsLength := func(a, b string) int {
+		lengths := make([][]int, len(a)+1)
+		for i := range lengths {
+			lengths[i] = make([]int, len(b)+1)
+		}
+		for i := len(a) - 1; i >= 0; i-- {
+			for j := len(b) - 1; j >= 0; j-- {
+				if a[i] == b[j] {
+					lengths[i][j] = lengths[i+1][j+1] + 1
+				} else {
+					lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+				}
+			}
+		}
+		return lengths[0][0]
+	}
+
+	cases := [][2]string{
+		{"", ""}, {"abc", ""}, {"", "abc"}, {"abc", "abc"},
+		{"abcabba", "cbabac"}, {"xaxbxc", "abc"}, {"abcdef", "fedcba"},
+		{"the quick brown fox", "a quick brown dog"}, {"aaaa", "aa"},
+	}
+	for _, c := range cases {
+		a, b := c[0], c[1]
+		pairs := lcs(len(a), len(b), func(i, j int) bool { return a[i] == b[j] })
+		assert.Len(t, pairs, lcsLength(a, b), "%q %q", a, b)
+		prev := [2]int{-1, -1}
+		for _, pair := range pairs {
+			assert.Equal(t, a[pair[0]], b[pair[1]])
+			assert.Greater(t, pair[0], prev[0])
+			assert.Greater(t, pair[1], prev[1])
+			prev = pair
+		}
+	}
+}
+
+func TestCompare_Export(t *testing.T) {
+	original := setupRootDoc(t)
+	original.AddParagraph("The quick brown fox")
+
+	revised := setupRootDoc(t)
+	revised.AddParagraph("The slow brown fox jumps")
+	revised.AddParagraph("").AddLink("New paragraph", "https://example.com")
+
+	result, err := Compare(original, revised, "Ann")
+	require.NoError(t, err)
+
+	var md bytes.Buffer
+	require.NoError(t, result.ToMarkdown(&md, nil))
+	assert.Equal(t, "The slow brown fox jumps\n\n[New paragraph](https://example.com)\n", md.String())
+
+	var html bytes.Buffer
+	require.NoError(t, result.ToHTML(&html, nil))
+	assert.Contains(t, html.String(), "<p>The slow brown fox jumps</p>")
+	assert.Contains(t, html.String(), `<p><a href="https://example.com">New paragraph</a></p>`)
+	assert.NotContains(t, html.String(), "quick")
+}
@@ -0,0 +1,199 @@
+package docx
+
+import (
+	"slices"
+
+	"godocx/internal"
+	"godocx/wml/ctypes"
+)
+
+// EffectiveProperties returns the paragraph properties in effect for the paragraph, resolving in
+// order the document defaults, the conditional formatting of the table style when the paragraph
+// is in a table cell, the paragraph style hierarchy and the direct formatting. The returned
+// properties are a new value: changing them does not change the paragraph. The run properties
+// of the paragraph mark, the section and revision properties are left out.
+//
+// Example:
+//
+//	prop := paragraph.EffectiveProperties()
+//	if prop.Justification != nil {
+//		fmt.Println(prop.Justification.Val)
+//	}
+func (p *Paragraph) EffectiveProperties() *ctypes.ParagraphProp {
+	return internal.DeepCopy(p.root.resolvedParaProp(p.GetCT(), p.root.paragraphCell(p.GetCT())))
+}
+
+// EffectiveProperties returns the run properties in effect for the run, resolving in order the
+// document defaults, the conditional formatting of the table style when the run is in a table
+// cell, the paragraph style hierarchy, the character style hierarchy and the direct formatting.
+//
+// Toggle properties, such as bold and italic, set by a style invert the value set by the styles
+// of another type: italic text in an italic paragraph style is upright. Theme fonts are replaced
+// by the fonts of the theme. The returned properties are a new value: changing them does not
+// change the run.
+//
+// Example:
+//
+//	for run := range paragraph.Runs() {
+//		prop := run.EffectiveProperties()
+//		fmt.Println(run.Text(), prop.Bold.Bool(), prop.Italic.Bool())
+//	}
+func (r *Run) EffectiveProperties() *ctypes.RunProperty {
+	var cell *cellPosition
+	if r.para != nil {
+		cell = r.root.paragraphCell(r.para)
+	}
+	prop := internal.DeepCopy(r.root.resolvedRunProp(r.para, cell, r.ct))
+
+	if fonts := prop.Fonts; fonts != nil {
+		if name := r.root.themeFont(fonts.AsciiTheme); name != "" {
+			fonts.Ascii, fonts.AsciiTheme = name, ""
+		}
+		if name := r.root.themeFont(fonts.HAnsiTheme); name != "" {
+			fonts.HAnsi, fonts.HAnsiTheme = name, ""
+		}
+	}
+	return prop
+}
+
+// paraLocation is the position of a paragraph among the blocks of the document body or of a
+// header or footer, or among the contents of a table cell.
+type paraLocation struct {
+	blocks *[]DocumentChild // Blocks holding the paragraph, nil in a table cell
+	table  *ctypes.Table
+	row    *ctypes.Row
+	cell   *ctypes.Cell
+	index  int
+}
+
+// holds reports whether the paragraph is still at the location.
+func (loc paraLocation) holds(p *ctypes.Paragraph) bool {
+	if loc.blocks != nil {
+		blocks := *loc.blocks
+		return loc.index < len(blocks) && blocks[loc.index].Para != nil && blocks[loc.index].Para.GetCT() == p
+	}
+	return loc.index < len(loc.cell.Contents) && loc.cell.Contents[loc.index].Paragraph == p &&
+		slices.ContainsFunc(loc.row.Contents, func(c ctypes.TRCellContent) bool { return c.Cell == loc.cell }) &&
+		slices.ContainsFunc(loc.table.RowContents, func(r ctypes.RowContent) bool { return r.Row == loc.row })
+}
+
+// paragraphCell returns the position of the innermost table cell holding the paragraph, nil
+// when the paragraph is not in a table of the document body or of a header or footer read by
+// HeadersFooters. The paragraphs are indexed once, and again when a paragraph is missing from
+// the index or has moved.
+func (rd *RootDoc) paragraphCell(p *ctypes.Paragraph) *cellPosition {
+	loc, ok := rd.paraIndex[p]
+	if !ok || !loc.holds(p) {
+		rd.indexParagraphs()
+		if loc, ok = rd.paraIndex[p]; !ok {
+			return nil
+		}
+	}
+	if loc.cell == nil {
+		return nil
+	}
+	return cellPositionOf(loc.table, loc.row, loc.cell)
+}
+
+// indexParagraphs records the location of the paragraphs of the document body and of the
+// headers and footers, including the paragraphs of their tables.
+func (rd *RootDoc) indexParagraphs() {
+	index := map[*ctypes.Paragraph]paraLocation{}
+	var indexTable func(t *ctypes.Table)
+	indexTable = func(t *ctypes.Table) {
+		for _, rc := range t.RowContents {
+			if rc.Row == nil {
+				continue
+			}
+			for _, cc := range rc.Row.Contents {
+				if cc.Cell == nil {
+					continue
+				}
+				for i, content := range cc.Cell.Contents {
+					if content.Paragraph != nil {
+						index[content.Paragraph] = paraLocation{table: t, row: rc.Row, cell: cc.Cell, index: i}
+					}
+					if content.Table != nil {
+						indexTable(content.Table)
+					}
+				}
+			}
+		}
+	}
+	indexBlocks := func(blocks *[]DocumentChild) {
+		for i, child := range *blocks {
+			if child.Para != nil {
+				index[child.Para.GetCT()] = paraLocation{blocks: blocks, index: i}
+			}
+			if child.Table != nil {
+				indexTable(child.Table.GetCT())
+			}
+		}
+	}
+	if rd.Document != nil && rd.Document.Body != nil {
+		indexBlocks(&rd.Document.Body.Children)
+	}
+	for _, hf := range rd.hdrFtr {
+		indexBlocks(&hf.Children)
+	}
+	rd.paraIndex = index
+}
+
+// parentParagraph returns the paragraph holding an element visited by RootDoc.Walk, nil if it
+// is not in a paragraph.
+func parentParagraph(parents []Element) *ctypes.Paragraph {
+	for i := len(parents) - 1; i >= 0; i-- {
+		if para, ok := parents[i].(*Paragraph); ok {
+			return para.GetCT()
+		}
+	}
+	return nil
+}
+
+// parentCell returns the position of the innermost table cell holding an element visited by
+// RootDoc.Walk, nil if it is not in a table.
+func parentCell(parents []Element) *cellPosition {
+	for i := len(parents) - 1; i >= 2; i-- {
+		if cell, ok := parents[i].(*Cell); ok {
+			return cellPositionOf(parents[i-2].(*Table).GetCT(), parents[i-1].(*Row).GetCT(), cell.GetCT())
+		}
+	}
+	return nil
+}
+
+// cellPositionOf returns the position of the cell of a row of the table.
+func cellPositionOf(t *ctypes.Table, row *ctypes.Row, cell *ctypes.Cell) *cellPosition {
+	pos := &cellPosition{table: t, row: -1, span: 1, cols: len(t.Grid.Col)}
+	for _, rc := range t.RowContents {
+		if rc.Row == nil {
+			continue
+		}
+		if rc.Row == row {
+			pos.row = pos.rows
+		}
+		pos.rows++
+	}
+
+	col := 0
+	if row.Property != nil && row.Property.GridBefore != nil {
+		col = row.Property.GridBefore.Val
+	}
+	for _, cc := range row.Contents {
+		if cc.Cell == nil {
+			continue
+		}
+		span := 1
+		if cc.Cell.Property != nil && cc.Cell.Property.GridSpan != nil {
+			span = max(cc.Cell.Property.GridSpan.Val, 1)
+		}
+		if cc.Cell == cell {
+			pos.col, pos.span = col, span
+		}
+		col += span
+	}
+	// Tables without a grid are laid out from the cells of the row
+	if pos.cols == 0 {
+		pos.cols = col
+	}
+	return pos
+}
@@ -0,0 +1,124 @@
+package docx
+
+import (
+	"testing"
+
+	"godocx/common/constants"
+	"godocx/internal"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveProperties(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.FileMap.Store("word/theme/theme1.xml", []byte(`<a:theme xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main">`+
+		`<a:themeElements><a:fontScheme><a:majorFont><a:latin typeface="Calibri Light"/></a:majorFont>`+
+		`<a:minorFont><a:latin typeface="Calibri"/></a:minorFont></a:fontScheme></a:themeElements></a:theme>`))
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships, &Relationship{
+		ID: "rId1", Type: constants.SourceRelationshipTheme, Target: "theme/theme1.xml",
+	})
+	rd.DocStyles.DocDefaults = &ctypes.DocDefault{RunProp: &ctypes.RunPropDefault{RunProp: &ctypes.RunProperty{
+		Fonts: &ctypes.RunFonts{AsciiTheme: stypes.ThemeFontMinorAscii, HAnsiTheme: stypes.ThemeFontMinorHAnsi},
+		Size:  ctypes.NewFontSize(22),
+	}}}
+	rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, ctypes.Style{
+		ID:      internal.ToPtr("Normal"),
+		Name:    ctypes.NewCTString("Normal"),
+		Type:    internal.ToPtr(stypes.StyleTypeParagraph),
+		Default: internal.ToPtr(stypes.OnOffOne),
+	})
+	require.NoError(t, rd.AddParagraphStyle("Heading1", "heading 1").BasedOn("Normal").
+		Italic(true).Size(16).Justification(stypes.JustificationCenter).Err())
+	require.NoError(t, rd.AddParagraphStyle("Title", "Title").BasedOn("Heading1").Italic(true).Err())
+	require.NoError(t, rd.AddCharacterStyle("Emphasis", "Emphasis").Italic(true).Bold(true).Err())
+	grid := rd.AddTableStyle("Grid", "Grid").Font("Arial")
+	grid.Conditional(stypes.TblStyleOverrideFirstRow).Bold(true).Justification(stypes.JustificationRight)
+	grid.Conditional(stypes.TblStyleOverrideBand1Horz).Color("FF0000")
+	grid.Conditional(stypes.TblStyleOverrideBand2Horz).Color("00FF00")
+	require.NoError(t, grid.Err())
+
+	heading := rd.AddParagraph("")
+	heading.Style("Title")
+	plain := heading.AddText("plain")
+	emphasis := heading.AddText("emphasis").Style("Emphasis")
+	direct := heading.AddText("direct").Style("Emphasis").Italic(true)
+
+	// The style hierarchy inherits, toggle properties of other style types invert it
+	prop := plain.EffectiveProperties()
+	assert.True(t, prop.Italic.Bool())
+	assert.False(t, prop.Bold.Bool())
+	assert.Equal(t, uint64(32), prop.Size.Value)
+	assert.Equal(t, "Calibri", prop.Fonts.Ascii)
+	assert.Empty(t, prop.Fonts.AsciiTheme)
+	prop = emphasis.EffectiveProperties()
+	assert.False(t, prop.Italic.Bool())
+	assert.True(t, prop.Bold.Bool())
+	assert.True(t, direct.EffectiveProperties().Italic.Bool())
+
+	para := heading.EffectiveProperties()
+	assert.Equal(t, stypes.JustificationCenter, para.Justification.Val)
+	para.Justification = nil
+	assert.NotNil(t, heading.EffectiveProperties().Justification)
+
+	table := rd.AddTable()
+	table.Style("Grid")
+	table.GetCT().TableProp.TableLook = ctypes.NewCTString("0020")
+	var cells []*Paragraph
+	for range 3 {
+		cells = append(cells, table.AddRow().AddCell().AddParagraph("cell"))
+	}
+	firstRun := func(p *Paragraph) *Run {
+		for run := range p.Runs() {
+			return run
+		}
+		return nil
+	}
+	prop = firstRun(cells[0]).EffectiveProperties()
+	assert.True(t, prop.Bold.Bool())
+	assert.Nil(t, prop.Color)
+	assert.Equal(t, "Arial", prop.Fonts.Ascii)
+	assert.Equal(t, stypes.JustificationRight, cells[0].EffectiveProperties().Justification.Val)
+
+	// Banding starts after the header row
+	prop = firstRun(cells[1]).EffectiveProperties()
+	assert.False(t, prop.Bold.Bool())
+	assert.Equal(t, "FF0000", prop.Color.Val)
+	prop = firstRun(cells[2]).EffectiveProperties()
+	assert.Equal(t, "00FF00", prop.Color.Val)
+	assert.Nil(t, cells[2].EffectiveProperties().Justification)
+
+	// Paragraphs added afterwards are found in their cell
+	moved, err := cells[1].InsertParagraphBefore("moved")
+	require.NoError(t, err)
+	assert.Equal(t, "FF0000", firstRun(moved).EffectiveProperties().Color.Val)
+}
+
+func TestEffectiveProperties_OutsideBody(t *testing.T) {
+	rd := setupRootDoc(t)
+	require.NoError(t, rd.AddParagraphStyle("Strong", "Strong").Bold(true).Err())
+	p := rd.AddParagraph("")
+	p.Style("Strong")
+	p.AddText("text")
+
+	// The runs of a copy and of a header know their paragraph
+	for run := range p.Clone().Runs() {
+		assert.True(t, run.EffectiveProperties().Bold.Bool())
+	}
+	rd.Document.relativePath = "word/document.xml"
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships,
+		&Relationship{ID: "rId5", Type: constants.SourceRelationshipHeader, Target: "header1.xml"})
+	rd.FileMap.Store("word/header1.xml", []byte(`<w:hdr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">`+
+		`<w:tbl><w:tr><w:tc><w:p><w:pPr><w:pStyle w:val="Strong"/></w:pPr><w:r><w:t>header</w:t></w:r></w:p></w:tc></w:tr></w:tbl></w:hdr>`))
+	parts, err := rd.HeadersFooters()
+	require.NoError(t, err)
+	require.Len(t, parts, 1)
+	cell := &Cell{root: rd, ref: parts[0].Children[0].Table.GetCT().RowContents[0].Row.Contents[0].Cell}
+	para := cell.Blocks()[0].(*Paragraph)
+	for run := range para.Runs() {
+		assert.True(t, run.EffectiveProperties().Bold.Bool())
+	}
+	require.NotNil(t, rd.paragraphCell(para.GetCT()))
+}
@@ -0,0 +1,201 @@
+package docx
+
+import (
+	"regexp"
+
+	"godocx/internal"
+	"godocx/wml/ctypes"
+)
+
+// Match is the location of a regular expression match within the text of a paragraph.
+//
+// The text of a paragraph is the text of all its runs, including the runs of hyperlinks,
+// so a match can span several runs with different formatting.
+type Match struct {
+	Part      string            // Package part holding the paragraph, e.g. word/document.xml or word/footer1.xml
+	Paragraph int               // Index of the paragraph within the part, in document order including table cells
+	Start     int               // Byte offset of the match within the paragraph text
+	End       int               // Byte offset just after the match
+	Text      string            // The matched text
+	Groups    []string          // The capture groups, Groups[0] being the whole match
+	Para      *ctypes.Paragraph // The paragraph holding the match
+}
+
+// ReplaceOptions configures RootDoc.Replace.
+type ReplaceOptions struct {
+	// Format, when set, is applied to a new run holding the replacement text. The run starts
+	// with the formatting of the run where the match begins.
+	// When nil the replacement text is written into that run.
+	Format func(r *Run)
+
+	// Literal disables the expansion of $1, ${name} capture group references in the replacement.
+	Literal bool
+
+	// Limit is the maximum number of replacements, 0 replaces every match.
+	Limit int
+
+	// SkipHeadersFooters restricts the replacement to the document body.
+	SkipHeadersFooters bool
+}
+
+// Find returns every match of the regular expression in the paragraphs of the document body,
+// its tables, headers and footers.
+//
+// Example:
+//
+//	matches, err := document.Find(regexp.MustCompile(`INV-\d+`))
+func (rd *RootDoc) Find(re *regexp.Regexp) ([]Match, error) {
+	var matches []Match
+	err := rd.eachPart(false, func(part string, children []DocumentChild) {
+		idx := 0
+		forEachPara(children, func(p *ctypes.Paragraph) bool {
+			_, text := paraSegments(p)
+			for _, m := range re.FindAllStringSubmatchIndex(text, -1) {
+				matches = append(matches, newMatch(part, idx, p, text, m))
+			}
+			idx++
+			return true
+		})
+	})
+	return matches, err
+}
+
+// Replace replaces every match of the regular expression in the paragraphs of the document
+// body, its tables, headers and footers. Unless opts.Literal is set, $1 or ${name} in the
+// replacement refer to the capture groups as in regexp.Regexp.Expand.
+//
+// The returned matches locate the replaced text as it was before the replacement.
+//
+// Example:
+//
+//	matches, err := document.Replace(regexp.MustCompile(`(\w+)@example\.com`), "$1@example.org",
+//		&docx.ReplaceOptions{Format: func(r *docx.Run) { r.Bold(true) }})
+func (rd *RootDoc) Replace(re *regexp.Regexp, replacement string, opts *ReplaceOptions) ([]Match, error) {
+	if opts == nil {
+		opts = &ReplaceOptions{}
+	}
+
+	var matches []Match
+	err := rd.eachPart(opts.SkipHeadersFooters, func(part string, children []DocumentChild) {
+		idx := 0
+		forEachPara(children, func(p *ctypes.Paragraph) bool {
+			_, text := paraSegments(p)
+			found := re.FindAllStringSubmatchIndex(text, -1)
+			if opts.Limit > 0 && len(matches)+len(found) > opts.Limit {
+				found = found[:opts.Limit-len(matches)]
+			}
+			for _, m := range found {
+				matches = append(matches, newMatch(part, idx, p, text, m))
+			}
+
+			// Work backwards so earlier offsets stay valid
+			for i := len(found) - 1; i >= 0; i-- {
+				m := found[i]
+				repl := replacement
+				if !opts.Literal {
+					repl = string(re.ExpandString(nil, replacement, text, m))
+				}
+				rd.replaceMatch(p, m[0], m[1], repl, opts.Format)
+			}
+			idx++
+			return opts.Limit == 0 || len(matches) < opts.Limit
+		})
+	})
+	return matches, err
+}
+
+// replaceMatch replaces the text between start and end with repl, formatting the replacement
+// with format when given.
+func (rd *RootDoc) replaceMatch(p *ctypes.Paragraph, start, end int, repl string, format func(r *Run)) {
+	if format == nil {
+		replaceParaText(p, start, end, repl)
+		return
+	}
+
+	run := &ctypes.Run{
+		Children: []ctypes.RunChild{{Text: ctypes.TextFromString(repl)}},
+	}
+	if from := runAt(p, start); from != nil && from.Property != nil {
+		run.Property = internal.DeepCopy(from.Property)
+	}
+	format(newRun(rd, p, run))
+
+	replaceParaText(p, start, end, "")
+	insertParaRuns(p, start, run)
+}
+
+// eachPart calls fn with the block level content of the document body followed by the
+// content of the headers and footers.
+func (rd *RootDoc) eachPart(bodyOnly bool, fn func(part string, children []DocumentChild)) error {
+	if rd.Document != nil && rd.Document.Body != nil {
+		fn(rd.Document.relativePath, rd.Document.Body.Children)
+	}
+	if bodyOnly {
+		return nil
+	}
+
+	parts, err := rd.HeadersFooters()
+	if err != nil {
+		return err
+	}
+	for _, hf := range parts {
+		fn(hf.Path, hf.Children)
+	}
+	return nil
+}
+
+func newMatch(part string, idx int, p *ctypes.Paragraph, text string, m []int) Match {
+	match := Match{
+		Part:      part,
+		Paragraph: idx,
+		Start:     m[0],
+		End:       m[1],
+		Text:      text[m[0]:m[1]],
+		Para:      p,
+	}
+	for g := 0; g < len(m); g += 2 {
+		if m[g] < 0 {
+			match.Groups = append(match.Groups, "")
+			continue
+		}
+		match.Groups = append(match.Groups, text[m[g]:m[g+1]])
+	}
+	return match
+}
+
+// forEachPara calls fn for every paragraph of the block level content in document order,
+// including the paragraphs of table cells and nested tables. Iteration stops when fn returns false.
+func forEachPara(children []DocumentChild, fn func(p *ctypes.Paragraph) bool) bool {
+	for _, child := range children {
+		if child.Para != nil && !fn(child.Para.GetCT()) {
+			return false
+		}
+		if child.Table != nil && !forEachTablePara(child.Table.GetCT(), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// forEachTablePara calls fn for every paragraph of the table cells, see forEachPara.
+func forEachTablePara(t *ctypes.Table, fn func(p *ctypes.Paragraph) bool) bool {
+	for _, rc := range t.RowContents {
+		if rc.Row == nil {
+			continue
+		}
+		for _, cc := range rc.Row.Contents {
+			if cc.Cell == nil {
+				continue
+			}
+			for _, block := range cc.Cell.Contents {
+				if block.Paragraph != nil && !fn(block.Paragraph) {
+					return false
+				}
+				if block.Table != nil && !forEachTablePara(block.Table, fn) {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,200 @@
+package docx
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+
+	"godocx/common/constants"
+	"godocx/wml/ctypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFind(t *testing.T) {
+	rd := setupRootDoc(t)
+	p := rd.AddParagraph("Invoice INV-")
+	p.AddText("1234 dated today")
+	rd.AddTable().AddRow().AddCell().AddParagraph("see INV-99")
+
+	matches, err := rd.Find(regexp.MustCompile(`INV-(\d+)`))
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+
+	assert.Equal(t, "INV-1234", matches[0].Text)
+	assert.Equal(t, []string{"INV-1234", "1234"}, matches[0].Groups)
+	assert.Equal(t, 8, matches[0].Start)
+	assert.Equal(t, 0, matches[0].Paragraph)
+
+	assert.Equal(t, "INV-99", matches[1].Text)
+	assert.Equal(t, 1, matches[1].Paragraph)
+}
+
+func TestReplace(t *testing.T) {
+	rd := setupRootDoc(t)
+	p := rd.AddParagraph("Contact ")
+	p.AddText("bob@exam").Bold(true)
+	p.AddText("ple.com or alice@example.com")
+
+	re := regexp.MustCompile(`(\w+)@example\.com`)
+	matches, err := rd.Replace(re, "$1@example.org", nil)
+	require.NoError(t, err)
+	assert.Len(t, matches, 2)
+
+	_, text := paraSegments(&p.ct)
+	assert.Equal(t, "Contact bob@example.org or alice@example.org", text)
+	// The first replacement is held by the bold run where it starts
+	assert.Equal(t, "bob@example.org", p.ct.Children[1].Run.Children[0].Text.Text)
+
+	matches, err = rd.Replace(regexp.MustCompile(`alice`), "ALICE", &ReplaceOptions{
+		Format: func(r *Run) { r.Italic(true) },
+	})
+	require.NoError(t, err)
+	assert.Len(t, matches, 1)
+
+	_, text = paraSegments(&p.ct)
+	assert.Equal(t, "Contact bob@example.org or ALICE@example.org", text)
+	run := runAt(&p.ct, len("Contact bob@example.org or "))
+	require.NotNil(t, run.Property)
+	assert.NotNil(t, run.Property.Italic)
+	assert.Equal(t, "ALICE", run.Children[0].Text.Text)
+
+	matches, err = rd.Replace(regexp.MustCompile(`example`), "x", &ReplaceOptions{Limit: 1})
+	require.NoError(t, err)
+	assert.Len(t, matches, 1)
+	_, text = paraSegments(&p.ct)
+	assert.Equal(t, "Contact bob@x.org or ALICE@example.org", text)
+}
+
+func TestFind_TrackedInsertions(t *testing.T) {
+	rd := setupRootDoc(t)
+	p := rd.AddParagraph("Pay ")
+	ins := &ctypes.RunTrackChange{Runs: []*ctypes.Run{
+		{Children: []ctypes.RunChild{{Text: ctypes.TextFromString("five dollars")}}},
+	}}
+	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Ins: ins})
+	p.AddText(" now")
+
+	matches, err := rd.Find(regexp.MustCompile(`five`))
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, 4, matches[0].Start)
+
+	var runs []string
+	for run := range p.Runs() {
+		runs = append(runs, run.Text())
+	}
+	assert.Equal(t, []string{"Pay ", "five dollars", " now"}, runs)
+
+	// Text formatted on replacement stays within the insertion
+	_, err = rd.Replace(regexp.MustCompile(`five`), "ten", &ReplaceOptions{
+		Format: func(r *Run) { r.Bold(true) },
+	})
+	require.NoError(t, err)
+	_, text := paraSegments(&p.ct)
+	assert.Equal(t, "Pay ten dollars now", text)
+	require.Len(t, ins.Runs, 2)
+	assert.Equal(t, "ten", ins.Runs[0].Children[0].Text.Text)
+	assert.NotNil(t, ins.Runs[0].Property.Bold)
+}
+
+func TestReplace_HeadersFooters(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.Document.relativePath = "word/document.xml"
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships, &Relationship{
+		ID:     "rId5",
+		Type:   constants.SourceRelationshipFooter,
+		Target: "footer1.xml",
+	})
+	rd.FileMap.Store("word/footer1.xml", []byte(`<w:ftr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">`+
+		`<w:p><w:r><w:t>Page footer {{company}}</w:t></w:r></w:p></w:ftr>`))
+
+	matches, err := rd.Replace(regexp.MustCompile(`\{\{company\}\}`), "Acme", nil)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "word/footer1.xml", matches[0].Part)
+
+	parts, err := rd.HeadersFooters()
+	require.NoError(t, err)
+	require.Len(t, parts, 1)
+	assert.True(t, parts[0].IsFooter)
+	_, text := paraSegments(&parts[0].Children[0].Para.ct)
+	assert.Equal(t, "Page footer Acme", text)
+}
+
+func TestFind_KeepsUnchangedFooters(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.Document.relativePath = "word/document.xml"
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships, &Relationship{
+		ID:     "rId5",
+		Type:   constants.SourceRelationshipFooter,
+		Target: "footer1.xml",
+	})
+	footer := []byte(`<w:ftr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:p><w:r><w:fldChar w:fldCharType="begin"/></w:r><w:r><w:instrText>PAGE</w:instrText></w:r>` +
+		`<w:r><w:fldChar w:fldCharType="end"/></w:r><w:fldSimple w:instr="NUMPAGES"><w:r><w:t>1</w:t></w:r></w:fldSimple></w:p>` +
+		`<w:sdt><w:sdtContent><w:p><w:r><w:t>Acme</w:t></w:r></w:p></w:sdtContent></w:sdt></w:ftr>`)
+	rd.FileMap.Store("word/footer1.xml", footer)
+	rd.AddParagraph("Body text")
+
+	matches, err := rd.Find(regexp.MustCompile(`PAGE|text`))
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+
+	assert.Equal(t, footer, savedPart(t, rd, "word/footer1.xml"))
+
+	// A changed footer is written from its content
+	parts, err := rd.HeadersFooters()
+	require.NoError(t, err)
+	parts[0].Children[0].Para.AddText("Page")
+	saved := string(savedPart(t, rd, "word/footer1.xml"))
+	assert.Contains(t, saved, "<w:t>Page</w:t>")
+	assert.Contains(t, saved, `<w:r><w:fldChar w:fldCharType="begin"></w:fldChar></w:r><w:r><w:instrText>PAGE</w:instrText></w:r>`+
+		`<w:r><w:fldChar w:fldCharType="end"></w:fldChar></w:r>`)
+	assert.Contains(t, saved, `<w:sdt><w:sdtContent><w:p><w:r><w:t>Acme</w:t></w:r></w:p></w:sdtContent></w:sdt></w:ftr>`)
+}
+
+func TestReplace_KeepsFooterContentControls(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.Document.relativePath = "word/document.xml"
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships, &Relationship{
+		ID:     "rId5",
+		Type:   constants.SourceRelationshipFooter,
+		Target: "footer1.xml",
+	})
+	rd.FileMap.Store("word/footer1.xml", []byte(`<w:ftr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" `+
+		`xmlns:w14="http://schemas.microsoft.com/office/word/2010/wordml">`+
+		`<w:sdt><w:sdtPr><w:docPartObj><w:docPartGallery w:val="Page Numbers (Bottom of Page)"/></w:docPartObj></w:sdtPr>`+
+		`<w:sdtContent><w:p w14:paraId="1A2B3C4D"><w:fldSimple w:instr="PAGE"/></w:p></w:sdtContent></w:sdt>`+
+		`<w:p><w:r><w:t>ACME Corp</w:t></w:r></w:p>`+
+		`<w:bookmarkStart w:id="0" w:name="end"/><w:bookmarkEnd w:id="0"/></w:ftr>`))
+
+	_, err := rd.Replace(regexp.MustCompile(`ACME Corp`), "Globex", nil)
+	require.NoError(t, err)
+
+	saved := string(savedPart(t, rd, "word/footer1.xml"))
+	sdt := strings.Index(saved, `<w:sdt><w:sdtPr><w:docPartObj><w:docPartGallery w:val="Page Numbers (Bottom of Page)"/>`)
+	text := strings.Index(saved, "<w:t>Globex</w:t>")
+	bookmark := strings.Index(saved, `<w:bookmarkStart w:id="0" w:name="end"></w:bookmarkStart><w:bookmarkEnd w:id="0"></w:bookmarkEnd></w:ftr>`)
+	assert.True(t, sdt >= 0 && sdt < text && text < bookmark, saved)
+	assert.Contains(t, saved, `<w:p w14:paraId="1A2B3C4D"><w:fldSimple w:instr="PAGE"/></w:p>`)
+}
+
+// savedPart saves the document and returns the content of a part of the saved package.
+func savedPart(t *testing.T, rd *RootDoc, path string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, rd.Write(&buf))
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	f, err := zr.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	content, err := io.ReadAll(f)
+	require.NoError(t, err)
+	return content
+}
@@ -0,0 +1,144 @@
+package docx
+
+import (
+	"fmt"
+
+	"godocx/common/units"
+	"godocx/internal"
+)
+
+// Fragment is block content built apart from a document and inserted into it later with
+// RootDoc.Insert.
+//
+// A fragment keeps the hyperlinks, images and list instances of its content to itself, and
+// reads copies of the styles, the abstract numberings and the page size of the document taken
+// when creating it, so fragments may be built on separate goroutines while the document is
+// used. List instances of the fragment must be created with Fragment.NewListInstance.
+type Fragment struct {
+	rd *RootDoc // Document holding the content of the fragment
+}
+
+// NewFragment returns an empty fragment for the document.
+//
+// Example:
+//
+//	frag := docx.NewFragment(document)
+//	frag.AddHeading("Appendix", 1)
+//	frag.AddParagraph("Details")
+//	err := document.Insert(frag, 3)
+func NewFragment(rd *RootDoc) *Fragment {
+	frag := &RootDoc{}
+	if rd.DocStyles != nil {
+		frag.DocStyles = internal.DeepCopy(rd.DocStyles)
+	}
+	frag.Numbering = NewNumberingManager(frag)
+	if rd.Numbering != nil {
+		// List instances of the fragment refer to the abstract numberings of the document
+		if defs := rd.Numbering.definitions(); defs != nil {
+			defs.Nums = nil
+			frag.Numbering.numbering = defs
+		}
+	}
+	frag.Document = &Document{
+		Root:         frag,
+		Body:         NewBody(frag),
+		relativePath: rd.Document.relativePath,
+	}
+	if rd.Document.Body.SectPr != nil {
+		frag.Document.Body.SectPr = internal.DeepCopy(rd.Document.Body.SectPr)
+	}
+	return &Fragment{rd: frag}
+}
+
+// Children returns the paragraphs and tables of the fragment.
+func (f *Fragment) Children() []DocumentChild {
+	return f.rd.Document.Body.Children
+}
+
+// AddParagraph adds a paragraph with the given text to the fragment.
+func (f *Fragment) AddParagraph(text string) *Paragraph {
+	return f.rd.AddParagraph(text)
+}
+
+// AddEmptyParagraph adds an empty paragraph to the fragment.
+func (f *Fragment) AddEmptyParagraph() *Paragraph {
+	return f.rd.AddEmptyParagraph()
+}
+
+// AddHeading adds a heading paragraph to the fragment, see RootDoc.AddHeading.
+func (f *Fragment) AddHeading(text string, level uint) (*Paragraph, error) {
+	return f.rd.AddHeading(text, level)
+}
+
+// AddTable adds an empty table to the fragment.
+func (f *Fragment) AddTable() *Table {
+	return f.rd.AddTable()
+}
+
+// AddImage adds a paragraph holding the image to the fragment, see RootDoc.AddImage.
+func (f *Fragment) AddImage(imgBytes []byte, width, height units.Units) (*PicMeta, error) {
+	return f.rd.AddImage(imgBytes, width, height)
+}
+
+// NewListInstance creates a numbering instance of the abstract numbering for the paragraphs
+// of the fragment, see RootDoc.NewListInstance. The instance is created in the document when
+// the fragment is inserted.
+func (f *Fragment) NewListInstance(abstractNumID int) int {
+	return f.rd.NewListInstance(abstractNumID)
+}
+
+// AppendMarkdown converts Markdown and adds it to the fragment, see RootDoc.AppendMarkdown.
+func (f *Fragment) AppendMarkdown(src []byte, opts *MarkdownImportOptions) error {
+	return f.rd.AppendMarkdown(src, opts)
+}
+
+// AppendHTML converts an HTML fragment and adds it to the fragment, see RootDoc.AppendHTML.
+func (f *Fragment) AppendHTML(src []byte, opts *HTMLImportOptions) error {
+	return f.rd.AppendHTML(src, opts)
+}
+
+// Insert inserts a copy of the content of the fragment in the document body before the child
+// at the given index, len(Body.Children) appending it. The hyperlinks and images of the
+// fragment are added to the document and its list instances are created in the document.
+//
+// Either all the content is inserted or, on error, the document is left unchanged. Insertions
+// of fragments in a document are serialized, but must not run concurrently with other changes
+// of the document. The fragment may be inserted again, in this or another document.
+func (rd *RootDoc) Insert(f *Fragment, index int) error {
+	rd.insertMu.Lock()
+	defer rd.insertMu.Unlock()
+
+	if index < 0 || index > len(rd.Document.Body.Children) {
+		return fmt.Errorf("insert position %d out of range [0, %d]", index, len(rd.Document.Body.Children))
+	}
+
+	instances := map[int]int{}
+	if defs := f.rd.Numbering.definitions(); defs != nil {
+		for _, num := range defs.Nums {
+			instances[num.ID] = num.AbstractNumID.Val
+		}
+	}
+
+	ci := &contentImporter{
+		src: f.rd,
+		dst: rd,
+		listInstance: func(numID int) (int, bool) {
+			abstractNumID, ok := instances[numID]
+			if !ok {
+				return 0, false
+			}
+			return rd.NewListInstance(abstractNumID), true
+		},
+	}
+	if err := ci.prepare(f.Children()); err != nil {
+		return fmt.Errorf("fragment: %w", err)
+	}
+	children, err := ci.commit()
+	if err != nil {
+		return fmt.Errorf("fragment: %w", err)
+	}
+
+	body := rd.Document.Body
+	body.Children = append(body.Children[:index], append(children, body.Children[index:]...)...)
+	return nil
+}
@@ -0,0 +1,151 @@
+package docx
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"godocx/common/units"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFragmentInsert(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.Numbering = NewNumberingManager(rd)
+	rd.AddParagraph("first")
+	rd.AddParagraph("last")
+	_, err := rd.AddImage([]byte("GIF89a"), units.Emu(9525), units.Emu(9525))
+	require.NoError(t, err)
+	rd.NewListInstance(1)
+	imageCount := rd.ImageCount
+
+	frags := make([]*Fragment, 3)
+	var wg sync.WaitGroup
+	for i := range frags {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			frag := NewFragment(rd)
+			numID := frag.NewListInstance(1)
+			p := frag.AddParagraph(fmt.Sprintf("item %d ", i))
+			p.Numbering(numID, 0)
+			p.AddLink("link", fmt.Sprintf("https://example.com/%d", i))
+			if _, err := frag.AddImage([]byte("GIF89a"), units.Emu(9525), units.Emu(9525)); err != nil {
+				t.Error(err)
+			}
+			frags[i] = frag
+		}()
+	}
+	wg.Wait()
+
+	for i := len(frags) - 1; i >= 0; i-- {
+		require.NoError(t, rd.Insert(frags[i], 1))
+	}
+	assert.Equal(t, "first\nitem 0 link\n\nitem 1 link\n\nitem 2 link\n\nlast\n\n", bodyText(t, rd))
+	assert.Equal(t, imageCount+3, rd.ImageCount)
+
+	var numIDs []int
+	targets := map[string]bool{}
+	for i := range frags {
+		p := rd.Document.Body.Children[1+2*i].Para
+		numIDs = append(numIDs, p.GetCT().Property.NumProp.NumID.Val)
+		rel := rd.Document.relationship(p.GetCT().Children[1].Link.ID)
+		require.NotNil(t, rel)
+		assert.Equal(t, fmt.Sprintf("https://example.com/%d", i), rel.Target)
+
+		img := rd.Document.Body.Children[2+2*i].Para.GetCT().Children[0].Run.Children[0].Drawing.Inline[0]
+		partPath, _, ok := rd.imagePart(img.Graphic.Data.Pic.BlipFill.Blip.EmbedID)
+		require.True(t, ok)
+		targets[partPath] = true
+		assert.NotEqual(t, uint64(1), img.DocProp.ID)
+	}
+	// Each fragment gets its own list instance, after the one of the document
+	assert.Equal(t, []int{4, 3, 2}, numIDs)
+	assert.Len(t, targets, 3)
+	assert.NotContains(t, targets, fmt.Sprintf("word/media/image%d.gif", imageCount))
+
+	// A fragment referencing a missing part leaves the document unchanged
+	frag := NewFragment(rd)
+	frag.AddParagraph("broken").AddLink("x", "https://example.com").ct.ID = "rId99"
+	count := len(rd.Document.Body.Children)
+	assert.ErrorContains(t, rd.Insert(frag, 0), "relationship rId99 not found")
+	assert.Len(t, rd.Document.Body.Children, count)
+	assert.Error(t, rd.Insert(frags[0], count+1))
+}
+
+func TestFragmentStylesCopy(t *testing.T) {
+	rd := setupRootDoc(t)
+	require.NoError(t, rd.AddCharacterStyle("Strong", "Strong").Err())
+	frag := NewFragment(rd)
+	frag.AddParagraph("").AddText("bold").Style("Strong")
+
+	// Styles added to the document afterwards are not seen by the fragment
+	require.NoError(t, rd.AddCharacterStyle("Later", "Later").Err())
+	assert.NotSame(t, rd.DocStyles, frag.rd.DocStyles)
+	assert.Len(t, frag.rd.DocStyles.StyleList, 1)
+
+	require.NoError(t, rd.Insert(frag, 0))
+	var styles []string
+	for run := range rd.Document.Body.Children[0].Para.Runs() {
+		if run.ct.Property != nil && run.ct.Property.Style != nil {
+			styles = append(styles, run.ct.Property.Style.Val)
+		}
+	}
+	assert.Equal(t, []string{"Strong"}, styles)
+}
+
+func TestFragmentListAbstract(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.FileMap.Store(numberingPath, []byte(`<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">`+
+		`<w:abstractNum w:abstractNumId="1"><w:lvl w:ilvl="0"><w:numFmt w:val="upperRoman"/></w:lvl></w:abstractNum></w:numbering>`))
+	rd.Numbering = NewNumberingManager(rd)
+	direct := rd.NewListInstance(1)
+
+	frag := NewFragment(rd)
+	frag.AddParagraph("item").Numbering(frag.NewListInstance(1), 0)
+	require.NoError(t, rd.Insert(frag, 0))
+
+	defs, err := rd.Numbering.Definitions()
+	require.NoError(t, err)
+	inserted := rd.Document.Body.Children[0].Para.GetCT().Property.NumProp.NumID.Val
+	assert.NotEqual(t, direct, inserted)
+	assert.Equal(t, 1, defs.NumByID(direct).AbstractNumID.Val)
+	assert.Equal(t, 1, defs.NumByID(inserted).AbstractNumID.Val)
+	assert.Len(t, defs.AbstractNums, 1)
+}
+
+func TestContentImporterRollback(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.AddParagraph("first")
+	rels, overrides, imageCount := len(rd.Document.DocRels.Relationships), len(rd.ContentType.Override), rd.ImageCount
+
+	frag := NewFragment(rd)
+	frag.AddParagraph("").AddLink("link", "https://example.com")
+	_, err := frag.AddImage([]byte("GIF89a"), units.Emu(9525), units.Emu(9525))
+	require.NoError(t, err)
+
+	ci := &contentImporter{src: frag.rd, dst: rd}
+	require.NoError(t, ci.prepare(frag.Children()))
+	saved := importState{
+		rels:       rels,
+		rID:        rd.Document.RID,
+		imageCount: imageCount,
+		defaults:   len(rd.ContentType.Default),
+		overrides:  overrides,
+	}
+	_, err = ci.commit()
+	require.NoError(t, err)
+	require.Len(t, ci.stored, 1)
+	stored := ci.stored[0]
+	_, ok := rd.FileMap.Load(stored)
+	require.True(t, ok)
+
+	ci.rollback(saved)
+	assert.Len(t, rd.Document.DocRels.Relationships, rels)
+	assert.Len(t, rd.ContentType.Override, overrides)
+	assert.Equal(t, imageCount, rd.ImageCount)
+	_, ok = rd.FileMap.Load(stored)
+	assert.False(t, ok)
+}
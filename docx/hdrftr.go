@@ -0,0 +1,216 @@
+package docx
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strings"
+
+	"godocx/common/constants"
+)
+
+// HeaderFooter represents a header (w:hdr) or footer (w:ftr) part of the document.
+//
+// Header and footer parts are read from the package the first time they are requested
+// through RootDoc.HeadersFooters. Once read, the parts whose content was changed are written
+// back from this structure when the document is saved, the other parts being kept as they
+// were read. Block elements other than paragraphs and tables, such as content controls holding
+// page numbers, are kept as read and written back after the child preceding them.
+type HeaderFooter struct {
+	root     *RootDoc
+	Path     string // Path of the part within the package, e.g. word/header1.xml
+	RelID    string // Relationship ID referencing the part from the document
+	IsFooter bool
+	Children []DocumentChild
+
+	read    []byte     // Structure of the part as read, marshalled to detect changes
+	nsAttrs []xml.Attr // Namespace declarations of the part as read
+	raw     []rawBlock // Block elements not held by Children
+}
+
+// rawBlock is a block element of a header or footer kept as read.
+type rawBlock struct {
+	after   DocumentChild // Child preceding the element, none for the first elements
+	name    string        // Qualified name of the element, e.g. w:sdt
+	attrs   []xml.Attr
+	content string
+}
+
+// prefixed returns the name with the prefix declared for its namespace by the attributes.
+func prefixed(name xml.Name, nsAttrs []xml.Attr) string {
+	switch name.Space {
+	case "":
+		return name.Local
+	case "xmlns":
+		return "xmlns:" + name.Local
+	}
+	for _, attr := range nsAttrs {
+		if attr.Value == name.Space {
+			return strings.TrimPrefix(attr.Name.Local, "xmlns:") + ":" + name.Local
+		}
+	}
+	return name.Local
+}
+
+// cloneRaw returns the raw elements of the part for copies of its children, in the same order.
+func (hf *HeaderFooter) cloneRaw(children []DocumentChild) []rawBlock {
+	raw := slices.Clone(hf.raw)
+	for i := range raw {
+		if j := slices.Index(hf.Children, raw[i].after); j >= 0 {
+			raw[i].after = children[j]
+		}
+	}
+	return raw
+}
+
+// changed returns the content of the part if it was changed since it was read, nil otherwise.
+func (hf *HeaderFooter) changed() ([]byte, error) {
+	content, err := marshal(hf)
+	if err != nil || bytes.Equal(content, hf.read) {
+		return nil, err
+	}
+	return content, nil
+}
+
+// MarshalXML implements the xml.Marshaler interface for the HeaderFooter type.
+func (hf *HeaderFooter) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
+	start.Name.Local = "w:hdr"
+	if hf.IsFooter {
+		start.Name.Local = "w:ftr"
+	}
+	start.Attr = append(start.Attr, docAttrs...)
+	for _, attr := range hf.nsAttrs {
+		if !slices.ContainsFunc(start.Attr, func(a xml.Attr) bool { return a.Name.Local == attr.Name.Local }) {
+			start.Attr = append(start.Attr, attr)
+		}
+	}
+
+	if err = e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	// The raw elements whose preceding child was removed are written at the end
+	written := make([]bool, len(hf.raw))
+	marshalRaw := func(after DocumentChild) error {
+		for i, raw := range hf.raw {
+			if written[i] || raw.after != after {
+				continue
+			}
+			written[i] = true
+			content := struct {
+				Content string `xml:",innerxml"`
+			}{raw.content}
+			if err := e.EncodeElement(content, xml.StartElement{Name: xml.Name{Local: raw.name}, Attr: raw.attrs}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err = marshalRaw(DocumentChild{}); err != nil {
+		return err
+	}
+	for _, child := range hf.Children {
+		if err = marshalBlockChildren(e, []DocumentChild{child}); err != nil {
+			return err
+		}
+		if err = marshalRaw(child); err != nil {
+			return err
+		}
+	}
+	for i, raw := range hf.raw {
+		if !written[i] {
+			if err = marshalRaw(raw.after); err != nil {
+				return err
+			}
+		}
+	}
+
+	return e.EncodeToken(xml.EndElement{Name: start.Name})
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface for the HeaderFooter type.
+func (hf *HeaderFooter) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	hf.IsFooter = start.Name.Local == "ftr"
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" {
+			hf.nsAttrs = append(hf.nsAttrs, xml.Attr{Name: xml.Name{Local: "xmlns:" + attr.Name.Local}, Value: attr.Value})
+		}
+	}
+
+	for {
+		var currentToken xml.Token
+		if currentToken, err = d.Token(); err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			switch elem.Name.Local {
+			case "p", "tbl":
+				var child DocumentChild
+				if child, err = decodeBlockChild(hf.root, d, elem); err != nil {
+					return err
+				}
+				hf.Children = append(hf.Children, child)
+			default:
+				var elemXML struct {
+					Content string `xml:",innerxml"`
+				}
+				if err = d.DecodeElement(&elemXML, &elem); err != nil {
+					return err
+				}
+				raw := rawBlock{name: prefixed(elem.Name, hf.nsAttrs), content: elemXML.Content}
+				for _, attr := range elem.Attr {
+					raw.attrs = append(raw.attrs, xml.Attr{Name: xml.Name{Local: prefixed(attr.Name, hf.nsAttrs)}, Value: attr.Value})
+				}
+				if len(hf.Children) > 0 {
+					raw.after = hf.Children[len(hf.Children)-1]
+				}
+				hf.raw = append(hf.raw, raw)
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// HeadersFooters returns the header and footer parts referenced by the document, in the order
+// of the document relationships.
+func (rd *RootDoc) HeadersFooters() ([]*HeaderFooter, error) {
+	if rd.hdrFtrLoaded {
+		return rd.hdrFtr, nil
+	}
+
+	var parts []*HeaderFooter
+	for _, rel := range rd.Document.DocRels.Relationships {
+		if rel.Type != constants.SourceRelationshipHeader && rel.Type != constants.SourceRelationshipFooter {
+			continue
+		}
+
+		partPath := rd.Document.partPath(rel.Target)
+		content, ok := rd.FileMap.Load(partPath)
+		if !ok {
+			return nil, fmt.Errorf("part %s referenced by %s not found", partPath, rel.ID)
+		}
+
+		hf := &HeaderFooter{
+			root:  rd,
+			Path:  partPath,
+			RelID: rel.ID,
+		}
+		if err := xml.Unmarshal(content.([]byte), hf); err != nil {
+			return nil, fmt.Errorf("%s: %w", partPath, err)
+		}
+		read, err := marshal(hf)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", partPath, err)
+		}
+		hf.read = read
+		parts = append(parts, hf)
+	}
+
+	rd.hdrFtr = parts
+	rd.hdrFtrLoaded = true
+	return parts, nil
+}
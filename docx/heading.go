@@ -3,14 +3,19 @@ package docx
 import (
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
 )
 
 // Return a heading paragraph newly added to the end of the document.
 // The heading paragraph will contain text and have its paragraph style determined by level.
 // If level is 0, the style is set to Title.
 // The style is set to Heading {level}.
+// The style is looked up by its built-in name, such as "heading 1", so that templates with
+// localised style IDs are supported.
 // if level is outside the range 0-9, error will be returned
 func (rd *RootDoc) AddHeading(text string, level uint) (*Paragraph, error) {
 	if level < 0 || level > 9 {
@@ -18,14 +23,17 @@ func (rd *RootDoc) AddHeading(text string, level uint) (*Paragraph, error) {
 	}
 
 	p := newParagraph(rd)
-	p.ct.Property = ctypes.DefaultParaProperty()
+	p.GetCT().Property = ctypes.DefaultParaProperty()
 
 	style := "Title"
 	if level != 0 {
 		style = fmt.Sprintf("Heading%d", level)
 	}
+	if found := rd.lookupStyle(style, stypes.StyleTypeParagraph); found != nil {
+		style = *found.ID
+	}
 
-	p.ct.Property.Style = ctypes.NewParagraphStyle(style)
+	p.GetCT().Property.Style = ctypes.NewParagraphStyle(style)
 
 	bodyElem := DocumentChild{
 		Para: p,
@@ -35,3 +43,46 @@ func (rd *RootDoc) AddHeading(text string, level uint) (*Paragraph, error) {
 	p.AddText(text)
 	return p, nil
 }
+
+// headingLevel returns the heading level of the paragraph: 0 for the Title style, 1 to 9 for
+// the heading styles or outline levels, and -1 for body text. The style hierarchy is followed.
+func (rd *RootDoc) headingLevel(p *ctypes.Paragraph) int {
+	if p.Property == nil {
+		return -1
+	}
+	if p.Property.OutlineLvl != nil && p.Property.OutlineLvl.Val < 9 {
+		return p.Property.OutlineLvl.Val + 1
+	}
+	if p.Property.Style == nil {
+		return -1
+	}
+
+	styleID := p.Property.Style.Val
+	for range 10 {
+		style := rd.GetStyleByID(styleID, stypes.StyleTypeParagraph)
+		names := []string{styleID}
+		if style != nil && style.Name != nil {
+			names = append(names, style.Name.Val)
+		}
+		for _, name := range names {
+			name = strings.ToLower(strings.ReplaceAll(name, " ", ""))
+			if name == "title" {
+				return 0
+			}
+			if level, err := strconv.Atoi(strings.TrimPrefix(name, "heading")); err == nil && strings.HasPrefix(name, "heading") && level >= 1 && level <= 9 {
+				return level
+			}
+		}
+		if style == nil {
+			break
+		}
+		if style.ParaProp != nil && style.ParaProp.OutlineLvl != nil && style.ParaProp.OutlineLvl.Val < 9 {
+			return style.ParaProp.OutlineLvl.Val + 1
+		}
+		if style.BasedOn == nil {
+			break
+		}
+		styleID = style.BasedOn.Val
+	}
+	return -1
+}
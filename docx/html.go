@@ -0,0 +1,1090 @@
+package docx
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"godocx/common/constants"
+	"godocx/dml"
+	"godocx/dml/dmlct"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+)
+
+// HTMLOptions configures the HTML export of RootDoc.ToHTML.
+type HTMLOptions struct {
+	// ImageDir is the directory the images of the document are extracted to, it is created
+	// if needed. Images are embedded as data URIs when ImageDir is empty.
+	ImageDir string
+
+	// ImageURL is the prefix of the image sources written in the HTML, e.g. "/assets/doc1".
+	// It defaults to ImageDir.
+	ImageURL string
+
+	// Fragment writes a style element followed by a div element holding the content, to be
+	// included in another page, instead of a complete HTML document.
+	Fragment bool
+
+	// Title is the title of the HTML document.
+	Title string
+}
+
+// ToHTML writes the document body as HTML5.
+//
+// Heading styles become h1 to h6 elements, list paragraphs become ol and ul elements nested by
+// their level, bold, italic, underlined, struck through, superscript and subscript runs become
+// strong, em, u, s, sup and sub elements and tables keep their merged cells as colspan and
+// rowspan. The formatting resolved from the document defaults, the styles and the direct
+// formatting (fonts, sizes, colours, alignment, indentation, spacing, borders and shading) is
+// written as CSS: a class for each paragraph style and inline styles for the rest.
+// Hyperlinks are resolved through the document relationships, footnotes and endnotes are
+// written at the end of the document and linked from their references. Images are embedded
+// as data URIs unless opts.ImageDir is set.
+//
+// Example:
+//
+//	f, _ := os.Create("page.html")
+//	defer f.Close()
+//	err := document.ToHTML(f, &docx.HTMLOptions{Title: "Report"})
+func (rd *RootDoc) ToHTML(w io.Writer, opts *HTMLOptions) error {
+	if opts == nil {
+		opts = &HTMLOptions{}
+	}
+
+	hw := &htmlWriter{
+		rd:      rd,
+		opts:    opts,
+		labels:  newListLabeler(rd),
+		images:  map[string]string{},
+		fonts:   map[ctypes.RunFonts]string{},
+		classes: map[string]htmlClass{},
+		tables:  map[string]string{},
+	}
+	hw.body = hw.runDecls(rd.paraRunProp(nil, nil))
+
+	if rd.Document != nil && rd.Document.Body != nil {
+		var err error
+		if hw.footnotes, err = rd.notes(constants.SourceRelationshipFootnotes); err != nil {
+			return err
+		}
+		if hw.endnotes, err = rd.notes(constants.SourceRelationshipEndnotes); err != nil {
+			return err
+		}
+		hw.writeBlocks(rd.Document.Body.Children)
+		hw.writeNotes()
+	}
+	if hw.err != nil {
+		return hw.err
+	}
+	_, err := io.WriteString(w, hw.page())
+	return err
+}
+
+// htmlWriter accumulates the HTML of the document body and the CSS rules it uses.
+type htmlWriter struct {
+	rd     *RootDoc
+	opts   *HTMLOptions
+	labels *listLabeler
+	images map[string]string          // package path of images -> source
+	fonts  map[ctypes.RunFonts]string // resolved font families
+
+	body    cssDecls             // run formatting of the default paragraph style
+	classes map[string]htmlClass // paragraph style ID -> class
+	tables  map[string]string    // table borders -> class
+	rules   []cssRule
+
+	footnotes, endnotes map[int]*note
+	noteRefs            []htmlNoteRef // notes in the order of their first reference
+
+	lists []htmlList    // open lists, the outermost first
+	cell  *cellPosition // cell being written, for the conditional formatting of its table
+	sb    strings.Builder
+	err   error
+}
+
+// htmlClass is the CSS class of a paragraph style.
+type htmlClass struct {
+	name      string
+	paraDecls cssDecls // paragraph formatting of the style
+}
+
+// htmlList is an open ol or ul element.
+type htmlList struct {
+	numID int
+	level int
+	tag   string
+	value int // counter value of the last item
+}
+
+// htmlNoteRef is a footnote or an endnote referenced from the content.
+type htmlNoteRef struct {
+	kind   string // "fn" for footnotes, "en" for endnotes
+	number int
+	note   *note
+}
+
+// htmlSpan is a piece of inline content sharing the same formatting.
+type htmlSpan struct {
+	open, close string
+	content     string
+}
+
+// page returns the complete HTML document, or the fragment when opts.Fragment is set.
+func (hw *htmlWriter) page() string {
+	scope := "body"
+	if hw.opts.Fragment {
+		scope = ".document"
+	}
+
+	var css strings.Builder
+	base := []cssRule{
+		{selectors: []string{""}, decls: hw.body},
+		{selectors: []string{"p", "h1", "h2", "h3", "h4", "h5", "h6"}, decls: cssDecls{{"margin", "0"}, {"font-size", "inherit"}, {"font-weight", "inherit"}}},
+		{selectors: []string{"table"}, decls: cssDecls{{"border-collapse", "collapse"}}},
+		{selectors: []string{"td", "th"}, decls: cssDecls{{"vertical-align", "top"}, {"padding", "0 5.4pt"}, {"text-align", "inherit"}, {"font-weight", "inherit"}}},
+		{selectors: []string{".tab"}, decls: cssDecls{{"white-space", "pre"}}},
+	}
+	for _, rule := range append(base, hw.rules...) {
+		selectors := make([]string, len(rule.selectors))
+		for i, sel := range rule.selectors {
+			selectors[i] = strings.TrimSpace(scope + " " + sel)
+		}
+		fmt.Fprintf(&css, "%s { %s }\n", strings.Join(selectors, ", "), rule.decls)
+	}
+
+	var sb strings.Builder
+	if hw.opts.Fragment {
+		sb.WriteString("<style>\n" + css.String() + "</style>\n")
+		sb.WriteString("<div class=\"document\">\n" + hw.sb.String() + "</div>\n")
+		return sb.String()
+	}
+
+	sb.WriteString("<!DOCTYPE html>\n")
+	if lang := hw.lang(); lang != "" {
+		sb.WriteString("<html lang=\"" + html.EscapeString(lang) + "\">\n")
+	} else {
+		sb.WriteString("<html>\n")
+	}
+	sb.WriteString("<head>\n<meta charset=\"utf-8\">\n")
+	sb.WriteString("<title>" + html.EscapeString(hw.opts.Title) + "</title>\n")
+	sb.WriteString("<style>\n" + css.String() + "</style>\n")
+	sb.WriteString("</head>\n<body>\n")
+	sb.WriteString(hw.sb.String())
+	sb.WriteString("</body>\n</html>\n")
+	return sb.String()
+}
+
+// lang returns the default language of the document.
+func (hw *htmlWriter) lang() string {
+	rp := hw.rd.paraRunProp(nil, nil)
+	if rp.Lang == nil || rp.Lang.Val == nil {
+		return ""
+	}
+	return *rp.Lang.Val
+}
+
+func (hw *htmlWriter) writeBlocks(children []DocumentChild) {
+	for _, child := range children {
+		if child.Para != nil {
+			hw.writePara(child.Para.GetCT())
+		}
+		if child.Table != nil {
+			hw.writeTable(child.Table.GetCT())
+		}
+	}
+	hw.closeLists(0)
+}
+
+func (hw *htmlWriter) writePara(p *ctypes.Paragraph) {
+	item, isItem := hw.labels.item(p)
+	class := hw.paraClass(paraStyleID(p))
+	decls := hw.paraDecls(hw.rd.resolvedParaProp(p, hw.cell))
+	content := hw.inline(p)
+
+	if level := hw.rd.headingLevel(p); level >= 0 {
+		hw.closeLists(0)
+		tag := "h" + strconv.Itoa(min(max(level, 1), 6))
+		if isItem && item.label != "" {
+			content = "<span class=\"label\">" + html.EscapeString(item.label) + "</span> " + content
+		}
+		hw.sb.WriteString("<" + tag + htmlAttrs(class.name, decls.diff(class.paraDecls, true)) + ">")
+		hw.sb.WriteString(content + "</" + tag + ">\n")
+		return
+	}
+
+	if isItem {
+		// The list elements indent their items
+		decls = decls.without("margin-left", "text-indent")
+		attrs := hw.openItem(item)
+		hw.sb.WriteString("<li" + attrs + htmlAttrs(class.name, decls.diff(class.paraDecls, true)) + ">")
+		hw.sb.WriteString(content)
+		return
+	}
+
+	hw.closeLists(0)
+	if content == "" {
+		// Empty paragraphs keep their line
+		content = "<br>"
+	}
+	hw.sb.WriteString("<p" + htmlAttrs(class.name, decls.diff(class.paraDecls, true)) + ">")
+	hw.sb.WriteString(content + "</p>\n")
+}
+
+// openItem closes the lists and the items the list item does not belong to and opens its list
+// if needed. It returns the value attribute of the li element when the item does not follow
+// the numbering of the previous item.
+func (hw *htmlWriter) openItem(item listItem) string {
+	tag, typ := "ol", ""
+	switch stypes.NumFmt(item.format) {
+	case stypes.NumFmtLowerLetter:
+		typ = "a"
+	case stypes.NumFmtUpperLetter:
+		typ = "A"
+	case stypes.NumFmtLowerRoman:
+		typ = "i"
+	case stypes.NumFmtUpperRoman:
+		typ = "I"
+	}
+	if item.bullet {
+		tag, typ = "ul", ""
+	}
+
+	for len(hw.lists) > 0 {
+		top := hw.lists[len(hw.lists)-1]
+		if top.level < item.level || (top.level == item.level && top.numID == item.numID && top.tag == tag) {
+			break
+		}
+		hw.closeLists(len(hw.lists) - 1)
+	}
+
+	if n := len(hw.lists); n > 0 && hw.lists[n-1].level == item.level {
+		top := &hw.lists[n-1]
+		hw.sb.WriteString("</li>\n")
+		attrs := ""
+		if tag == "ol" && item.value != top.value+1 {
+			attrs = " value=\"" + strconv.Itoa(item.value) + "\""
+		}
+		top.value = item.value
+		return attrs
+	}
+
+	attrs := ""
+	if typ != "" {
+		attrs += " type=\"" + typ + "\""
+	}
+	if tag == "ol" && item.value != 1 {
+		attrs += " start=\"" + strconv.Itoa(item.value) + "\""
+	}
+	hw.sb.WriteString("<" + tag + attrs + ">\n")
+	hw.lists = append(hw.lists, htmlList{numID: item.numID, level: item.level, tag: tag, value: item.value})
+	return ""
+}
+
+// closeLists closes the open lists from the given depth.
+func (hw *htmlWriter) closeLists(depth int) {
+	for len(hw.lists) > depth {
+		top := hw.lists[len(hw.lists)-1]
+		hw.sb.WriteString("</li>\n</" + top.tag + ">\n")
+		hw.lists = hw.lists[:len(hw.lists)-1]
+	}
+}
+
+// paraClass returns the class of a paragraph style, registering its CSS rule on first use.
+func (hw *htmlWriter) paraClass(styleID string) htmlClass {
+	if styleID == "" {
+		styleID = hw.rd.defaultStyleID(stypes.StyleTypeParagraph)
+	}
+	if class, ok := hw.classes[styleID]; ok {
+		return class
+	}
+
+	var stub *ctypes.Paragraph
+	if styleID != "" {
+		stub = &ctypes.Paragraph{Property: &ctypes.ParagraphProp{Style: &ctypes.CTString{Val: styleID}}}
+	}
+	class := htmlClass{paraDecls: hw.paraDecls(hw.rd.resolvedParaProp(stub, nil))}
+	if styleID != "" {
+		class.name = "s-" + htmlClassRe.ReplaceAllString(styleID, "-")
+		runDecls := hw.runDecls(hw.rd.paraRunProp(stub, nil))
+		decls := append(append(cssDecls{}, class.paraDecls...), runDecls.diff(hw.body, false)...)
+		if len(decls) > 0 {
+			hw.rules = append(hw.rules, cssRule{selectors: []string{"." + class.name}, decls: decls})
+		}
+	}
+	hw.classes[styleID] = class
+	return class
+}
+
+var htmlClassRe = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// inline renders the runs and hyperlinks of the paragraph, with the tracked insertions and
+// without the tracked deletions. The formatting of the runs is written against the run
+// formatting of the paragraph style, held by its class, so that the formatting of the table
+// style of a cell is written with the runs.
+func (hw *htmlWriter) inline(p *ctypes.Paragraph) string {
+	paraRun := hw.rd.paraRunProp(p, nil)
+
+	var spans []htmlSpan
+	for _, child := range p.Children {
+		if child.Run != nil {
+			spans = append(spans, hw.runSpans(p, paraRun, child.Run)...)
+		}
+		if child.Ins != nil {
+			for _, r := range child.Ins.Runs {
+				spans = append(spans, hw.runSpans(p, paraRun, r)...)
+			}
+		}
+		if child.Link == nil {
+			continue
+		}
+
+		href := ""
+		if child.Link.Anchor != "" {
+			href = "#" + child.Link.Anchor
+		}
+		if child.Link.ID != "" {
+			if rel := hw.rd.Document.relationship(child.Link.ID); rel != nil {
+				href = safeHref(rel.Target)
+			}
+		}
+		var linked []htmlSpan
+		for _, r := range child.Link.Runs() {
+			linked = append(linked, hw.runSpans(p, paraRun, r)...)
+		}
+		content := renderHTMLSpans(linked)
+		if href != "" && content != "" {
+			content = "<a href=\"" + html.EscapeString(href) + "\">" + content + "</a>"
+		}
+		spans = append(spans, htmlSpan{content: content})
+	}
+	return renderHTMLSpans(spans)
+}
+
+// runSpans renders the content of a run: text with the formatting of the run, images and
+// note references. Hidden runs are left out.
+func (hw *htmlWriter) runSpans(p *ctypes.Paragraph, paraRun *ctypes.RunProperty, r *ctypes.Run) []htmlSpan {
+	rp := hw.rd.resolvedRunProp(p, hw.cell, r)
+	if rp.Vanish.Bool() {
+		return nil
+	}
+	open, close := hw.runFormat(rp, paraRun)
+
+	var spans []htmlSpan
+	var text strings.Builder
+	flush := func() {
+		if text.Len() > 0 {
+			spans = append(spans, htmlSpan{open: open, close: close, content: text.String()})
+			text.Reset()
+		}
+	}
+	for _, child := range r.Children {
+		switch {
+		case child.Tab != nil:
+			text.WriteString("<span class=\"tab\">&#9;</span>")
+		case child.Break != nil:
+			text.WriteString("<br>")
+		case child.FootnoteReference != nil:
+			flush()
+			spans = append(spans, htmlSpan{content: hw.noteRef("fn", child.FootnoteReference.ID)})
+		case child.EndnoteReference != nil:
+			flush()
+			spans = append(spans, htmlSpan{content: hw.noteRef("en", child.EndnoteReference.ID)})
+		case child.Drawing != nil:
+			flush()
+			for _, inline := range child.Drawing.Inline {
+				spans = append(spans, htmlSpan{content: hw.image(inline.Graphic, inline.DocProp, inline.Extent)})
+			}
+			for _, anchor := range child.Drawing.Anchor {
+				spans = append(spans, htmlSpan{content: hw.image(anchor.Graphic, anchor.DocProp, anchor.Extent)})
+			}
+		default:
+			text.WriteString(escapeHTMLText(runText(&ctypes.Run{Children: []ctypes.RunChild{child}})))
+		}
+	}
+	flush()
+	return spans
+}
+
+// runFormat returns the tags opening and closing the content of a run: elements for the
+// emphasis the run adds to its paragraph and a span for the rest of its formatting.
+func (hw *htmlWriter) runFormat(rp, paraRun *ctypes.RunProperty) (open, close string) {
+	var tags []string
+	if rp.Bold.Bool() && !paraRun.Bold.Bool() {
+		tags = append(tags, "strong")
+	}
+	if rp.Italic.Bool() && !paraRun.Italic.Bool() {
+		tags = append(tags, "em")
+	}
+	if underlined(rp) && !underlined(paraRun) {
+		tags = append(tags, "u")
+	}
+	if (rp.Strike.Bool() || rp.DoubleStrike.Bool()) && !(paraRun.Strike.Bool() || paraRun.DoubleStrike.Bool()) {
+		tags = append(tags, "s")
+	}
+	if rp.VertAlign != nil && (paraRun.VertAlign == nil || paraRun.VertAlign.Val != rp.VertAlign.Val) {
+		switch rp.VertAlign.Val {
+		case stypes.VerticalAlignRunSuperscript:
+			tags = append(tags, "sup")
+		case stypes.VerticalAlignRunSubscript:
+			tags = append(tags, "sub")
+		}
+	}
+
+	emphasis := []string{"font-weight", "font-style", "text-decoration", "vertical-align"}
+	decls := hw.runDecls(rp).without(emphasis...).diff(hw.runDecls(paraRun).without(emphasis...), false)
+	if paraRun.Bold.Bool() && !rp.Bold.Bool() {
+		decls = append(decls, cssDecl{"font-weight", "normal"})
+	}
+	if paraRun.Italic.Bool() && !rp.Italic.Bool() {
+		decls = append(decls, cssDecl{"font-style", "normal"})
+	}
+	if len(decls) > 0 {
+		tags = append([]string{"span style=\"" + html.EscapeString(decls.String()) + "\""}, tags...)
+	}
+
+	for i := len(tags) - 1; i >= 0; i-- {
+		open = "<" + tags[i] + ">" + open
+		close += "</" + strings.Fields(tags[i])[0] + ">"
+	}
+	return open, close
+}
+
+// renderHTMLSpans merges the consecutive spans sharing the same formatting.
+func renderHTMLSpans(spans []htmlSpan) string {
+	var sb strings.Builder
+	for i := 0; i < len(spans); {
+		j := i + 1
+		content := spans[i].content
+		for ; j < len(spans) && spans[j].open == spans[i].open; j++ {
+			content += spans[j].content
+		}
+		sb.WriteString(spans[i].open + content + spans[i].close)
+		i = j
+	}
+	return sb.String()
+}
+
+// noteRef registers a reference to a footnote or an endnote and returns the link to it.
+func (hw *htmlWriter) noteRef(kind string, id int) string {
+	notes := hw.footnotes
+	if kind == "en" {
+		notes = hw.endnotes
+	}
+	n, ok := notes[id]
+	if !ok {
+		return ""
+	}
+
+	number := 1
+	for _, ref := range hw.noteRefs {
+		if ref.kind == kind {
+			number++
+		}
+	}
+	hw.noteRefs = append(hw.noteRefs, htmlNoteRef{kind: kind, number: number, note: n})
+	return fmt.Sprintf("<a class=\"note-ref\" href=\"#%[1]s%[2]d\" id=\"%[1]sref%[2]d\"><sup>%[2]d</sup></a>", kind, number)
+}
+
+// writeNotes writes the referenced footnotes, then the referenced endnotes, each linking back
+// to its reference.
+func (hw *htmlWriter) writeNotes() {
+	for _, kind := range []string{"fn", "en"} {
+		section := "footnotes"
+		if kind == "en" {
+			section = "endnotes"
+		}
+
+		started := false
+		// Notes may reference further notes
+		for i := 0; i < len(hw.noteRefs); i++ {
+			ref := hw.noteRefs[i]
+			if ref.kind != kind {
+				continue
+			}
+			if !started {
+				hw.sb.WriteString("<section class=\"" + section + "\">\n<hr>\n<ol>\n")
+				started = true
+			}
+			fmt.Fprintf(&hw.sb, "<li id=\"%s%d\">\n", kind, ref.number)
+			hw.writeBlocks(ref.note.Children)
+			fmt.Fprintf(&hw.sb, "<a class=\"note-back\" href=\"#%sref%d\">&#8617;</a>\n</li>\n", kind, ref.number)
+		}
+		if started {
+			hw.sb.WriteString("</ol>\n</section>\n")
+		}
+	}
+}
+
+// image renders a picture as an img element, its size being converted to CSS pixels.
+func (hw *htmlWriter) image(graphic dml.Graphic, docProp dml.DocProp, extent dmlct.PSize2D) string {
+	if graphic.Data == nil || graphic.Data.Pic == nil || graphic.Data.Pic.BlipFill.Blip == nil {
+		return ""
+	}
+	src := hw.imageSrc(graphic.Data.Pic.BlipFill.Blip.EmbedID)
+	if src == "" {
+		return ""
+	}
+	alt := docProp.Description
+	if alt == "" {
+		alt = docProp.Name
+	}
+
+	img := "<img src=\"" + html.EscapeString(src) + "\" alt=\"" + html.EscapeString(alt) + "\""
+	// There are 9525 EMUs in a pixel at 96 dpi
+	if extent.Width > 0 && extent.Height > 0 {
+		img += fmt.Sprintf(" width=\"%d\" height=\"%d\"", (extent.Width+4762)/9525, (extent.Height+4762)/9525)
+	}
+	return img + ">"
+}
+
+// imageSrc returns the source of the image with the given relationship ID: a data URI, or the
+// link to the extracted image when opts.ImageDir is set. It returns "" for unknown images.
+func (hw *htmlWriter) imageSrc(rID string) string {
+	partPath, content, ok := hw.rd.imagePart(rID)
+	if !ok {
+		return ""
+	}
+	if src, ok := hw.images[partPath]; ok {
+		return src
+	}
+
+	var src string
+	if hw.opts.ImageDir == "" {
+		mediaType := mime.TypeByExtension(path.Ext(partPath))
+		if mediaType == "" {
+			mediaType = http.DetectContentType(content)
+		}
+		src = "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(content)
+	} else {
+		var err error
+		if src, err = extractImage(partPath, content, hw.opts.ImageDir, hw.opts.ImageURL); err != nil {
+			hw.err = err
+			return ""
+		}
+	}
+	hw.images[partPath] = src
+	return src
+}
+
+// writeTable writes a table. Cells spanning several grid columns or merged with the cells
+// below them get colspan and rowspan attributes, leading header rows are written as thead.
+func (hw *htmlWriter) writeTable(t *ctypes.Table) {
+	hw.closeLists(0)
+	tp := hw.rd.resolvedTableProp(t)
+
+	var decls cssDecls
+	decls.add("width", cssWidth(tp.Width))
+	if tp.Justification != nil {
+		switch tp.Justification.Val {
+		case stypes.JustificationCenter:
+			decls = append(decls, cssDecl{"margin-left", "auto"}, cssDecl{"margin-right", "auto"})
+		case stypes.JustificationRight:
+			decls.add("margin-left", "auto")
+		}
+	}
+	decls.add("background-color", shadingColor(tp.Shading))
+	hw.sb.WriteString("<table" + htmlAttrs(hw.tableClass(tp.Borders), decls) + ">\n")
+
+	var rows []*ctypes.Row
+	for _, rc := range t.RowContents {
+		if rc.Row != nil {
+			rows = append(rows, rc.Row)
+		}
+	}
+
+	// Grid column of each cell, to find the cells vertically merged with each other
+	cols := make([]map[*ctypes.Cell]int, len(rows))
+	byCol := make([]map[int]*ctypes.Cell, len(rows))
+	for r, row := range rows {
+		cols[r], byCol[r] = map[*ctypes.Cell]int{}, map[int]*ctypes.Cell{}
+		col := 0
+		for _, cc := range row.Contents {
+			if cc.Cell == nil {
+				continue
+			}
+			cols[r][cc.Cell], byCol[r][col] = col, cc.Cell
+			col += cellSpan(cc.Cell)
+		}
+	}
+
+	headerRows := 0
+	for headerRows < len(rows) && rows[headerRows].Property != nil && rows[headerRows].Property.Header.Bool() {
+		headerRows++
+	}
+
+	for r, row := range rows {
+		switch r {
+		case 0:
+			if headerRows > 0 {
+				hw.sb.WriteString("<thead>\n")
+			} else {
+				hw.sb.WriteString("<tbody>\n")
+			}
+		case headerRows:
+			hw.sb.WriteString("</thead>\n<tbody>\n")
+		}
+		tag := "td"
+		if r < headerRows {
+			tag = "th"
+		}
+
+		hw.sb.WriteString("<tr>\n")
+		for _, cc := range row.Contents {
+			if cc.Cell == nil || vMerge(cc.Cell) == stypes.MergeCellContinue {
+				continue
+			}
+
+			attrs := ""
+			if span := cellSpan(cc.Cell); span > 1 {
+				attrs += " colspan=\"" + strconv.Itoa(span) + "\""
+			}
+			if vMerge(cc.Cell) == stypes.MergeCellRestart {
+				rowspan := 1
+				for next := r + 1; next < len(rows); next++ {
+					below := byCol[next][cols[r][cc.Cell]]
+					if below == nil || vMerge(below) != stypes.MergeCellContinue {
+						break
+					}
+					rowspan++
+				}
+				if rowspan > 1 {
+					attrs += " rowspan=\"" + strconv.Itoa(rowspan) + "\""
+				}
+			}
+
+			hw.sb.WriteString("<" + tag + attrs + htmlAttrs("", cellDecls(cc.Cell.Property)) + ">\n")
+			outer := hw.cell
+			hw.cell = cellPositionOf(t, row, cc.Cell)
+			for _, block := range cc.Cell.Contents {
+				if block.Paragraph != nil {
+					hw.writePara(block.Paragraph)
+				}
+				if block.Table != nil {
+					hw.writeTable(block.Table)
+				}
+			}
+			hw.cell = outer
+			hw.closeLists(0)
+			hw.sb.WriteString("</" + tag + ">\n")
+		}
+		hw.sb.WriteString("</tr>\n")
+	}
+	if len(rows) > 0 {
+		if headerRows == len(rows) {
+			hw.sb.WriteString("</thead>\n")
+		} else {
+			hw.sb.WriteString("</tbody>\n")
+		}
+	}
+	hw.sb.WriteString("</table>\n")
+}
+
+// tableClass returns the class drawing the borders of a table, registering its CSS rules on
+// first use. The inside borders are drawn by the cells.
+func (hw *htmlWriter) tableClass(b *ctypes.TableBorders) string {
+	if b == nil {
+		return ""
+	}
+	var outer, inner cssDecls
+	outer.add("border-top", cssBorder(b.Top))
+	outer.add("border-left", cssBorder(b.Left))
+	outer.add("border-bottom", cssBorder(b.Bottom))
+	outer.add("border-right", cssBorder(b.Right))
+	inner.add("border-top", cssBorder(b.InsideH))
+	inner.add("border-bottom", cssBorder(b.InsideH))
+	inner.add("border-left", cssBorder(b.InsideV))
+	inner.add("border-right", cssBorder(b.InsideV))
+	if len(outer) == 0 && len(inner) == 0 {
+		return ""
+	}
+
+	key := outer.String() + "|" + inner.String()
+	if name, ok := hw.tables[key]; ok {
+		return name
+	}
+	name := "t" + strconv.Itoa(len(hw.tables)+1)
+	hw.tables[key] = name
+	if len(outer) > 0 {
+		hw.rules = append(hw.rules, cssRule{selectors: []string{"table." + name}, decls: outer})
+	}
+	if len(inner) > 0 {
+		cells := []string{"." + name + " > * > tr > td", "." + name + " > * > tr > th"}
+		hw.rules = append(hw.rules, cssRule{selectors: cells, decls: inner})
+	}
+	return name
+}
+
+// cellDecls returns the CSS of the direct formatting of a table cell.
+func cellDecls(cp *ctypes.CellProperty) cssDecls {
+	var decls cssDecls
+	if cp == nil {
+		return decls
+	}
+	decls.add("width", cssWidth(cp.Width))
+	if cp.VAlign != nil {
+		switch cp.VAlign.Val {
+		case stypes.VerticalJcCenter:
+			decls.add("vertical-align", "middle")
+		case stypes.VerticalJcBottom:
+			decls.add("vertical-align", "bottom")
+		}
+	}
+	decls.add("background-color", shadingColor(cp.Shading))
+	if b := cp.Borders; b != nil {
+		decls.add("border-top", cssBorder(b.Top))
+		decls.add("border-left", cssBorder(b.Left))
+		decls.add("border-bottom", cssBorder(b.Bottom))
+		decls.add("border-right", cssBorder(b.Right))
+	}
+	return decls
+}
+
+// cellSpan returns the number of grid columns spanned by the cell.
+func cellSpan(c *ctypes.Cell) int {
+	if c.Property != nil && c.Property.GridSpan != nil && c.Property.GridSpan.Val > 1 {
+		return c.Property.GridSpan.Val
+	}
+	return 1
+}
+
+// vMerge returns the vertical merge state of the cell, "" when it is not merged.
+func vMerge(c *ctypes.Cell) stypes.MergeCell {
+	if c.Property == nil || c.Property.VMerge == nil {
+		return ""
+	}
+	if c.Property.VMerge.Val == nil {
+		return stypes.MergeCellContinue
+	}
+	return *c.Property.VMerge.Val
+}
+
+// paraDecls returns the CSS of the paragraph properties.
+func (hw *htmlWriter) paraDecls(pp *ctypes.ParagraphProp) cssDecls {
+	var decls cssDecls
+	if sp := pp.Spacing; sp != nil {
+		if sp.Before != nil {
+			decls.add("margin-top", twipsCSS(int(*sp.Before)))
+		}
+		if sp.After != nil {
+			decls.add("margin-bottom", twipsCSS(int(*sp.After)))
+		}
+		if sp.Line != nil && *sp.Line > 0 {
+			if sp.LineRule == nil || *sp.LineRule == stypes.LineSpacingRuleAuto {
+				decls.add("line-height", strconv.FormatFloat(float64(*sp.Line)/240, 'f', -1, 64))
+			} else {
+				decls.add("line-height", twipsCSS(*sp.Line))
+			}
+		}
+	}
+
+	if pp.Justification != nil {
+		switch pp.Justification.Val {
+		case stypes.JustificationLeft:
+			decls.add("text-align", "left")
+		case stypes.JustificationCenter:
+			decls.add("text-align", "center")
+		case stypes.JustificationRight:
+			decls.add("text-align", "right")
+		case stypes.JustificationBoth, stypes.JustificationDistribute:
+			decls.add("text-align", "justify")
+		}
+	}
+
+	if ind := pp.Indent; ind != nil {
+		if ind.Left != nil && *ind.Left != 0 {
+			decls.add("margin-left", twipsCSS(*ind.Left))
+		}
+		if ind.Right != nil && *ind.Right != 0 {
+			decls.add("margin-right", twipsCSS(*ind.Right))
+		}
+		if ind.FirstLine != nil && *ind.FirstLine != 0 {
+			decls.add("text-indent", twipsCSS(int(*ind.FirstLine)))
+		} else if ind.Hanging != nil && *ind.Hanging != 0 {
+			decls.add("text-indent", twipsCSS(-int(*ind.Hanging)))
+		}
+	}
+
+	if b := pp.Border; b != nil {
+		sides := []struct {
+			name   string
+			border *ctypes.Border
+		}{{"top", b.Top}, {"left", b.Left}, {"bottom", b.Bottom}, {"right", b.Right}}
+		for _, side := range sides {
+			decls.add("border-"+side.name, cssBorder(side.border))
+			if side.border != nil && side.border.Space != nil {
+				if space, err := strconv.Atoi(*side.border.Space); err == nil && space > 0 {
+					decls.add("padding-"+side.name, strconv.Itoa(space)+"pt")
+				}
+			}
+		}
+	}
+
+	decls.add("background-color", shadingColor(pp.Shading))
+	return decls
+}
+
+// runDecls returns the CSS of the run properties.
+func (hw *htmlWriter) runDecls(rp *ctypes.RunProperty) cssDecls {
+	var decls cssDecls
+	if rp.Fonts != nil {
+		family, ok := hw.fonts[*rp.Fonts]
+		if !ok {
+			family = hw.rd.fontFamily(rp.Fonts)
+			hw.fonts[*rp.Fonts] = family
+		}
+		decls.add("font-family", cssFontName(family))
+	}
+	if rp.Size != nil && rp.Size.Value > 0 {
+		decls.add("font-size", strconv.FormatFloat(float64(rp.Size.Value)/2, 'f', -1, 64)+"pt")
+	}
+	if rp.Color != nil {
+		decls.add("color", cssColor(rp.Color.Val))
+	}
+	if rp.Bold.Bool() {
+		decls.add("font-weight", "bold")
+	}
+	if rp.Italic.Bool() {
+		decls.add("font-style", "italic")
+	}
+
+	var lines []string
+	if underlined(rp) {
+		lines = append(lines, "underline")
+	}
+	if rp.Strike.Bool() || rp.DoubleStrike.Bool() {
+		lines = append(lines, "line-through")
+	}
+	decls.add("text-decoration", strings.Join(lines, " "))
+
+	if rp.Caps.Bool() {
+		decls.add("text-transform", "uppercase")
+	} else if rp.SmallCaps.Bool() {
+		decls.add("font-variant", "small-caps")
+	}
+	if rp.VertAlign != nil {
+		switch rp.VertAlign.Val {
+		case stypes.VerticalAlignRunSuperscript:
+			decls.add("vertical-align", "super")
+		case stypes.VerticalAlignRunSubscript:
+			decls.add("vertical-align", "sub")
+		}
+	}
+
+	if rp.Highlight != nil && rp.Highlight.Val != "none" {
+		if color, ok := highlightColors[rp.Highlight.Val]; ok {
+			decls.add("background-color", color)
+		}
+	} else {
+		decls.add("background-color", shadingColor(rp.Shading))
+	}
+	return decls
+}
+
+// underlined reports whether the run properties underline the text.
+func underlined(rp *ctypes.RunProperty) bool {
+	return rp.Underline != nil && rp.Underline.Val != "" && rp.Underline.Val != stypes.UnderlineNone
+}
+
+// highlightColors maps the text highlight colours to CSS colours.
+var highlightColors = map[string]string{
+	"black": "#000000", "blue": "#0000FF", "cyan": "#00FFFF", "green": "#00FF00",
+	"magenta": "#FF00FF", "red": "#FF0000", "yellow": "#FFFF00", "white": "#FFFFFF",
+	"darkBlue": "#000080", "darkCyan": "#008080", "darkGreen": "#008000", "darkMagenta": "#800080",
+	"darkRed": "#800000", "darkYellow": "#808000", "darkGray": "#808080", "lightGray": "#C0C0C0",
+}
+
+// shadingColor returns the CSS colour of the shading, or "" if there is no shading.
+func shadingColor(s *ctypes.Shading) string {
+	if s == nil {
+		return ""
+	}
+	if s.Fill != nil && cssColor(*s.Fill) != "" {
+		return cssColor(*s.Fill)
+	}
+	if s.Val == stypes.ShdSolid && s.Color != nil {
+		return cssColor(*s.Color)
+	}
+	return ""
+}
+
+// cssColorRe matches the hexadecimal RGB colours of the document.
+var cssColorRe = regexp.MustCompile(`^[0-9A-Fa-f]{6}$`)
+
+// cssColor returns the CSS of a colour of the document, "" for automatic colours and values
+// which are not hexadecimal RGB colours.
+func cssColor(val string) string {
+	if !cssColorRe.MatchString(val) {
+		return ""
+	}
+	return "#" + val
+}
+
+// cssFontName returns the quoted CSS font family of a font name, "" for names holding other
+// characters than letters, digits, spaces, dots, hyphens and underscores.
+func cssFontName(name string) string {
+	if strings.TrimSpace(name) == "" {
+		return ""
+	}
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && !strings.ContainsRune(" .-_", r) {
+			return ""
+		}
+	}
+	return "\"" + name + "\""
+}
+
+// safeHref returns the target of a hyperlink if it is a web or mail address or a fragment, ""
+// otherwise, so that the scripts of javascript: or data: URLs cannot run from the page.
+func safeHref(target string) string {
+	target = strings.TrimSpace(target)
+	if strings.HasPrefix(target, "#") {
+		return target
+	}
+	u, err := url.Parse(target)
+	if err != nil {
+		return ""
+	}
+	switch strings.ToLower(u.Scheme) {
+	case "http", "https", "mailto":
+		return target
+	}
+	return ""
+}
+
+// cssBorder returns the CSS of a border, its width being given in eighths of a point.
+func cssBorder(b *ctypes.Border) string {
+	if b == nil {
+		return ""
+	}
+	style := "solid"
+	switch b.Val {
+	case stypes.BorderStyleNil, stypes.BorderStyleNone:
+		return "none"
+	case stypes.BorderStyleDouble:
+		style = "double"
+	case stypes.BorderStyleDotted:
+		style = "dotted"
+	case stypes.BorderStyleDashed:
+		style = "dashed"
+	}
+
+	size := 4
+	if b.Size != nil && *b.Size > 0 {
+		size = *b.Size
+	}
+	color := "#000000"
+	if b.Color != nil && cssColor(*b.Color) != "" {
+		color = cssColor(*b.Color)
+	}
+	return strconv.FormatFloat(float64(size)/8, 'f', -1, 64) + "pt " + style + " " + color
+}
+
+// cssWidth returns the CSS of a table or cell width, "" for automatic widths.
+func cssWidth(w *ctypes.TableWidth) string {
+	if w == nil || w.Width == nil || *w.Width <= 0 || w.WidthType == nil {
+		return ""
+	}
+	switch *w.WidthType {
+	case stypes.TableWidthDxa:
+		return twipsCSS(*w.Width)
+	case stypes.TableWidthPct:
+		return strconv.FormatFloat(float64(*w.Width)/50, 'f', -1, 64) + "%"
+	}
+	return ""
+}
+
+// twipsCSS converts twentieths of a point to a CSS length in points.
+func twipsCSS(twips int) string {
+	return strconv.FormatFloat(float64(twips)/20, 'f', -1, 64) + "pt"
+}
+
+// escapeHTMLText escapes text, keeping the runs of spaces that HTML would collapse.
+func escapeHTMLText(text string) string {
+	return strings.ReplaceAll(html.EscapeString(text), "  ", "&nbsp; ")
+}
+
+// htmlAttrs renders the class and style attributes of an element.
+func htmlAttrs(class string, decls cssDecls) string {
+	attrs := ""
+	if class != "" {
+		attrs += " class=\"" + class + "\""
+	}
+	if len(decls) > 0 {
+		attrs += " style=\"" + html.EscapeString(decls.String()) + "\""
+	}
+	return attrs
+}
+
+// cssRule is a rule of the style sheet, its selectors being relative to the document element.
+type cssRule struct {
+	selectors []string
+	decls     cssDecls
+}
+
+type cssDecl struct {
+	prop, value string
+}
+
+// cssDecls is a list of CSS declarations.
+type cssDecls []cssDecl
+
+// add appends a declaration unless its value is empty.
+func (d *cssDecls) add(prop, value string) {
+	if value != "" {
+		*d = append(*d, cssDecl{prop, value})
+	}
+}
+
+func (d cssDecls) String() string {
+	parts := make([]string, len(d))
+	for i, decl := range d {
+		parts[i] = decl.prop + ": " + decl.value
+	}
+	return strings.Join(parts, "; ")
+}
+
+// without returns the declarations of other properties than the given ones.
+func (d cssDecls) without(props ...string) cssDecls {
+	var kept cssDecls
+	for _, decl := range d {
+		if !slices.Contains(props, decl.prop) {
+			kept = append(kept, decl)
+		}
+	}
+	return kept
+}
+
+// diff returns the declarations not already made by base. When reset is set, the properties
+// declared by base only are reset to their initial value.
+func (d cssDecls) diff(base cssDecls, reset bool) cssDecls {
+	values := map[string]string{}
+	for _, decl := range base {
+		values[decl.prop] = decl.value
+	}
+	var diff cssDecls
+	for _, decl := range d {
+		if value, ok := values[decl.prop]; !ok || value != decl.value {
+			diff = append(diff, decl)
+		}
+		delete(values, decl.prop)
+	}
+	if reset {
+		for _, decl := range base {
+			if _, ok := values[decl.prop]; ok {
+				diff = append(diff, cssDecl{decl.prop, "initial"})
+			}
+		}
+	}
+	return diff
+}
@@ -0,0 +1,922 @@
+package docx
+
+import (
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"godocx/common/constants"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+)
+
+// HTMLImportOptions configures RootDoc.InsertHTML and RootDoc.AppendHTML.
+type HTMLImportOptions struct {
+	// LocalImages enables the images of local files, which are otherwise written as their
+	// alternative text, so that HTML from untrusted sources cannot embed the files of the
+	// system.
+	LocalImages bool
+
+	// BaseDir is the directory relative image sources are resolved against when LocalImages is
+	// set, it defaults to the current directory. Images are only read from within BaseDir:
+	// absolute paths and paths leading out of it are rejected.
+	BaseDir string
+
+	// CodeFont is the font of code, kbd, samp and pre elements, "Courier New" by default.
+	CodeFont string
+
+	// TableStyle is the ID or name of the style of the tables, "TableGrid" by default.
+	TableStyle string
+}
+
+// AppendHTML converts an HTML fragment and appends it to the end of the document body.
+// See InsertHTML for the supported HTML.
+func (rd *RootDoc) AppendHTML(src []byte, opts *HTMLImportOptions) error {
+	return rd.InsertHTML(len(rd.Document.Body.Children), src, opts)
+}
+
+// InsertHTML converts an HTML fragment to paragraphs and tables and inserts them in the document
+// body before the child at the given index, len(Body.Children) appending them.
+//
+// Paragraphs (p, div), headings (h1 to h6), block quotes, preformatted text, thematic breaks,
+// bullet and numbered lists nested in each other and tables with colspan and rowspan are
+// converted. Inline content may be bold (b, strong), italic (i, em), underlined (u, ins),
+// struck through (s, strike, del), superscript, subscript, code, highlighted (mark), a link
+// or an image, and span or font elements may set the colour, background colour, size, font,
+// weight, style and decoration of the text through their style attribute. Headings use the
+// HeadingN styles, block quotes the Quote style and list items the ListParagraph style, the
+// class of a paragraph selects the paragraph style with that ID or name. Built-in styles are
+// also found by their name in templates with localised style IDs; styles missing from the
+// document are not applied. Images are read from data URIs, or from local files with the
+// LocalImages option, images from URLs are written as links.
+//
+// Example:
+//
+//	err := document.InsertHTML(2, []byte(`<p>Hello <b>world</b></p>`), nil)
+func (rd *RootDoc) InsertHTML(index int, src []byte, opts *HTMLImportOptions) error {
+	if index < 0 || index > len(rd.Document.Body.Children) {
+		return fmt.Errorf("insert position %d out of range [0, %d]", index, len(rd.Document.Body.Children))
+	}
+	if opts == nil {
+		opts = &HTMLImportOptions{}
+	}
+
+	root, err := parseHTML(src)
+	if err != nil {
+		return fmt.Errorf("html: %w", err)
+	}
+
+	hb := &htmlBuilder{
+		rd:         rd,
+		baseDir:    opts.BaseDir,
+		local:      opts.LocalImages,
+		codeFont:   opts.CodeFont,
+		tableStyle: opts.TableStyle,
+	}
+	if hb.codeFont == "" {
+		hb.codeFont = "Courier New"
+	}
+	if hb.tableStyle == "" {
+		hb.tableStyle = "TableGrid"
+	}
+
+	var children []DocumentChild
+	flow := &htmlFlow{out: &children, ctx: htmlContext{level: -1}}
+	if err := hb.walk(root.children, flow); err != nil {
+		return err
+	}
+	hb.endPara(flow)
+
+	body := rd.Document.Body
+	body.Children = append(body.Children[:index], append(children, body.Children[index:]...)...)
+	return nil
+}
+
+// htmlBuilder converts parsed HTML to paragraphs and tables.
+type htmlBuilder struct {
+	rd         *RootDoc
+	baseDir    string
+	local      bool
+	codeFont   string
+	tableStyle string
+}
+
+// htmlContext is the formatting inherited from the enclosing elements.
+type htmlContext struct {
+	quote   int                  // Block quote depth
+	numID   int                  // Numbering instance of the enclosing list
+	level   int                  // Level of the enclosing list, -1 outside lists
+	ordered bool                 // Whether the enclosing list is numbered
+	item    *htmlItem            // Enclosing list item
+	align   stypes.Justification // Paragraph alignment, "" for the style alignment
+	pre     bool                 // Whether white space is preserved
+	style   htmlInlineStyle      // Text formatting
+}
+
+// htmlItem tracks whether the first paragraph of a list item, which carries the numbering, was written.
+type htmlItem struct {
+	numbered bool
+}
+
+// htmlInlineStyle is the formatting of text.
+type htmlInlineStyle struct {
+	bold, italic, underline, strike bool
+	sup, sub, code                  bool
+	color, background, highlight    string // Colours are RGB hex values, the highlight a highlight colour name
+	font                            string
+	size                            uint64 // Font size in half points
+	link                            string
+}
+
+// htmlFlow receives the content of a block container, inline content going to the current paragraph.
+type htmlFlow struct {
+	out   *[]DocumentChild
+	ctx   htmlContext
+	para  *Paragraph        // Paragraph receiving inline content, nil before the first inline content
+	space bool              // Whether the inline content ends with white space
+	link  *ctypes.Hyperlink // Hyperlink receiving runs
+	href  string            // Destination of link
+}
+
+var htmlHeadingRe = regexp.MustCompile(`^h[1-6]$`)
+
+// walk converts the nodes of a block container.
+func (hb *htmlBuilder) walk(nodes []*htmlNode, f *htmlFlow) error {
+	for _, n := range nodes {
+		if err := hb.node(n, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (hb *htmlBuilder) node(n *htmlNode, f *htmlFlow) error {
+	switch {
+	case n.tag == "":
+		hb.text(f, n.text, f.ctx.style)
+		return nil
+	case n.tag == "head" || n.tag == "script" || n.tag == "style" || n.tag == "title" || n.tag == "template":
+		return nil
+	}
+
+	ctx := f.ctx
+	ctx.style = hb.inlineStyle(n, ctx.style)
+	if align := htmlAlign(n); align != "" {
+		ctx.align = align
+	}
+
+	switch {
+	case n.tag == "p" || htmlHeadingRe.MatchString(n.tag):
+		hb.endPara(f)
+		p := hb.newPara(f, ctx)
+		if n.tag != "p" {
+			level, _ := strconv.Atoi(n.tag[1:])
+			if !hb.applyStyle(p, fmt.Sprintf("heading %d", level)) {
+				ctx.style.bold = true
+			}
+		}
+		hb.applyClass(p, n.attr("class"))
+		inner := &htmlFlow{out: f.out, ctx: ctx, para: p, space: true}
+		if err := hb.walk(n.children, inner); err != nil {
+			return err
+		}
+		hb.endPara(inner)
+
+	case n.tag == "div" || n.tag == "section" || n.tag == "article" || n.tag == "main" || n.tag == "header" ||
+		n.tag == "footer" || n.tag == "body" || n.tag == "html" || n.tag == "figure" || n.tag == "center":
+		hb.endPara(f)
+		if n.tag == "center" {
+			ctx.align = stypes.JustificationCenter
+		}
+		inner := &htmlFlow{out: f.out, ctx: ctx}
+		if err := hb.walk(n.children, inner); err != nil {
+			return err
+		}
+		hb.endPara(inner)
+
+	case n.tag == "blockquote":
+		hb.endPara(f)
+		ctx.quote++
+		inner := &htmlFlow{out: f.out, ctx: ctx}
+		if err := hb.walk(n.children, inner); err != nil {
+			return err
+		}
+		hb.endPara(inner)
+
+	case n.tag == "pre":
+		hb.endPara(f)
+		ctx.pre = true
+		ctx.style.code = true
+		inner := &htmlFlow{out: f.out, ctx: ctx, para: hb.newPara(f, ctx), space: true}
+		// A newline following the start tag is not part of the content
+		children := n.children
+		if len(children) > 0 && children[0].tag == "" {
+			trimmed := *children[0]
+			trimmed.text = strings.TrimPrefix(strings.TrimPrefix(trimmed.text, "\r"), "\n")
+			children = append([]*htmlNode{&trimmed}, children[1:]...)
+		}
+		if err := hb.walk(children, inner); err != nil {
+			return err
+		}
+
+	case n.tag == "hr":
+		hb.endPara(f)
+		p := hb.newPara(f, ctx)
+		size, space := 6, "1"
+		p.GetCT().Property.Border = &ctypes.ParaBorder{
+			Bottom: &ctypes.Border{Val: stypes.BorderStyleSingle, Size: &size, Space: &space},
+		}
+		f.para = nil
+
+	case n.tag == "ul" || n.tag == "ol":
+		hb.endPara(f)
+		return hb.list(n, f, ctx)
+
+	case n.tag == "li":
+		// A list item outside a list
+		hb.endPara(f)
+		return hb.listItem(n, f, ctx)
+
+	case n.tag == "table":
+		hb.endPara(f)
+		return hb.table(n, f, ctx)
+
+	default:
+		return hb.inline(n, f, ctx)
+	}
+	return nil
+}
+
+// list converts the items of a list. A nested list of the same kind continues the numbering
+// instance of its parent at the next level.
+func (hb *htmlBuilder) list(n *htmlNode, f *htmlFlow, ctx htmlContext) error {
+	if ctx.item != nil && !ctx.item.numbered {
+		// The list is the first content of its item
+		hb.newPara(f, ctx)
+	}
+
+	ordered := n.tag == "ol"
+	inner := ctx
+	inner.level++
+	inner.ordered = ordered
+	inner.item = nil
+	if ctx.numID == 0 || ctx.ordered != ordered {
+		abstractNumID := BulletListID
+		if ordered {
+			abstractNumID = DecimalListID
+		}
+		inner.numID = hb.rd.NewListInstance(abstractNumID)
+	}
+
+	for _, child := range n.children {
+		switch child.tag {
+		case "":
+			// White space between the items
+		case "li":
+			if err := hb.listItem(child, f, inner); err != nil {
+				return err
+			}
+		default:
+			flow := &htmlFlow{out: f.out, ctx: inner}
+			if err := hb.node(child, flow); err != nil {
+				return err
+			}
+			hb.endPara(flow)
+		}
+	}
+	return nil
+}
+
+// listItem converts a list item, its first paragraph carrying the numbering.
+func (hb *htmlBuilder) listItem(n *htmlNode, f *htmlFlow, ctx htmlContext) error {
+	if ctx.level < 0 {
+		ctx.level, ctx.ordered = 0, false
+		ctx.numID = hb.rd.NewListInstance(BulletListID)
+	}
+	ctx.item = &htmlItem{}
+	ctx.style = hb.inlineStyle(n, ctx.style)
+
+	inner := &htmlFlow{out: f.out, ctx: ctx}
+	if err := hb.walk(n.children, inner); err != nil {
+		return err
+	}
+	hb.endPara(inner)
+	if !ctx.item.numbered {
+		hb.newPara(inner, ctx)
+	}
+	return nil
+}
+
+// table converts a table, cells spanning rows being merged vertically with placeholder cells
+// in the rows below them.
+func (hb *htmlBuilder) table(n *htmlNode, f *htmlFlow, ctx htmlContext) error {
+	var rows []*htmlNode
+	var caption *htmlNode
+	for _, child := range n.children {
+		switch child.tag {
+		case "caption":
+			caption = child
+		case "tr":
+			rows = append(rows, child)
+		case "thead", "tbody", "tfoot":
+			for _, tr := range child.children {
+				if tr.tag == "tr" {
+					rows = append(rows, tr)
+				}
+			}
+		}
+	}
+
+	if caption != nil {
+		p := hb.newPara(f, ctx)
+		hb.applyStyle(p, "caption")
+		inner := &htmlFlow{out: f.out, ctx: ctx, para: p, space: true}
+		if err := hb.walk(caption.children, inner); err != nil {
+			return err
+		}
+		hb.endPara(inner)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	tbl := &Table{root: hb.rd, ct: *ctypes.DefaultTable()}
+	if style := hb.rd.lookupStyle(hb.tableStyle, stypes.StyleTypeTable); style != nil {
+		tbl.Style(*style.ID)
+	}
+	*f.out = append(*f.out, DocumentChild{Table: tbl})
+
+	// Cells of the rows above spanning the row, by grid column
+	type rowSpan struct {
+		rows, cols int
+	}
+	spans := map[int]rowSpan{}
+
+	cellCtx := htmlContext{level: -1, style: ctx.style}
+	for _, tr := range rows {
+		row := tbl.AddRow()
+		col := 0
+		// continueSpans adds the placeholder cells of the cells above spanning the row, up to the given column
+		continueSpans := func(limit int) {
+			for {
+				span, ok := spans[col]
+				if !ok || col >= limit {
+					return
+				}
+				cell := row.AddCell()
+				cell.AddEmptyPara()
+				cell.GetCT().Property.VMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{}
+				if span.cols > 1 {
+					cell.ColSpan(span.cols)
+				}
+				if span.rows > 2 {
+					spans[col] = rowSpan{rows: span.rows - 1, cols: span.cols}
+				} else {
+					delete(spans, col)
+				}
+				col += span.cols
+			}
+		}
+
+		for _, td := range tr.children {
+			if td.tag != "td" && td.tag != "th" {
+				continue
+			}
+			continueSpans(int(^uint(0) >> 1))
+
+			cell := row.AddCell()
+			colspan, _ := strconv.Atoi(td.attr("colspan"))
+			colspan = max(colspan, 1)
+			if colspan > 1 {
+				cell.ColSpan(colspan)
+			}
+			if rowspan, _ := strconv.Atoi(td.attr("rowspan")); rowspan > 1 {
+				restart := stypes.MergeCellRestart
+				cell.GetCT().Property.VMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{Val: &restart}
+				spans[col] = rowSpan{rows: rowspan, cols: colspan}
+			}
+			col += colspan
+
+			tdCtx := cellCtx
+			tdCtx.style = hb.inlineStyle(td, tdCtx.style)
+			tdCtx.style.bold = tdCtx.style.bold || td.tag == "th"
+			tdCtx.align = htmlAlign(td)
+			hb.cellProps(cell, td)
+			if err := hb.cell(cell, td, tdCtx); err != nil {
+				return err
+			}
+		}
+		// Cells spanning the row after its last cell
+		for len(spans) > 0 {
+			last := -1
+			for c := range spans {
+				if c >= col && (last < 0 || c < last) {
+					last = c
+				}
+			}
+			if last < 0 {
+				break
+			}
+			col = last
+			continueSpans(last + 1)
+		}
+	}
+	return nil
+}
+
+// cell converts the content of a table cell, which holds at least one paragraph.
+func (hb *htmlBuilder) cell(cell *Cell, td *htmlNode, ctx htmlContext) error {
+	var children []DocumentChild
+	flow := &htmlFlow{out: &children, ctx: ctx}
+	if err := hb.walk(td.children, flow); err != nil {
+		return err
+	}
+	hb.endPara(flow)
+
+	for _, child := range children {
+		if child.Para != nil {
+			cell.GetCT().Contents = append(cell.GetCT().Contents, ctypes.TCBlockContent{Paragraph: child.Para.GetCT()})
+		}
+		if child.Table != nil {
+			cell.GetCT().Contents = append(cell.GetCT().Contents, ctypes.TCBlockContent{Table: child.Table.GetCT()})
+		}
+	}
+	if len(cell.GetCT().Contents) == 0 {
+		cell.AddEmptyPara()
+	}
+	return nil
+}
+
+// cellProps applies the background colour and vertical alignment of a table cell.
+func (hb *htmlBuilder) cellProps(cell *Cell, td *htmlNode) {
+	decls := parseCSSDecls(td.attr("style"))
+	background := decls["background-color"]
+	if background == "" {
+		background = td.attr("bgcolor")
+	}
+	if color, ok := parseCSSColor(background); ok {
+		cell.BackgroundColor(color)
+	}
+
+	valign := decls["vertical-align"]
+	if valign == "" {
+		valign = td.attr("valign")
+	}
+	cell.VerticalAlign(strings.ToLower(valign))
+}
+
+// inline converts an inline element.
+func (hb *htmlBuilder) inline(n *htmlNode, f *htmlFlow, ctx htmlContext) error {
+	switch n.tag {
+	case "br":
+		hb.addRun(f, ctx.style, ctypes.RunChild{Break: &ctypes.Break{}})
+		f.space = true
+		return nil
+	case "img":
+		return hb.image(n, f, ctx.style)
+	}
+
+	inner := *f
+	inner.ctx = ctx
+	if err := hb.walk(n.children, &inner); err != nil {
+		return err
+	}
+	f.para, f.space, f.link, f.href = inner.para, inner.space, inner.link, inner.href
+	return nil
+}
+
+// inlineStyle returns the text formatting of an element.
+func (hb *htmlBuilder) inlineStyle(n *htmlNode, st htmlInlineStyle) htmlInlineStyle {
+	switch n.tag {
+	case "b", "strong":
+		st.bold = true
+	case "i", "em", "cite", "var", "dfn":
+		st.italic = true
+	case "u", "ins":
+		st.underline = true
+	case "s", "strike", "del":
+		st.strike = true
+	case "sup":
+		st.sup, st.sub = true, false
+	case "sub":
+		st.sub, st.sup = true, false
+	case "code", "kbd", "samp", "tt":
+		st.code = true
+	case "mark":
+		st.highlight = "yellow"
+	case "a":
+		if href := strings.TrimSpace(n.attr("href")); href != "" {
+			st.link = href
+		}
+	case "font":
+		if color, ok := parseCSSColor(n.attr("color")); ok {
+			st.color = color
+		}
+		if face := n.attr("face"); face != "" {
+			st.font = cssFontFamily(face)
+		}
+	}
+
+	decls := parseCSSDecls(n.attr("style"))
+	if color, ok := parseCSSColor(decls["color"]); ok {
+		st.color = color
+	}
+	if color, ok := parseCSSColor(decls["background-color"]); ok && n.tag != "td" && n.tag != "th" {
+		st.background = color
+	}
+	if size, ok := parseCSSFontSize(decls["font-size"]); ok {
+		st.size = size
+	}
+	if family := decls["font-family"]; family != "" {
+		st.font = cssFontFamily(family)
+	}
+	switch weight := decls["font-weight"]; weight {
+	case "bold", "bolder", "600", "700", "800", "900":
+		st.bold = true
+	case "normal", "lighter", "100", "200", "300", "400", "500":
+		st.bold = false
+	}
+	switch decls["font-style"] {
+	case "italic", "oblique":
+		st.italic = true
+	case "normal":
+		st.italic = false
+	}
+	decoration := decls["text-decoration"] + " " + decls["text-decoration-line"]
+	if strings.Contains(decoration, "underline") {
+		st.underline = true
+	}
+	if strings.Contains(decoration, "line-through") {
+		st.strike = true
+	}
+	switch decls["vertical-align"] {
+	case "super":
+		st.sup, st.sub = true, false
+	case "sub":
+		st.sub, st.sup = true, false
+	}
+	return st
+}
+
+// text appends text to the current paragraph, collapsing white space unless it is preserved.
+func (hb *htmlBuilder) text(f *htmlFlow, text string, st htmlInlineStyle) {
+	if f.ctx.pre {
+		var children []ctypes.RunChild
+		lines := strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n")
+		for i, line := range lines {
+			if i > 0 {
+				children = append(children, ctypes.RunChild{Break: &ctypes.Break{}})
+			}
+			for j, part := range strings.Split(line, "\t") {
+				if j > 0 {
+					children = append(children, ctypes.RunChild{Tab: &ctypes.Empty{}})
+				}
+				if part != "" {
+					children = append(children, ctypes.RunChild{Text: ctypes.TextFromString(part)})
+				}
+			}
+		}
+		if len(children) > 0 {
+			hb.addRun(f, st, children...)
+		}
+		return
+	}
+
+	text = htmlSpaceRe.ReplaceAllString(text, " ")
+	if f.para == nil || f.space {
+		text = strings.TrimLeft(text, " ")
+	}
+	if text == "" {
+		return
+	}
+	hb.addRun(f, st, ctypes.RunChild{Text: ctypes.TextFromString(text)})
+	f.space = strings.HasSuffix(text, " ")
+}
+
+var htmlSpaceRe = regexp.MustCompile(`[ \t\r\n\f]+`)
+
+// addRun appends a run to the current paragraph, creating it if needed. Consecutive runs
+// sharing a destination are grouped in a hyperlink.
+func (hb *htmlBuilder) addRun(f *htmlFlow, st htmlInlineStyle, children ...ctypes.RunChild) *ctypes.Run {
+	if f.para == nil {
+		hb.newPara(f, f.ctx)
+	}
+	p := f.para
+
+	ct := &ctypes.Run{Children: children}
+	hb.formatRun(newRun(hb.rd, p.GetCT(), ct), st)
+	if st.link == "" {
+		f.link = nil
+		p.GetCT().Children = append(p.GetCT().Children, ctypes.ParagraphChild{Run: ct})
+		return ct
+	}
+
+	if f.link == nil || f.href != st.link {
+		f.link, f.href = &ctypes.Hyperlink{}, st.link
+		if anchor, ok := strings.CutPrefix(st.link, "#"); ok {
+			f.link.Anchor = anchor
+		} else {
+			f.link.ID = hb.rd.Document.addLinkRelation(st.link)
+		}
+		p.GetCT().Children = append(p.GetCT().Children, ctypes.ParagraphChild{Link: f.link})
+	}
+	if ct.Property == nil {
+		ct.Property = &ctypes.RunProperty{}
+	}
+	ct.Property.Style = ctypes.NewRunStyle(constants.HyperLinkStyle)
+	f.link.Children = append(f.link.Children, ctypes.ParagraphChild{Run: ct})
+	return ct
+}
+
+// formatRun applies the text formatting to the run.
+func (hb *htmlBuilder) formatRun(run *Run, st htmlInlineStyle) {
+	if st.bold {
+		run.Bold(true)
+	}
+	if st.italic {
+		run.Italic(true)
+	}
+	if st.underline {
+		run.Underline(stypes.UnderlineSingle)
+	}
+	if st.strike {
+		run.Strike(true)
+	}
+	if st.sup {
+		run.VerticalAlign(stypes.VerticalAlignRunSuperscript)
+	}
+	if st.sub {
+		run.VerticalAlign(stypes.VerticalAlignRunSubscript)
+	}
+	if st.font != "" {
+		run.Font(st.font)
+	}
+	if st.code {
+		run.Font(hb.codeFont)
+	}
+	if st.color != "" {
+		run.Color(st.color)
+	}
+	if st.size > 0 {
+		run.getProp().Size = ctypes.NewFontSize(st.size)
+	}
+	if st.highlight != "" {
+		run.Highlight(st.highlight)
+	}
+	if st.background != "" {
+		run.Shading(stypes.ShdClear, "auto", st.background)
+	}
+}
+
+// image appends an image to the current paragraph. Images from URLs are not embedded, their
+// alternative text links to them instead, and images of local files are only embedded with the
+// LocalImages option.
+func (hb *htmlBuilder) image(n *htmlNode, f *htmlFlow, st htmlInlineStyle) error {
+	src := strings.TrimSpace(n.attr("src"))
+	alt := n.attr("alt")
+	if src == "" {
+		return nil
+	}
+
+	var imgBytes []byte
+	switch {
+	case strings.HasPrefix(src, "data:"):
+		header, data, ok := strings.Cut(src, ",")
+		if !ok || !strings.HasSuffix(header, ";base64") {
+			return fmt.Errorf("html image: unsupported data URI %.40s", src)
+		}
+		var err error
+		if imgBytes, err = base64.StdEncoding.DecodeString(data); err != nil {
+			return fmt.Errorf("html image: %w", err)
+		}
+	case isMdURL(src):
+		if alt == "" {
+			alt = src
+		}
+		if st.link == "" {
+			st.link = src
+		}
+		hb.text(f, alt, st)
+		return nil
+	case !hb.local:
+		hb.text(f, alt, st)
+		return nil
+	default:
+		var err error
+		if imgBytes, err = readLocalImage(hb.baseDir, src); err != nil {
+			return fmt.Errorf("html image: %w", err)
+		}
+	}
+
+	decls := parseCSSDecls(n.attr("style"))
+	widthPx, heightPx := cssPixels(decls["width"]), cssPixels(decls["height"])
+	if widthPx <= 0 {
+		widthPx = cssPixels(n.attr("width"))
+	}
+	if heightPx <= 0 {
+		heightPx = cssPixels(n.attr("height"))
+	}
+	width, height := hb.rd.fittedImageSize(imgBytes, widthPx, heightPx)
+
+	run, inline, err := hb.rd.newImageRun(imgBytes, width, height)
+	if err != nil {
+		return fmt.Errorf("html image %.40s: %w", src, err)
+	}
+	inline.DocProp.Description = alt
+
+	hb.addRun(f, st, run.Children...)
+	f.space = false
+	return nil
+}
+
+// newPara appends a paragraph formatted for its container and makes it the current paragraph of the flow.
+func (hb *htmlBuilder) newPara(f *htmlFlow, ctx htmlContext) *Paragraph {
+	p := newParagraph(hb.rd)
+	p.ensureProp()
+	*f.out = append(*f.out, DocumentChild{Para: p})
+
+	indent := 0
+	if ctx.level >= 0 {
+		hb.applyStyle(p, "List Paragraph")
+		if ctx.item != nil && !ctx.item.numbered {
+			p.Numbering(ctx.numID, ctx.level)
+			ctx.item.numbered = true
+		} else {
+			// Later paragraphs of a list item are aligned with its first paragraph
+			indent = mdListItemIndent * (ctx.level + 1)
+		}
+	}
+	if ctx.quote > 0 {
+		hb.applyStyle(p, "Quote")
+		indent += mdListItemIndent * ctx.quote
+	}
+	if indent > 0 {
+		p.Indent(&ctypes.Indent{Left: &indent})
+	}
+	if ctx.align != "" {
+		p.Justification(ctx.align)
+	}
+
+	f.para, f.space, f.link = p, true, nil
+	return p
+}
+
+// endPara ends the current paragraph of the flow, trimming its trailing white space.
+func (hb *htmlBuilder) endPara(f *htmlFlow) {
+	if f.para != nil && !f.ctx.pre {
+		trimTrailingSpace(f.para.GetCT())
+	}
+	f.para, f.space, f.link = nil, true, nil
+}
+
+// trimTrailingSpace removes the spaces ending the text of the paragraph.
+func trimTrailingSpace(p *ctypes.Paragraph) {
+	for i := len(p.Children) - 1; i >= 0; i-- {
+		runs := []*ctypes.Run{p.Children[i].Run}
+		if p.Children[i].Link != nil {
+			runs = p.Children[i].Link.Runs()
+		}
+		for j := len(runs) - 1; j >= 0; j-- {
+			if runs[j] == nil || len(runs[j].Children) == 0 {
+				continue
+			}
+			last := runs[j].Children[len(runs[j].Children)-1]
+			if last.Text == nil {
+				return
+			}
+			last.Text.Text = strings.TrimRight(last.Text.Text, " ")
+			return
+		}
+	}
+}
+
+// applyStyle sets the paragraph style with the given built-in name when the document defines it
+// and reports whether it did.
+func (hb *htmlBuilder) applyStyle(p *Paragraph, name string) bool {
+	style := hb.rd.lookupStyle(name, stypes.StyleTypeParagraph)
+	if style == nil {
+		return false
+	}
+	p.Style(*style.ID)
+	return true
+}
+
+// applyClass sets the paragraph style matching one of the classes of the element by ID or
+// name, ignoring case and spaces. The "s-" prefix of the classes written by ToHTML is ignored.
+func (hb *htmlBuilder) applyClass(p *Paragraph, class string) {
+	if class == "" || hb.rd.DocStyles == nil {
+		return
+	}
+	normalize := func(s string) string {
+		return strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	}
+	for _, name := range strings.Fields(class) {
+		name = normalize(strings.TrimPrefix(name, "s-"))
+		for _, style := range hb.rd.DocStyles.StyleList {
+			if style.ID == nil || style.Type == nil || *style.Type != stypes.StyleTypeParagraph {
+				continue
+			}
+			if normalize(*style.ID) == name || (style.Name != nil && normalize(style.Name.Val) == name) {
+				p.Style(*style.ID)
+				return
+			}
+		}
+	}
+}
+
+// htmlAlign returns the alignment set by the align attribute or the text-align property of an element.
+func htmlAlign(n *htmlNode) stypes.Justification {
+	align := parseCSSDecls(n.attr("style"))["text-align"]
+	if align == "" {
+		align = n.attr("align")
+	}
+	switch strings.ToLower(align) {
+	case "left", "start":
+		return stypes.JustificationLeft
+	case "center":
+		return stypes.JustificationCenter
+	case "right", "end":
+		return stypes.JustificationRight
+	case "justify":
+		return stypes.JustificationBoth
+	}
+	return ""
+}
+
+// cssColors maps the basic CSS colour keywords to RGB hex values.
+var cssColors = map[string]string{
+	"black": "000000", "silver": "C0C0C0", "gray": "808080", "grey": "808080", "white": "FFFFFF",
+	"maroon": "800000", "red": "FF0000", "purple": "800080", "fuchsia": "FF00FF", "magenta": "FF00FF",
+	"green": "008000", "lime": "00FF00", "olive": "808000", "yellow": "FFFF00", "navy": "000080",
+	"blue": "0000FF", "teal": "008080", "aqua": "00FFFF", "cyan": "00FFFF", "orange": "FFA500",
+}
+
+var cssRGBRe = regexp.MustCompile(`^rgba?\(\s*(\d+)\s*,\s*(\d+)\s*,\s*(\d+)\s*(,[^)]*)?\)$`)
+
+// parseCSSColor converts a CSS colour (#rgb, #rrggbb, rgb() or a basic keyword) to an RGB hex value.
+func parseCSSColor(value string) (string, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if hex, ok := strings.CutPrefix(value, "#"); ok {
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if _, err := strconv.ParseUint(hex, 16, 32); err == nil && len(hex) == 6 {
+			return strings.ToUpper(hex), true
+		}
+		return "", false
+	}
+	if m := cssRGBRe.FindStringSubmatch(value); m != nil {
+		var rgb [3]int
+		for i := range rgb {
+			rgb[i], _ = strconv.Atoi(m[i+1])
+			rgb[i] = min(rgb[i], 255)
+		}
+		return fmt.Sprintf("%02X%02X%02X", rgb[0], rgb[1], rgb[2]), true
+	}
+	color, ok := cssColors[value]
+	return color, ok
+}
+
+// parseCSSFontSize converts a CSS font size in points or pixels to half points.
+func parseCSSFontSize(value string) (uint64, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	var points float64
+	switch {
+	case strings.HasSuffix(value, "pt"):
+		v, err := strconv.ParseFloat(strings.TrimSuffix(value, "pt"), 64)
+		if err != nil {
+			return 0, false
+		}
+		points = v
+	case strings.HasSuffix(value, "px"):
+		v, err := strconv.ParseFloat(strings.TrimSuffix(value, "px"), 64)
+		if err != nil {
+			return 0, false
+		}
+		points = v * 0.75
+	default:
+		return 0, false
+	}
+	if points <= 0 {
+		return 0, false
+	}
+	return uint64(points*2 + 0.5), true
+}
+
+// cssPixels converts a length in pixels, with or without the px unit, to a number of pixels.
+// It returns 0 for other lengths.
+func cssPixels(value string) float64 {
+	v, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "px"), 64)
+	if err != nil || v < 0 {
+		return 0
+	}
+	return v
+}
+
+// cssFontFamily returns the first font of a CSS font family list.
+func cssFontFamily(family string) string {
+	first, _, _ := strings.Cut(family, ",")
+	return strings.Trim(strings.TrimSpace(first), `"'`)
+}
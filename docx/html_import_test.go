@@ -0,0 +1,164 @@
+package docx
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"godocx/internal"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertHTML(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.Numbering = NewNumberingManager(rd)
+	for _, id := range []string{"Heading2", "ListParagraph", "Quote"} {
+		rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, ctypes.Style{
+			ID:   internal.ToPtr(id),
+			Type: internal.ToPtr(stypes.StyleTypeParagraph),
+		})
+	}
+	rd.AddParagraph("Before")
+	rd.AddParagraph("After")
+
+	src := `<h2>News &amp; notes</h2>
+<p style="text-align:center">Some <b>bold</b>, <em>italic</em>, <span style="color:#f00; font-size:14pt">red</span>
+and a <a href="https://example.com">link</a>.<br>Next line
+<ul>
+  <li>one
+  <li>two
+    <ul><li>nested</ul>
+</ul>
+<blockquote><p>quoted</blockquote>
+<table>
+  <tr><th colspan=2>Head<td rowspan="2">Tall</tr>
+  <tr><td>a<td style="background-color: rgb(0, 128, 0)">b</tr>
+</table>`
+	require.NoError(t, rd.InsertHTML(1, []byte(src), nil))
+
+	text, err := rd.Text(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Before\n"+
+		"News & notes\n"+
+		"Some bold, italic, red and a link.\nNext line\n"+
+		"one\ntwo\nnested\n"+
+		"quoted\n"+
+		"Head\tTall\n"+
+		"a\tb\t\n"+
+		"After\n", text)
+
+	children := rd.Document.Body.Children
+	require.Len(t, children, 9)
+	assert.Equal(t, "Heading2", children[1].Para.GetCT().Property.Style.Val)
+
+	p := children[2].Para.GetCT()
+	assert.Equal(t, stypes.JustificationCenter, p.Property.Justification.Val)
+	assert.True(t, p.Children[1].Run.Property.Bold.Bool())
+	assert.True(t, p.Children[3].Run.Property.Italic.Bool())
+	red := p.Children[5].Run.Property
+	assert.Equal(t, "FF0000", red.Color.Val)
+	assert.Equal(t, uint64(28), red.Size.Value)
+	require.NotNil(t, p.Children[7].Link)
+	assert.NotEmpty(t, p.Children[7].Link.ID)
+
+	// Nested lists of the same kind continue the numbering instance of their parent
+	one, nested := children[3].Para.GetCT().Property.NumProp, children[5].Para.GetCT().Property.NumProp
+	assert.Equal(t, "ListParagraph", children[3].Para.GetCT().Property.Style.Val)
+	assert.Equal(t, 0, one.ILvl.Val)
+	assert.Equal(t, 1, nested.ILvl.Val)
+	assert.Equal(t, one.NumID.Val, nested.NumID.Val)
+
+	assert.Equal(t, "Quote", children[6].Para.GetCT().Property.Style.Val)
+
+	tbl := children[7].Table.GetCT()
+	require.Len(t, tbl.RowContents, 2)
+	head := tbl.RowContents[0].Row.Contents
+	assert.Equal(t, 2, head[0].Cell.Property.GridSpan.Val)
+	assert.True(t, head[0].Cell.Contents[0].Paragraph.Children[0].Run.Property.Bold.Bool())
+	assert.Equal(t, stypes.MergeCellRestart, *head[1].Cell.Property.VMerge.Val)
+	cells := tbl.RowContents[1].Row.Contents
+	require.Len(t, cells, 3)
+	assert.Equal(t, "008000", *cells[1].Cell.Property.Shading.Fill)
+	assert.Nil(t, cells[2].Cell.Property.VMerge.Val)
+
+	var buf bytes.Buffer
+	require.NoError(t, rd.ToHTML(&buf, &HTMLOptions{Fragment: true}))
+	assert.Contains(t, buf.String(), "<td rowspan=\"2\" style=\"background-color: #FFFFFF\">\n<p>Tall</p>\n</td>")
+
+	assert.Error(t, rd.InsertHTML(20, []byte("<p>x</p>"), nil))
+}
+
+func TestInsertHTML_Pre(t *testing.T) {
+	rd := setupRootDoc(t)
+	require.NoError(t, rd.AppendHTML([]byte("<pre>\nif x {\n\treturn\n}</pre><p>  spaced   <i>out</i>  </p>"), nil))
+
+	children := rd.Document.Body.Children
+	require.Len(t, children, 2)
+	runs := children[0].Para.GetCT().Children
+	require.Len(t, runs, 1)
+	assert.Equal(t, "Courier New", runs[0].Run.Property.Fonts.Ascii)
+
+	text, err := rd.Text(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "if x {\n\treturn\n}\nspaced out\n", text)
+}
+
+func TestParseHTML(t *testing.T) {
+	root, err := parseHTML([]byte(`<p>a&nbsp;b<p class=x>c<br>d</span></p><ul><li>1<li>2</ul>`))
+	require.NoError(t, err)
+
+	require.Len(t, root.children, 3)
+	assert.Equal(t, "p", root.children[0].tag)
+	assert.Equal(t, "a\u00a0b", root.children[0].children[0].text)
+
+	second := root.children[1]
+	assert.Equal(t, "x", second.attr("class"))
+	require.Len(t, second.children, 3)
+	assert.Equal(t, "br", second.children[1].tag)
+
+	list := root.children[2]
+	require.Len(t, list.children, 2)
+	assert.Equal(t, "li", list.children[1].tag)
+	assert.Equal(t, "2", list.children[1].children[0].text)
+
+	// Text is not markup, nor is the content of scripts
+	root, err = parseHTML([]byte(`<script>if (a<b) {}</script><p>5 < 6 && x</p>`))
+	require.NoError(t, err)
+	require.Len(t, root.children, 2)
+	assert.Equal(t, "if (a<b) {}", root.children[0].children[0].text)
+	assert.Equal(t, "5 < 6 && x", root.children[1].children[0].text)
+}
+
+func TestAppendHTML_LocalImages(t *testing.T) {
+	dir := t.TempDir()
+	var img bytes.Buffer
+	require.NoError(t, png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 2, 2))))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "logo.png"), img.Bytes(), 0o644))
+	src := []byte(`<p><img src="logo.png" alt="Logo"></p>`)
+
+	// Local files are not read unless enabled
+	rd := setupRootDoc(t)
+	require.NoError(t, rd.AppendHTML(src, &HTMLImportOptions{BaseDir: dir}))
+	text, err := rd.Text(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Logo\n", text)
+
+	opts := &HTMLImportOptions{BaseDir: dir, LocalImages: true}
+	rd = setupRootDoc(t)
+	require.NoError(t, rd.AppendHTML(src, opts))
+	run := rd.Document.Body.Children[0].Para.GetCT().Children[0].Run
+	require.NotNil(t, run)
+	assert.NotNil(t, run.Children[0].Drawing)
+
+	for _, path := range []string{"../logo.png", "/etc/passwd", "file:///etc/passwd"} {
+		err := rd.AppendHTML([]byte(`<img src="`+path+`">`), opts)
+		assert.Error(t, err, path)
+	}
+}
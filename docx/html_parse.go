@@ -0,0 +1,74 @@
+package docx
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// htmlNode is an element or a text node of a parsed HTML fragment.
+type htmlNode struct {
+	tag      string            // Lower case element name, "" for text nodes
+	attrs    map[string]string // Attributes by lower case name
+	text     string            // Content of text nodes
+	children []*htmlNode
+}
+
+// attr returns the value of an attribute, "" if it is not set.
+func (n *htmlNode) attr(name string) string {
+	return n.attrs[name]
+}
+
+// parseHTML parses an HTML fragment as browsers parse the content of a body element: end tags
+// may be missing, void elements need no end tag, attributes need no value, entities are decoded
+// and the content of script and style elements is raw text.
+func parseHTML(src []byte) (*htmlNode, error) {
+	context := &html.Node{Type: html.ElementNode, Data: "body", DataAtom: atom.Body}
+	nodes, err := html.ParseFragment(bytes.NewReader(src), context)
+	if err != nil {
+		return nil, err
+	}
+	root := &htmlNode{tag: "root"}
+	for _, n := range nodes {
+		appendHTMLNode(root, n)
+	}
+	return root, nil
+}
+
+// appendHTMLNode appends the element or text node and its descendants to the children of
+// parent. Comments and doctypes are left out.
+func appendHTMLNode(parent *htmlNode, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		parent.children = append(parent.children, &htmlNode{text: n.Data})
+	case html.ElementNode:
+		node := &htmlNode{tag: n.Data, attrs: map[string]string{}}
+		for _, attr := range n.Attr {
+			name := attr.Key
+			if attr.Namespace != "" {
+				name = attr.Namespace + ":" + name
+			}
+			node.attrs[name] = attr.Val
+		}
+		parent.children = append(parent.children, node)
+		for child := n.FirstChild; child != nil; child = child.NextSibling {
+			appendHTMLNode(node, child)
+		}
+	}
+}
+
+// parseCSSDecls parses the declarations of a style attribute, property names being lower case.
+func parseCSSDecls(style string) map[string]string {
+	decls := map[string]string{}
+	for _, decl := range strings.Split(style, ";") {
+		prop, value, ok := strings.Cut(decl, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important"))
+		decls[strings.ToLower(strings.TrimSpace(prop))] = value
+	}
+	return decls
+}
@@ -0,0 +1,202 @@
+package docx
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"godocx/common/constants"
+	"godocx/common/units"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToHTML(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.Numbering = NewNumberingManager(rd)
+	size := uint64(24)
+	rd.DocStyles.DocDefaults = &ctypes.DocDefault{
+		RunProp: &ctypes.RunPropDefault{RunProp: &ctypes.RunProperty{
+			Fonts: &ctypes.RunFonts{Ascii: "Calibri"},
+			Size:  &ctypes.FontSize{Value: size},
+		}},
+	}
+	headingType, headingID, bold := stypes.StyleTypeParagraph, "Heading1", true
+	rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, ctypes.Style{
+		Type:    &headingType,
+		ID:      &headingID,
+		Name:    &ctypes.CTString{Val: "heading 1"},
+		RunProp: &ctypes.RunProperty{Size: ctypes.NewFontSize(32), Bold: ctypes.OnOffFromBool(bold)},
+	})
+
+	_, err := rd.AddHeading("Report & summary", 1)
+	require.NoError(t, err)
+
+	p := rd.AddParagraph("Read ")
+	p.Justification(stypes.JustificationCenter)
+	p.AddText("carefully").Bold(true)
+	p.AddText(" in ")
+	p.AddText("red").Color("FF0000").Size(14)
+	p.AddText(", see ")
+	p.AddLink("the site", "https://example.com/docs")
+
+	numID := rd.NewListInstance(1)
+	rd.AddParagraph("First").Numbering(numID, 0)
+	rd.AddParagraph("Nested").Numbering(numID, 1)
+	rd.AddParagraph("Second").Numbering(numID, 0)
+	rd.AddParagraph("After")
+
+	tbl := rd.AddTable()
+	row := tbl.AddRow()
+	wide := row.AddCell()
+	wide.AddParagraph("Wide")
+	wide.ColSpan(2)
+	merged := row.AddCell()
+	merged.AddParagraph("Tall")
+	restart := stypes.MergeCellRestart
+	merged.ct.Property.VMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{Val: &restart}
+	row = tbl.AddRow()
+	row.AddCell().AddParagraph("A")
+	row.AddCell().AddParagraph("B")
+	cont := row.AddCell()
+	cont.AddEmptyPara()
+	cont.ct.Property.VMerge = &ctypes.GenOptStrVal[stypes.MergeCell]{}
+
+	var buf bytes.Buffer
+	require.NoError(t, rd.ToHTML(&buf, &HTMLOptions{Title: "Report"}))
+	out := buf.String()
+
+	assert.True(t, strings.HasPrefix(out, "<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Report</title>\n"))
+	assert.Contains(t, out, "body { font-family: \"Calibri\"; font-size: 12pt }")
+	assert.Contains(t, out, "body .s-Heading1 { font-size: 16pt; font-weight: bold }")
+	assert.Contains(t, out, "<h1 class=\"s-Heading1\">Report &amp; summary</h1>\n")
+	assert.Contains(t, out, "<p style=\"text-align: center\">Read <strong>carefully</strong> in "+
+		"<span style=\"font-size: 14pt; color: #FF0000\">red</span>, see <a href=\"https://example.com/docs\">the site</a></p>\n")
+	assert.Contains(t, out, "<ol>\n<li>First<ol type=\"a\">\n<li>Nested</li>\n</ol>\n</li>\n<li>Second</li>\n</ol>\n<p>After</p>\n")
+	// New cells are shaded white
+	assert.Contains(t, out, "<td colspan=\"2\" style=\"background-color: #FFFFFF\">\n<p>Wide</p>\n</td>\n"+
+		"<td rowspan=\"2\" style=\"background-color: #FFFFFF\">\n<p>Tall</p>\n</td>\n</tr>\n<tr>\n"+
+		"<td style=\"background-color: #FFFFFF\">\n<p>A</p>\n</td>\n<td style=\"background-color: #FFFFFF\">\n<p>B</p>\n</td>\n</tr>\n")
+	assert.True(t, strings.HasSuffix(out, "</body>\n</html>\n"))
+}
+
+func TestToHTML_NotesAndImages(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.Document.DocRels = Relationships{}
+
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships, &Relationship{
+		ID: "rId90", Type: constants.SourceRelationshipFootnotes, Target: "footnotes.xml",
+	})
+	rd.FileMap.Store("word/footnotes.xml", []byte(`<w:footnotes xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">`+
+		`<w:footnote w:type="separator" w:id="-1"><w:p><w:r><w:separator/></w:r></w:p></w:footnote>`+
+		`<w:footnote w:id="1"><w:p><w:r><w:footnoteRef/></w:r><w:r><w:t xml:space="preserve"> A note.</w:t></w:r></w:p></w:footnote>`+
+		`</w:footnotes>`))
+
+	p := rd.AddParagraph("Text")
+	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Run: &ctypes.Run{
+		Children: []ctypes.RunChild{{FootnoteReference: &ctypes.NoteRef{ID: 1}}},
+	}})
+
+	_, err := rd.AddImage([]byte("GIF89a"), units.Emu(95250), units.Emu(47625))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, rd.ToHTML(&buf, &HTMLOptions{Fragment: true}))
+	out := buf.String()
+
+	assert.True(t, strings.HasPrefix(out, "<style>\n.document { "))
+	assert.Contains(t, out, "<p>Text<a class=\"note-ref\" href=\"#fn1\" id=\"fnref1\"><sup>1</sup></a></p>\n")
+	assert.Contains(t, out, "<img src=\"data:image/gif;base64,R0lGODlh\" alt=\"")
+	assert.Contains(t, out, "width=\"10\" height=\"5\">")
+	assert.Contains(t, out, "<section class=\"footnotes\">\n<hr>\n<ol>\n<li id=\"fn1\">\n<p> A note.</p>\n"+
+		"<a class=\"note-back\" href=\"#fnref1\">&#8617;</a>\n</li>\n</ol>\n</section>\n")
+}
+
+func TestToHTML_UnsafeValues(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.Document.DocRels = Relationships{}
+	styleType, styleID := stypes.StyleTypeParagraph, "Evil"
+	rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, ctypes.Style{
+		Type: &styleType,
+		ID:   &styleID,
+		RunProp: &ctypes.RunProperty{
+			Fonts: &ctypes.RunFonts{Ascii: "x</style><script>alert(1)</script>"},
+			Color: &ctypes.Color{Val: "000;}</style><script>"},
+		},
+	})
+
+	p := rd.AddParagraph("styled")
+	p.Style(styleID)
+	p.AddText(" serif").Font("Times New Roman")
+	p.AddLink("script", "javascript:alert(1)")
+	p.AddLink("data", "data:text/html,<script>alert(1)</script>")
+	p.AddLink("mail", "mailto:team@example.com")
+
+	var buf bytes.Buffer
+	require.NoError(t, rd.ToHTML(&buf, nil))
+	out := buf.String()
+
+	assert.NotContains(t, out, "<script>")
+	assert.NotContains(t, out, "alert")
+	assert.Contains(t, out, `font-family: &#34;Times New Roman&#34;`)
+	assert.Contains(t, out, "scriptdata<a")
+	assert.Contains(t, out, `<a href="mailto:team@example.com">mail</a>`)
+}
+
+func TestResolvedRunProp(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.DocStyles.DocDefaults = &ctypes.DocDefault{
+		RunProp: &ctypes.RunPropDefault{RunProp: &ctypes.RunProperty{
+			Fonts: &ctypes.RunFonts{AsciiTheme: stypes.ThemeFontMinorHAnsi, EastAsia: "MS Mincho"},
+			Size:  ctypes.NewFontSize(22),
+		}},
+	}
+	paraType, charType := stypes.StyleTypeParagraph, stypes.StyleTypeCharacter
+	baseID, quoteID, emphasisID := "Base", "Quote", "Emphasis"
+	rd.DocStyles.StyleList = append(rd.DocStyles.StyleList,
+		ctypes.Style{Type: &paraType, ID: &baseID, RunProp: &ctypes.RunProperty{Color: ctypes.NewColor("333333")}},
+		ctypes.Style{Type: &paraType, ID: &quoteID, BasedOn: &ctypes.CTString{Val: baseID}, RunProp: &ctypes.RunProperty{Italic: &ctypes.OnOff{}}},
+		ctypes.Style{Type: &charType, ID: &emphasisID, RunProp: &ctypes.RunProperty{Bold: &ctypes.OnOff{}, Size: ctypes.NewFontSize(28)}},
+	)
+
+	p := rd.AddParagraph("")
+	p.Style(quoteID)
+	run := p.AddText("x").Font("Arial")
+	run.getProp().Style = ctypes.NewRunStyle(emphasisID)
+
+	rp := rd.resolvedRunProp(&p.ct, nil, run.ct)
+	assert.Equal(t, "Arial", rp.Fonts.Ascii)
+	assert.Empty(t, rp.Fonts.AsciiTheme)
+	assert.Equal(t, "MS Mincho", rp.Fonts.EastAsia)
+	assert.Equal(t, uint64(28), rp.Size.Value)
+	assert.Equal(t, "333333", rp.Color.Val)
+	assert.True(t, rp.Italic.Bool())
+	assert.True(t, rp.Bold.Bool())
+	assert.Equal(t, "Arial", rd.fontFamily(rp.Fonts))
+
+	// Resolving copies the style properties
+	rp.Color.Val = "000000"
+	assert.Equal(t, "333333", rd.GetStyleByID(baseID, paraType).RunProp.Color.Val)
+}
+
+func TestToHTML_TableStyle(t *testing.T) {
+	rd := setupRootDoc(t)
+	list := rd.AddTableStyle("LightList", "Light List")
+	list.Conditional(stypes.TblStyleOverrideFirstRow).Bold(true).Color("FFFFFF").Justification(stypes.JustificationCenter)
+	require.NoError(t, list.Err())
+
+	table := rd.AddTable()
+	table.Style("LightList")
+	table.GetCT().TableProp.TableLook = ctypes.NewCTString("04A0")
+	table.AddRow().AddCell().AddParagraph("Name")
+	table.AddRow().AddCell().AddParagraph("Ann")
+
+	var buf bytes.Buffer
+	require.NoError(t, rd.ToHTML(&buf, nil))
+	out := buf.String()
+	assert.Contains(t, out, `<p style="text-align: center"><span style="color: #FFFFFF"><strong>Name</strong></span></p>`)
+	assert.Contains(t, out, "<p>Ann</p>")
+}
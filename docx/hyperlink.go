@@ -16,10 +16,19 @@ func newHyperlink(root *RootDoc, ct *ctypes.Hyperlink) *Hyperlink {
 
 // getProp returns the hyperlink properties. If not initialized, it creates and returns a new instance.
 func (r *Hyperlink) getProp() *ctypes.RunProperty {
-	if r.ct.Run.Property == nil {
-		r.ct.Run.Property = &ctypes.RunProperty{}
+	run := r.ct.Run
+	if run == nil {
+		if runs := r.ct.Runs(); len(runs) > 0 {
+			run = runs[0]
+		} else {
+			run = &ctypes.Run{}
+			r.ct.Children = append(r.ct.Children, ctypes.ParagraphChild{Run: run})
+		}
 	}
-	return r.ct.Run.Property
+	if run.Property == nil {
+		run.Property = &ctypes.RunProperty{}
+	}
+	return run.Property
 }
 
 // Sets the color of the Hyperlink.
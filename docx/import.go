@@ -0,0 +1,349 @@
+package docx
+
+import (
+	"fmt"
+	"mime"
+	"net/http"
+	"path"
+	"strings"
+
+	"godocx/common/constants"
+	"godocx/dml"
+	"godocx/dml/dmlpic"
+	"godocx/wml/ctypes"
+)
+
+// importContent returns copies of block content of another document for the document. The
+// relationships, media, styles and list instances of the content are added to the document,
+// styles conflicting with those of the document being merged as selected by conflict.
+func (rd *RootDoc) importContent(other *RootDoc, children []DocumentChild, conflict StyleConflict) ([]DocumentChild, error) {
+	ci := &contentImporter{src: other, dst: rd}
+	if err := ci.prepare(children); err != nil {
+		return nil, err
+	}
+	sm := &styleMerger{src: other, dst: rd, conflict: conflict}
+	sm.plan(ci.children)
+
+	// Numbering instances used by the content and the copied styles
+	var numIDs []int
+	forEachPara(ci.children, func(p *ctypes.Paragraph) bool {
+		numIDs = appendNumID(numIDs, p.Property)
+		return true
+	})
+	for _, style := range sm.styles {
+		numIDs = appendNumID(numIDs, style.ParaProp)
+	}
+
+	instances := map[int]int{}
+	if src := other.numberingDefs(); src != nil && len(numIDs) > 0 {
+		if rd.Numbering == nil {
+			rd.Numbering = NewNumberingManager(rd)
+		}
+		var err error
+		if instances, err = rd.Numbering.importNumbering(src, numIDs, sm.renamed); err != nil {
+			return nil, err
+		}
+	}
+	ci.listInstance = func(numID int) (int, bool) {
+		newID, ok := instances[numID]
+		return newID, ok
+	}
+
+	sm.apply(instances)
+	copies, err := ci.commit()
+	if err != nil {
+		return nil, err
+	}
+	eachStyleRef(copies, func(ref *ctypes.CTString) {
+		if newID, ok := sm.renamed[ref.Val]; ok {
+			ref.Val = newID
+		}
+	})
+	return copies, nil
+}
+
+// ImportElement returns a copy of a paragraph or table of another document, detached from the
+// document body, to be inserted with MoveBefore or MoveAfter. The hyperlinks and images of the
+// element are added to the document, with the styles it uses that the document lacks and copies
+// of its lists. An element of the document itself is cloned.
+//
+// Example:
+//
+//	clause := library.FindParagraphs(docx.ContainingText("Liability"))[0]
+//	imported, err := document.ImportElement(clause, library)
+//	if err == nil {
+//		err = imported.MoveAfter(anchor)
+//	}
+func (rd *RootDoc) ImportElement(el Block, from *RootDoc) (Block, error) {
+	if from == rd {
+		switch el := el.(type) {
+		case *Paragraph:
+			return el.Clone(), nil
+		case *Table:
+			return el.Clone(), nil
+		}
+	}
+
+	copies, err := rd.importContent(from, []DocumentChild{el.blockChild()}, UseDestinationStyle)
+	if err != nil {
+		return nil, fmt.Errorf("import element: %w", err)
+	}
+	if copies[0].Para != nil {
+		return copies[0].Para, nil
+	}
+	return copies[0].Table, nil
+}
+
+// contentImporter copies block content of a source document into a destination document,
+// adding the relationships, media and list instances the content refers to.
+//
+// Importing is done in two steps so that the destination is left untouched when the content
+// cannot be imported: prepare copies the content and checks its references, commit adds the
+// referenced parts to the destination and rewrites the references of the copy.
+type contentImporter struct {
+	src, dst *RootDoc
+
+	// listInstance returns the destination numbering instance of a source numbering instance,
+	// false to keep the ID. It is called once per instance while committing.
+	listInstance func(numID int) (int, bool)
+
+	children []DocumentChild          // Copy of the imported content
+	rels     map[string]*Relationship // Source relationships referenced by the content, by ID
+	parts    map[string][]byte        // Content of the internal parts referenced by the content, by source relationship ID
+	relIDs   map[string]string        // Source relationship ID to destination relationship ID
+	numIDs   map[int]int              // Source numbering instance to destination numbering instance
+	images   map[string]uint          // Destination relationship ID to number of the imported images
+	stored   []string                 // Parts added to the destination
+}
+
+// importState is the state of the destination changed by adding parts, restored when adding
+// them fails.
+type importState struct {
+	rels, rID           int
+	imageCount          uint
+	defaults, overrides int
+}
+
+// importState returns the state of the document changed by adding parts.
+func (rd *RootDoc) importState() importState {
+	return importState{
+		rels:       len(rd.Document.DocRels.Relationships),
+		rID:        rd.Document.RID,
+		imageCount: rd.ImageCount,
+		defaults:   len(rd.ContentType.Default),
+		overrides:  len(rd.ContentType.Override),
+	}
+}
+
+// prepare copies the content and checks that the parts it references exist in the source.
+func (ci *contentImporter) prepare(children []DocumentChild) error {
+	ci.rels = map[string]*Relationship{}
+	ci.parts = map[string][]byte{}
+
+	ci.children = cloneChildren(ci.dst, children)
+
+	var err error
+	ci.eachRelRef(func(rID *string) {
+		if err != nil || *rID == "" || ci.rels[*rID] != nil {
+			return
+		}
+		rel := ci.src.Document.relationship(*rID)
+		if rel == nil {
+			err = fmt.Errorf("relationship %s not found", *rID)
+			return
+		}
+		ci.rels[*rID] = rel
+		if rel.TargetMode == "External" {
+			return
+		}
+		partPath := ci.src.Document.partPath(rel.Target)
+		content, ok := ci.src.FileMap.Load(partPath)
+		if !ok {
+			err = fmt.Errorf("part %s referenced by %s not found", partPath, *rID)
+			return
+		}
+		ci.parts[*rID] = content.([]byte)
+	})
+	return err
+}
+
+// commit adds the parts and list instances referenced by the content to the destination and
+// rewrites the references of the copied content, which is returned.
+// The destination is left unchanged when a part cannot be added.
+func (ci *contentImporter) commit() ([]DocumentChild, error) {
+	ci.relIDs = map[string]string{}
+	ci.images = map[string]uint{}
+	ci.stored = nil
+	saved := ci.dst.importState()
+	for rID, rel := range ci.rels {
+		newID, err := ci.importRel(rID, rel)
+		if err != nil {
+			ci.rollback(saved)
+			return nil, err
+		}
+		ci.relIDs[rID] = newID
+	}
+	ci.eachRelRef(func(rID *string) {
+		if newID, ok := ci.relIDs[*rID]; ok {
+			*rID = newID
+		}
+	})
+	ci.eachDrawing(func(docProp *dml.DocProp, blip *dmlpic.Blip) {
+		// Pictures of a document need distinct IDs
+		if n, ok := ci.images[blip.EmbedID]; ok {
+			docProp.ID = uint64(n)
+		}
+	})
+
+	ci.numIDs = map[int]int{}
+	forEachPara(ci.children, func(p *ctypes.Paragraph) bool {
+		if p.Property == nil || p.Property.NumProp == nil || p.Property.NumProp.NumID == nil || ci.listInstance == nil {
+			return true
+		}
+		numID := &p.Property.NumProp.NumID.Val
+		newID, ok := ci.numIDs[*numID]
+		if !ok {
+			if newID, ok = ci.listInstance(*numID); !ok {
+				newID = *numID
+			}
+			ci.numIDs[*numID] = newID
+		}
+		*numID = newID
+		return true
+	})
+	return ci.children, nil
+}
+
+// rollback removes the parts added to the destination and restores its state.
+func (ci *contentImporter) rollback(saved importState) {
+	doc := ci.dst.Document
+	for _, partPath := range ci.stored {
+		ci.dst.FileMap.Delete(partPath)
+	}
+	ci.stored = nil
+	doc.DocRels.Relationships = doc.DocRels.Relationships[:saved.rels]
+	doc.RID = saved.rID
+	ci.dst.ImageCount = saved.imageCount
+	ci.dst.ContentType.Default = ci.dst.ContentType.Default[:saved.defaults]
+	ci.dst.ContentType.Override = ci.dst.ContentType.Override[:saved.overrides]
+}
+
+// importRel adds the target of a source relationship to the destination and returns the ID of
+// the destination relationship. Images are numbered after the images of the destination,
+// other parts keep their name unless it is taken.
+func (ci *contentImporter) importRel(rID string, rel *Relationship) (string, error) {
+	doc := ci.dst.Document
+	if rel.TargetMode == "External" {
+		if rel.Type == constants.SourceRelationshipHyperLink {
+			return doc.addLinkRelation(rel.Target), nil
+		}
+		newID := "rId" + fmt.Sprint(doc.IncRelationID())
+		doc.DocRels.Relationships = append(doc.DocRels.Relationships, &Relationship{
+			ID: newID, Type: rel.Type, Target: rel.Target, TargetMode: rel.TargetMode,
+		})
+		return newID, nil
+	}
+
+	content := ci.parts[rID]
+	srcPath := ci.src.Document.partPath(rel.Target)
+	ext := strings.TrimPrefix(path.Ext(srcPath), ".")
+	contentType := ci.src.ContentType.partType(srcPath)
+	if contentType == "" {
+		contentType = mime.TypeByExtension("." + ext)
+	}
+	if contentType == "" {
+		contentType = http.DetectContentType(content)
+	}
+
+	if rel.Type == constants.SourceRelationshipImage {
+		newID, err := ci.dst.addImagePart(content, ext, contentType)
+		if err != nil {
+			return "", err
+		}
+		ci.images[newID] = ci.dst.ImageCount
+		ci.stored = append(ci.stored, doc.partPath(doc.relationship(newID).Target))
+		return newID, nil
+	}
+
+	dstPath := srcPath
+	for i := 1; ; i++ {
+		if _, taken := ci.dst.FileMap.Load(dstPath); !taken {
+			break
+		}
+		dstPath = fmt.Sprintf("%s%d%s", strings.TrimSuffix(srcPath, path.Ext(srcPath)), i, path.Ext(srcPath))
+	}
+	if err := ci.dst.ContentType.AddOverride("/"+dstPath, contentType); err != nil {
+		return "", err
+	}
+	ci.dst.FileMap.Store(dstPath, content)
+	ci.stored = append(ci.stored, dstPath)
+
+	return doc.addRelation(rel.Type, strings.TrimPrefix(dstPath, doc.partPath(".")+"/")), nil
+}
+
+// eachRelRef calls fn with the relationship IDs referenced by the copied content: hyperlinks,
+// pictures and VML image data.
+func (ci *contentImporter) eachRelRef(fn func(rID *string)) {
+	forEachPara(ci.children, func(p *ctypes.Paragraph) bool {
+		for _, child := range p.Children {
+			if child.Link != nil && child.Link.ID != "" {
+				fn(&child.Link.ID)
+			}
+		}
+		for run := range (&Paragraph{ref: p}).Runs() {
+			for _, rc := range run.ct.Children {
+				if rc.Pict != nil && rc.Pict.Shape != nil && rc.Pict.Shape.ImageData != nil {
+					fn(&rc.Pict.Shape.ImageData.RId)
+				}
+			}
+		}
+		return true
+	})
+	ci.eachDrawing(func(_ *dml.DocProp, blip *dmlpic.Blip) {
+		fn(&blip.EmbedID)
+	})
+}
+
+// eachDrawing calls fn with the properties and the image of the inline and anchored pictures
+// of the copied content.
+func (ci *contentImporter) eachDrawing(fn func(docProp *dml.DocProp, blip *dmlpic.Blip)) {
+	visit := func(docProp *dml.DocProp, graphic *dml.Graphic) {
+		if graphic.Data != nil && graphic.Data.Pic != nil && graphic.Data.Pic.BlipFill.Blip != nil {
+			fn(docProp, graphic.Data.Pic.BlipFill.Blip)
+		}
+	}
+	forEachPara(ci.children, func(p *ctypes.Paragraph) bool {
+		for run := range (&Paragraph{ref: p}).Runs() {
+			for _, rc := range run.ct.Children {
+				if rc.Drawing == nil {
+					continue
+				}
+				for i := range rc.Drawing.Inline {
+					visit(&rc.Drawing.Inline[i].DocProp, &rc.Drawing.Inline[i].Graphic)
+				}
+				for _, anchor := range rc.Drawing.Anchor {
+					if anchor != nil {
+						visit(&anchor.DocProp, &anchor.Graphic)
+					}
+				}
+			}
+		}
+		return true
+	})
+}
+
+// partType returns the content type of a part of the package, "" if it is unknown.
+func (c *ContentTypes) partType(partPath string) string {
+	for _, override := range c.Override {
+		if strings.TrimPrefix(override.PartName, "/") == partPath {
+			return override.ContentType
+		}
+	}
+	ext := strings.TrimPrefix(path.Ext(partPath), ".")
+	for _, def := range c.Default {
+		if strings.EqualFold(def.Extension, ext) {
+			return def.ContentType
+		}
+	}
+	return ""
+}
@@ -1,6 +1,7 @@
 package docx
 
 import (
+	"path"
 	"strconv"
 
 	"godocx/common/constants"
@@ -55,3 +56,23 @@ func (doc *Document) addRelation(relType string, fileName string) string {
 
 	return "rId" + strconv.Itoa(rID)
 }
+
+// relationship returns the document relationship with the given ID, or nil if there is none.
+func (doc *Document) relationship(rID string) *Relationship {
+	for _, rel := range doc.DocRels.Relationships {
+		if rel.ID == rID {
+			return rel
+		}
+	}
+	return nil
+}
+
+// partPath returns the package path of a part targeted by a document relationship,
+// targets being relative to the folder of the main document part.
+func (doc *Document) partPath(target string) string {
+	dir := "word"
+	if doc.relativePath != "" {
+		dir = path.Dir(doc.relativePath)
+	}
+	return path.Join(dir, target)
+}
@@ -0,0 +1,207 @@
+package docx
+
+import (
+	"fmt"
+)
+
+// List is a bulleted or numbered list of the document body or of a table cell. Its items are
+// paragraphs numbered with the list, kept together: an item is added after the last item of the
+// list and of its sublists. The first error met while adding items is recorded and returned by
+// Err.
+type List struct {
+	tree   *listTree
+	level  int
+	numID  int // Numbering instance of the list, 0 for the instance of the parent list
+	parent *ListItem
+	items  []*ListItem
+}
+
+// listTree is the state shared by a list and its sublists.
+type listTree struct {
+	root       *RootDoc
+	abstractID int
+	// add appends an empty paragraph to the body or cell holding the list
+	add func() *Paragraph
+	err error
+}
+
+// ListItem is an item of a list: a paragraph, to which runs, links and images can be added,
+// and the sublist it may hold.
+type ListItem struct {
+	*Paragraph
+	list *List
+	sub  *List
+}
+
+// AddBulletList adds a bulleted list to the end of the document body.
+//
+// Example:
+//
+//	list := document.AddBulletList()
+//	list.Item("Fruits").Sublist().Item("Apples")
+//	list.Item("See ").AddLink("the catalogue", "https://example.com/catalogue")
+func (rd *RootDoc) AddBulletList() *List {
+	return newList(rd, BulletListID, rd.AddEmptyParagraph)
+}
+
+// AddNumberedList adds a numbered list to the end of the document body, numbered from 1.
+func (rd *RootDoc) AddNumberedList() *List {
+	return newList(rd, DecimalListID, rd.AddEmptyParagraph)
+}
+
+// AddBulletList adds a bulleted list to the end of the cell.
+func (c *Cell) AddBulletList() *List {
+	return newList(c.root, BulletListID, c.AddEmptyPara)
+}
+
+// AddNumberedList adds a numbered list to the end of the cell, numbered from 1.
+func (c *Cell) AddNumberedList() *List {
+	return newList(c.root, DecimalListID, c.AddEmptyPara)
+}
+
+func newList(rd *RootDoc, abstractID int, add func() *Paragraph) *List {
+	if rd.Numbering == nil {
+		rd.Numbering = NewNumberingManager(rd)
+	}
+	tree := &listTree{root: rd, abstractID: abstractID, add: add}
+	l := &List{tree: tree, numID: rd.NewListInstance(abstractID)}
+	if err := rd.Numbering.err; err != nil {
+		tree.err = fmt.Errorf("list: %w", err)
+	}
+	return l
+}
+
+// Err returns the first error met while adding items to the list or to its sublists.
+func (l *List) Err() error {
+	return l.tree.err
+}
+
+// NumID returns the ID of the numbering instance of the list, as set by Paragraph.Numbering.
+// A sublist shares the instance of its parent list until restarted.
+func (l *List) NumID() int {
+	for l.numID == 0 && l.parent != nil {
+		l = l.parent.list
+	}
+	return l.numID
+}
+
+// Level returns the level of the list, 0 for a list and 1 for its sublists, and so on.
+func (l *List) Level() int {
+	return l.level
+}
+
+// Items returns the items of the list, without the items of its sublists.
+func (l *List) Items() []*ListItem {
+	return l.items
+}
+
+// Item adds an item with the given text to the list. More text, runs and links can be added to
+// the item, which is empty with an empty text.
+func (l *List) Item(text string) *ListItem {
+	p, err := l.addParagraph()
+	if err != nil {
+		if l.tree.err == nil {
+			l.tree.err = fmt.Errorf("list %d: item %q: %w", l.NumID(), text, err)
+		}
+		// Returned for the calls to go on, though not part of the document
+		p = newParagraph(l.tree.root)
+	}
+	if text != "" {
+		p.AddText(text)
+	}
+	p.Numbering(l.NumID(), l.level)
+	item := &ListItem{Paragraph: p, list: l}
+	l.items = append(l.items, item)
+	return item
+}
+
+// addParagraph adds an empty paragraph after the last item of the list, or after the item
+// holding the list for the first item of a sublist.
+func (l *List) addParagraph() (*Paragraph, error) {
+	var anchor *Paragraph
+	switch {
+	case len(l.items) > 0:
+		anchor = l.items[len(l.items)-1].last()
+	case l.parent != nil:
+		anchor = l.parent.Paragraph
+	default:
+		return l.tree.add(), nil
+	}
+	return anchor.InsertParagraphAfter("")
+}
+
+// Restart numbers the items added afterwards to the list and to its sublists from 1 again, as
+// a new list. Items added before keep their numbers, and the items of the parent lists go on
+// with their numbering.
+func (l *List) Restart() *List {
+	if l.tree.err == nil {
+		l.numID = l.tree.root.Numbering.newInstance(l.tree.abstractID, l.level)
+	}
+	return l
+}
+
+// ContinueFrom numbers the items of the list, and of its sublists, following the items of
+// another list, as the second part of a list interrupted by other paragraphs. Both lists then
+// share their numbering, including the items added afterwards.
+func (l *List) ContinueFrom(other *List) *List {
+	if l.tree.err != nil {
+		return l
+	}
+	if l.tree.root != other.tree.root {
+		l.tree.err = fmt.Errorf("list %d: continue from list %d of another document", l.NumID(), other.NumID())
+		return l
+	}
+	top := l
+	for top.parent != nil {
+		top = top.parent.list
+	}
+	l.tree.abstractID = other.tree.abstractID
+	top.renumber(other.NumID())
+	return l
+}
+
+// renumber sets the numbering instance of the list and of its sublists, and of their items.
+func (l *List) renumber(numID int) {
+	l.numID = 0
+	if l.parent == nil {
+		l.numID = numID
+	}
+	for _, item := range l.items {
+		item.Paragraph.Numbering(numID, l.level)
+		if item.sub != nil {
+			item.sub.renumber(numID)
+		}
+	}
+}
+
+// Sublist returns the list nested in the item, one level deeper, created on the first call.
+// Its items are added after the item and the items already nested in it.
+//
+// Example:
+//
+//	list := document.AddNumberedList()
+//	steps := list.Item("Install").Sublist()
+//	steps.Item("Download the archive")
+//	steps.Item("Extract it")
+func (i *ListItem) Sublist() *List {
+	if i.sub == nil {
+		i.sub = &List{tree: i.list.tree, level: i.list.level + 1, parent: i}
+		if i.sub.level > 8 && i.list.tree.err == nil {
+			i.list.tree.err = fmt.Errorf("list %d: level %d out of range [0, 8]", i.list.NumID(), i.sub.level)
+		}
+	}
+	return i.sub
+}
+
+// List returns the list holding the item.
+func (i *ListItem) List() *List {
+	return i.list
+}
+
+// last returns the last paragraph of the item, the last item of its sublist if any.
+func (i *ListItem) last() *Paragraph {
+	if i.sub != nil && len(i.sub.items) > 0 {
+		return i.sub.items[len(i.sub.items)-1].last()
+	}
+	return i.Paragraph
+}
@@ -0,0 +1,101 @@
+package docx
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddNumberedList(t *testing.T) {
+	rd := setupRootDoc(t)
+
+	steps := rd.AddNumberedList()
+	install := steps.Item("Install")
+	steps.Item("Configure")
+	// Added under Install, before Configure
+	download := install.Sublist().Item("Download")
+	download.Sublist().Item("Check the checksum")
+	install.Sublist().Item("Extract")
+	steps.Item("Run ").AddLink("the server", "https://example.com/run")
+	rd.AddParagraph("Then:")
+
+	more := rd.AddNumberedList().ContinueFrom(steps)
+	more.Item("Test")
+	more.Restart()
+	more.Item("Deploy")
+	require.NoError(t, steps.Err())
+	require.NoError(t, more.Err())
+
+	text, err := rd.Text(&TextOptions{ListLabels: true})
+	require.NoError(t, err)
+	assert.Equal(t, "1.\tInstall\n"+
+		"a.\tDownload\n"+
+		"i.\tCheck the checksum\n"+
+		"b.\tExtract\n"+
+		"2.\tConfigure\n"+
+		"3.\tRun the server\n"+
+		"Then:\n"+
+		"4.\tTest\n"+
+		"1.\tDeploy\n", text)
+
+	assert.Len(t, steps.Items(), 3)
+	assert.Equal(t, 1, download.List().Level())
+	assert.Equal(t, steps.NumID(), more.Items()[0].GetCT().Property.NumProp.NumID.Val)
+	assert.NotEqual(t, steps.NumID(), more.NumID())
+
+	nested := steps.Items()[0]
+	for range 8 {
+		nested = nested.Sublist().Item("deeper")
+	}
+	assert.ErrorContains(t, nested.Sublist().Err(), "level 9 out of range")
+}
+
+func TestAddBulletListInCell(t *testing.T) {
+	rd := setupRootDoc(t)
+	cell := rd.AddTable().AddRow().AddCell()
+	cell.AddParagraph("Options:")
+
+	list := cell.AddBulletList()
+	list.Item("Fast").Sublist().Item("Cached")
+	list.Item("Safe")
+	require.NoError(t, list.Err())
+
+	blocks := cell.Blocks()
+	require.Len(t, blocks, 4)
+	var labels []string
+	labeler := newListLabeler(rd)
+	for _, b := range blocks[1:] {
+		p := b.(*Paragraph)
+		labels = append(labels, labeler.label(p.GetCT())+p.Text())
+	}
+	assert.Equal(t, []string{"•Fast", "○Cached", "•Safe"}, labels)
+}
+
+func TestListRestartSublist(t *testing.T) {
+	rd := setupRootDoc(t)
+
+	list := rd.AddNumberedList()
+	list.Item("A").Sublist().Item("A1")
+	sub := list.Item("B").Sublist()
+	sub.Item("B1")
+	sub.Restart()
+	sub.Item("B2")
+	list.Item("C")
+	require.NoError(t, list.Err())
+
+	text, err := rd.Text(&TextOptions{ListLabels: true})
+	require.NoError(t, err)
+	assert.Equal(t, "1.\tA\n"+
+		"a.\tA1\n"+
+		"2.\tB\n"+
+		"a.\tB1\n"+
+		"a.\tB2\n"+
+		"3.\tC\n", text)
+
+	assert.NotEqual(t, list.NumID(), sub.NumID())
+	assert.Equal(t, list.NumID(), list.Items()[2].GetCT().Property.NumProp.NumID.Val)
+	assert.Contains(t, savedNumbering(t, rd), `<w:num w:numId="`+strconv.Itoa(sub.NumID())+`"><w:abstractNumId w:val="201"></w:abstractNumId>`+
+		`<w:lvlOverride w:ilvl="1"><w:startOverride w:val="1"></w:startOverride></w:lvlOverride></w:num>`)
+}
@@ -0,0 +1,254 @@
+package docx
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"godocx/common/units"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+)
+
+// ListBuilder defines a list added to the document: an abstract numbering definition, whose
+// nine levels are set with Level. Its methods return the builder, so that calls can be chained.
+// The first invalid call is recorded and returned by Err, the following calls being ignored.
+type ListBuilder struct {
+	root *RootDoc
+	id   int
+	err  error
+}
+
+// DefineList adds a list definition to the document. Its levels are numbered like the lists of
+// DecimalListID until changed: 1., a., i., A., 1. and so on, each level being indented by a
+// quarter inch more than the previous one. The ID of the definition is passed to
+// NewListInstance to start a list, whose paragraphs are numbered with Paragraph.Numbering.
+//
+// Example:
+//
+//	outline := document.DefineList()
+//	outline.Level(0).Format(stypes.NumFmtUpperRoman).Text("%1.").Indent(720, 720)
+//	outline.Level(1).Format(stypes.NumFmtDecimal).Text("%1.%2").Legal(true)
+//	outline.Level(2).Bullet("–", "Arial")
+//	if err := outline.Err(); err != nil {
+//		return err
+//	}
+//	numID := document.NewListInstance(outline.ID())
+//	document.AddParagraph("Scope").Numbering(numID, 0)
+//	document.AddParagraph("Definitions").Numbering(numID, 1)
+func (rd *RootDoc) DefineList() *ListBuilder {
+	if rd.Numbering == nil {
+		rd.Numbering = NewNumberingManager(rd)
+	}
+	b := &ListBuilder{root: rd}
+	if id, err := rd.Numbering.defineList(); err != nil {
+		b.err = fmt.Errorf("define list: %w", err)
+	} else {
+		b.id = id
+	}
+	return b
+}
+
+// Err returns the first error met while defining the list, nil if the list is valid.
+func (b *ListBuilder) Err() error {
+	return b.err
+}
+
+// ID returns the ID of the abstract numbering definition, to be passed to NewListInstance.
+func (b *ListBuilder) ID() int {
+	return b.id
+}
+
+// Definition returns the abstract numbering definition of the list in the document, nil if it
+// could not be added.
+func (b *ListBuilder) Definition() *ctypes.AbstractNum {
+	var abstract *ctypes.AbstractNum
+	if b.err == nil {
+		b.err = b.root.Numbering.update(func(n *ctypes.Numbering) error {
+			abstract = n.AbstractNumByID(b.id)
+			return nil
+		})
+	}
+	return abstract
+}
+
+// Level returns the definition of a level of the list, from 0 for the outermost level to 8.
+func (b *ListBuilder) Level(ilvl int) *ListLevel {
+	if b.err == nil && (ilvl < 0 || ilvl > 8) {
+		b.err = fmt.Errorf("list %d: level %d out of range [0, 8]", b.id, ilvl)
+	}
+	return &ListLevel{list: b, ilvl: ilvl}
+}
+
+// ListLevel defines a level of a list. Its methods return the level, so that calls can be
+// chained. Errors are recorded by the list builder.
+type ListLevel struct {
+	list *ListBuilder
+	ilvl int
+}
+
+// Err returns the first error met while defining the list, nil if the list is valid.
+func (l *ListLevel) Err() error {
+	return l.list.err
+}
+
+// set calls fn with the definition of the level, recording the error it returns.
+func (l *ListLevel) set(fn func(level *ctypes.Level) error) *ListLevel {
+	b := l.list
+	if b.err != nil {
+		return l
+	}
+	err := b.root.Numbering.update(func(n *ctypes.Numbering) error {
+		abstract := n.AbstractNumByID(b.id)
+		if abstract == nil {
+			return fmt.Errorf("the list was removed from the document")
+		}
+		level := abstract.Level(l.ilvl)
+		if level == nil {
+			level = &ctypes.Level{ILvl: l.ilvl}
+			abstract.Levels = append(abstract.Levels, level)
+			slices.SortFunc(abstract.Levels, func(a, b *ctypes.Level) int { return a.ILvl - b.ILvl })
+		}
+		return fn(level)
+	})
+	if err != nil {
+		b.err = fmt.Errorf("list %d: level %d: %w", b.id, l.ilvl, err)
+	}
+	return l
+}
+
+// Format sets the numbering format of the level, such as decimal or lower letter numbers.
+func (l *ListLevel) Format(format stypes.NumFmt) *ListLevel {
+	return l.set(func(level *ctypes.Level) error {
+		level.NumFmt = ctypes.NewGenSingleStrVal(format)
+		return nil
+	})
+}
+
+// levelPlaceholderRe matches the placeholders of the number of a level in a level text.
+var levelPlaceholderRe = regexp.MustCompile(`%(\d)`)
+
+// Text sets the text of the label of the level, where %1 to %9 stand for the current numbers
+// of the levels 0 to 8: "%1.%2." numbers the items of the second level 1.1., 1.2. and so on.
+// Only the numbers of the level and of the levels containing it can be shown.
+func (l *ListLevel) Text(text string) *ListLevel {
+	return l.set(func(level *ctypes.Level) error {
+		for _, m := range levelPlaceholderRe.FindAllStringSubmatch(text, -1) {
+			if n, _ := strconv.Atoi(m[1]); n < 1 || n > l.ilvl+1 {
+				return fmt.Errorf("level text %q refers to the number of level %d", text, n-1)
+			}
+		}
+		level.LvlText = ctypes.NewCTString(text)
+		return nil
+	})
+}
+
+// Start sets the number of the first item of the level.
+func (l *ListLevel) Start(value int) *ListLevel {
+	return l.set(func(level *ctypes.Level) error {
+		if value < 0 {
+			return fmt.Errorf("negative start value %d", value)
+		}
+		level.Start = ctypes.NewDecimalNum(value)
+		return nil
+	})
+}
+
+// Justification sets the alignment of the label of the level.
+func (l *ListLevel) Justification(value stypes.Justification) *ListLevel {
+	return l.set(func(level *ctypes.Level) error {
+		level.Justification = ctypes.NewGenSingleStrVal(value)
+		return nil
+	})
+}
+
+// Indent sets the indentation of the items of the level, in twips: the text is indented by
+// left and the label hangs hanging twips to its left. The tab stop following the label is
+// moved to the text.
+func (l *ListLevel) Indent(left int, hanging uint64) *ListLevel {
+	return l.set(func(level *ctypes.Level) error {
+		prop := levelParaProp(level)
+		prop.Indent = &ctypes.Indent{Left: &left, Hanging: &hanging}
+		prop.Tabs = ctypes.Tabs{Tab: []ctypes.Tab{{Val: stypes.CustTabStopNum, Position: left}}}
+		return nil
+	})
+}
+
+// Tab sets the position of the tab stop following the label of the level, in twips.
+func (l *ListLevel) Tab(position int) *ListLevel {
+	return l.set(func(level *ctypes.Level) error {
+		levelParaProp(level).Tabs = ctypes.Tabs{Tab: []ctypes.Tab{{Val: stypes.CustTabStopNum, Position: position}}}
+		return nil
+	})
+}
+
+// Bullet makes the level a bullet list showing the glyph in the given font, e.g. "•" in
+// "Arial", or "" in "Symbol" for the bullet of Word. An empty font keeps the font of the
+// paragraph.
+func (l *ListLevel) Bullet(glyph, font string) *ListLevel {
+	return l.set(func(level *ctypes.Level) error {
+		level.NumFmt = ctypes.NewGenSingleStrVal(stypes.NumFmtBullet)
+		level.LvlText = ctypes.NewCTString(glyph)
+		level.PicBulletID = nil
+		setLevelFont(level, font)
+		return nil
+	})
+}
+
+// PictureBullet makes the level a bullet list showing the image, a PNG, JPEG or GIF image,
+// at the given size.
+func (l *ListLevel) PictureBullet(imgBytes []byte, size units.Point) *ListLevel {
+	if l.list.err != nil {
+		return l
+	}
+	id, err := l.list.root.Numbering.addPicBullet(imgBytes, size)
+	if err != nil {
+		l.list.err = fmt.Errorf("list %d: level %d: picture bullet: %w", l.list.id, l.ilvl, err)
+		return l
+	}
+	return l.set(func(level *ctypes.Level) error {
+		level.NumFmt = ctypes.NewGenSingleStrVal(stypes.NumFmtBullet)
+		level.PicBulletID = ctypes.NewDecimalNum(id)
+		// Shown by applications without picture bullets
+		level.LvlText = ctypes.NewCTString("")
+		setLevelFont(level, "Symbol")
+		return nil
+	})
+}
+
+// Legal shows the numbers of the level and of the levels containing it as decimal numbers, as
+// in legal documents: with Legal(true), a level with the text "%1.%2" under a level numbered
+// with roman numerals is numbered 2.1 rather than II.1.
+func (l *ListLevel) Legal(value bool) *ListLevel {
+	return l.set(func(level *ctypes.Level) error {
+		level.IsLgl = nil
+		if value {
+			level.IsLgl = &ctypes.OnOff{}
+		}
+		return nil
+	})
+}
+
+// levelParaProp returns the paragraph properties of the level.
+func levelParaProp(level *ctypes.Level) *ctypes.ParagraphProp {
+	if level.ParaProp == nil {
+		level.ParaProp = &ctypes.ParagraphProp{}
+	}
+	return level.ParaProp
+}
+
+// setLevelFont sets the font of the label of the level, the font of the paragraph with an
+// empty font.
+func setLevelFont(level *ctypes.Level, font string) {
+	if font == "" {
+		if level.RunProp != nil {
+			level.RunProp.Fonts = nil
+		}
+		return
+	}
+	if level.RunProp == nil {
+		level.RunProp = &ctypes.RunProperty{}
+	}
+	level.RunProp.Fonts = &ctypes.RunFonts{Ascii: font, HAnsi: font, Hint: stypes.FontTypeHintDefault}
+}
@@ -0,0 +1,78 @@
+package docx
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"testing"
+
+	"godocx/wml/stypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefineList(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.Numbering = NewNumberingManager(rd)
+	rd.NewListInstance(1)
+
+	outline := rd.DefineList()
+	outline.Level(0).Format(stypes.NumFmtUpperRoman).Text("%1.").Start(3).Indent(720, 720)
+	outline.Level(1).Format(stypes.NumFmtDecimal).Text("%1.%2").Legal(true).Justification(stypes.JustificationEnd).Tab(900)
+	outline.Level(2).Bullet("–", "Arial")
+	require.NoError(t, outline.Err())
+	// The ID follows the generated definitions
+	assert.Equal(t, 203, outline.ID())
+
+	abstract := outline.Definition()
+	require.NotNil(t, abstract)
+	assert.Len(t, abstract.Levels, 9)
+	assert.NotNil(t, abstract.Nsid)
+	first := abstract.Level(0)
+	assert.Equal(t, 720, *first.ParaProp.Indent.Left)
+	assert.Equal(t, 720, first.ParaProp.Tabs.Tab[0].Position)
+	assert.Equal(t, 900, abstract.Level(1).ParaProp.Tabs.Tab[0].Position)
+	assert.Equal(t, "Arial", abstract.Level(2).RunProp.Fonts.Ascii)
+
+	numID := rd.NewListInstance(outline.ID())
+	labels := newListLabeler(rd)
+	var got []string
+	for _, level := range []int{0, 1, 1, 0, 1, 2} {
+		p := rd.AddParagraph("item")
+		p.Numbering(numID, level)
+		got = append(got, labels.label(p.GetCT()))
+	}
+	assert.Equal(t, []string{"III.", "3.1", "3.2", "IV.", "4.1", "–"}, got)
+
+	assert.ErrorContains(t, rd.DefineList().Level(9).Start(1).Err(), "level 9 out of range")
+	assert.ErrorContains(t, rd.DefineList().Level(0).Text("%1.%2").Err(), `level text "%1.%2" refers to the number of level 1`)
+	assert.ErrorContains(t, rd.DefineList().Level(0).Start(-1).Err(), "negative start value")
+}
+
+func TestDefineListPictureBullet(t *testing.T) {
+	rd := setupRootDoc(t)
+	var img bytes.Buffer
+	require.NoError(t, png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 2, 2))))
+
+	list := rd.DefineList()
+	list.Level(0).PictureBullet(img.Bytes(), 9)
+	require.NoError(t, list.Err())
+
+	level := list.Definition().Level(0)
+	require.NotNil(t, level.PicBulletID)
+	assert.Equal(t, stypes.NumFmtBullet, level.NumFmt.Val)
+
+	numbering := savedNumbering(t, rd)
+	assert.Contains(t, numbering, `<w:numPicBullet w:numPicBulletId="0"><w:pict`)
+	assert.Contains(t, numbering, `style="width:9pt;height:9pt" o:bullet="t"><v:imagedata r:id="rId1"`)
+	assert.Contains(t, numbering, `<w:lvlPicBulletId w:val="0"></w:lvlPicBulletId>`)
+
+	rels, ok := rd.FileMap.Load(numberingRelsPath)
+	require.True(t, ok)
+	assert.Contains(t, string(rels.([]byte)), `Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="media/image2.png"`)
+	_, ok = rd.FileMap.Load("word/media/image2.png")
+	assert.True(t, ok)
+
+	assert.ErrorContains(t, rd.DefineList().Level(0).PictureBullet([]byte("not an image"), 9).Err(), "picture bullet")
+}
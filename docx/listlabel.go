@@ -0,0 +1,272 @@
+package docx
+
+import (
+	"strconv"
+	"strings"
+
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+)
+
+// listLabeler renders the labels of list paragraphs, counting items as it goes.
+// Paragraphs must be passed in document order.
+type listLabeler struct {
+	rd        *RootDoc
+	levels    map[int]map[int]*ctypes.Level // abstractNumId -> level -> definition
+	abstracts map[int]int                   // numId -> abstractNumId
+	overrides map[int]map[int]int           // numId -> level -> start override
+	counters  map[string]map[int]int        // counter key -> level -> current value
+}
+
+func newListLabeler(rd *RootDoc) *listLabeler {
+	l := &listLabeler{
+		rd:        rd,
+		levels:    map[int]map[int]*ctypes.Level{},
+		abstracts: map[int]int{},
+		overrides: map[int]map[int]int{},
+		counters:  map[string]map[int]int{},
+	}
+	if rd.Numbering == nil {
+		return l
+	}
+	defs := rd.Numbering.definitions()
+	if defs == nil {
+		return l
+	}
+	for _, a := range defs.AbstractNums {
+		l.levels[a.ID] = map[int]*ctypes.Level{}
+		for _, lvl := range a.Levels {
+			l.levels[a.ID][lvl.ILvl] = lvl
+		}
+	}
+	for _, n := range defs.Nums {
+		l.abstracts[n.ID] = n.AbstractNumID.Val
+		for _, o := range n.LvlOverrides {
+			if o.StartOverride == nil {
+				continue
+			}
+			if l.overrides[n.ID] == nil {
+				l.overrides[n.ID] = map[int]int{}
+			}
+			l.overrides[n.ID][o.ILvl] = o.StartOverride.Val
+		}
+	}
+	return l
+}
+
+// listItem describes a list paragraph at the time it is counted.
+type listItem struct {
+	numID  int    // Numbering instance of the item
+	level  int    // Level of the item, 0 being the outermost level
+	bullet bool   // Whether the level uses bullets rather than numbers
+	value  int    // Counter value of the item at its level
+	format string // Numbering format of the level, "" when the level definition is unknown
+	label  string // Rendered label, "" when the level definition is unknown
+}
+
+// label returns the list label of the paragraph, or "" if the paragraph is not a list item.
+func (l *listLabeler) label(p *ctypes.Paragraph) string {
+	item, _ := l.item(p)
+	return item.label
+}
+
+// item counts the paragraph if it is a list item and describes it.
+func (l *listLabeler) item(p *ctypes.Paragraph) (listItem, bool) {
+	numID, ilvl, ok := l.numbering(p)
+	if !ok || numID == 0 {
+		return listItem{}, false
+	}
+	item := listItem{numID: numID, level: ilvl, bullet: true}
+	absID, ok := l.abstracts[numID]
+	if !ok {
+		return item, true
+	}
+	levels := l.levels[absID]
+
+	// Instances restarting their numbering count on their own, otherwise they share the abstract counters
+	key := "a" + strconv.Itoa(absID)
+	if _, ok := l.overrides[numID]; ok {
+		key = "n" + strconv.Itoa(numID)
+	}
+	counters := l.counters[key]
+	if counters == nil {
+		counters = map[int]int{}
+		l.counters[key] = counters
+	}
+
+	if _, started := counters[ilvl]; started {
+		counters[ilvl]++
+	} else {
+		counters[ilvl] = l.start(numID, levels, ilvl)
+	}
+	for lvl := range counters {
+		if lvl > ilvl {
+			delete(counters, lvl)
+		}
+	}
+	item.value = counters[ilvl]
+
+	def, ok := levels[ilvl]
+	if ok && def.NumFmt != nil {
+		item.format = string(def.NumFmt.Val)
+	}
+	if !ok || def.LvlText == nil {
+		return item, true
+	}
+	if def.NumFmt != nil && def.NumFmt.Val == stypes.NumFmtBullet {
+		var font string
+		if def.RunProp != nil && def.RunProp.Fonts != nil {
+			font = def.RunProp.Fonts.Ascii
+		}
+		item.label = bulletLabel(def.LvlText.Val, font)
+		return item, true
+	}
+	item.bullet = false
+
+	text := def.LvlText.Val
+	for lvl := 0; lvl <= ilvl; lvl++ {
+		placeholder := "%" + strconv.Itoa(lvl+1)
+		if !strings.Contains(text, placeholder) {
+			continue
+		}
+		value, ok := counters[lvl]
+		if !ok {
+			value = l.start(numID, levels, lvl)
+		}
+		// Legal numbering shows the numbers of all the levels as decimal numbers
+		format := "decimal"
+		if d, ok := levels[lvl]; ok && d.NumFmt != nil && !def.IsLgl.Bool() {
+			format = string(d.NumFmt.Val)
+		}
+		text = strings.ReplaceAll(text, placeholder, formatListNumber(value, format))
+	}
+	item.label = text
+	return item, true
+}
+
+// numbering returns the numbering instance and level of the paragraph, which may come from its style.
+func (l *listLabeler) numbering(p *ctypes.Paragraph) (numID, ilvl int, ok bool) {
+	if p.Property != nil && p.Property.NumProp != nil && p.Property.NumProp.NumID != nil {
+		np := p.Property.NumProp
+		if np.ILvl != nil {
+			ilvl = np.ILvl.Val
+		}
+		return np.NumID.Val, ilvl, true
+	}
+	if p.Property == nil || p.Property.Style == nil {
+		return 0, 0, false
+	}
+
+	// Follow the style hierarchy, guarding against loops
+	styleID := p.Property.Style.Val
+	for range 10 {
+		style := l.rd.GetStyleByID(styleID, stypes.StyleTypeParagraph)
+		if style == nil {
+			break
+		}
+		if style.ParaProp != nil && style.ParaProp.NumProp != nil && style.ParaProp.NumProp.NumID != nil {
+			np := style.ParaProp.NumProp
+			if np.ILvl != nil {
+				ilvl = np.ILvl.Val
+			}
+			return np.NumID.Val, ilvl, true
+		}
+		if style.BasedOn == nil {
+			break
+		}
+		styleID = style.BasedOn.Val
+	}
+	return 0, 0, false
+}
+
+func (l *listLabeler) start(numID int, levels map[int]*ctypes.Level, ilvl int) int {
+	if start, ok := l.overrides[numID][ilvl]; ok {
+		return start
+	}
+	if def, ok := levels[ilvl]; ok && def.Start != nil {
+		return def.Start.Val
+	}
+	return 1
+}
+
+// bulletLabel maps bullet glyphs drawn from symbol fonts to their Unicode equivalent, a
+// bullet for the glyphs it cannot map.
+func bulletLabel(glyph, font string) string {
+	var sb strings.Builder
+	for _, r := range glyph {
+		char, ok := symbolFontChar(font, r)
+		if !ok {
+			char = '•'
+		}
+		sb.WriteRune(char)
+	}
+	return sb.String()
+}
+
+// formatListNumber renders a list counter in the given numbering format.
+func formatListNumber(value int, format string) string {
+	switch stypes.NumFmt(format) {
+	case stypes.NumFmtNone:
+		return ""
+	case stypes.NumFmtDecimalZero:
+		if value < 10 {
+			return "0" + strconv.Itoa(value)
+		}
+	case stypes.NumFmtLowerLetter:
+		return strings.ToLower(letterNumber(value))
+	case stypes.NumFmtUpperLetter:
+		return letterNumber(value)
+	case stypes.NumFmtLowerRoman:
+		return strings.ToLower(romanNumber(value))
+	case stypes.NumFmtUpperRoman:
+		return romanNumber(value)
+	case stypes.NumFmtOrdinal:
+		return strconv.Itoa(value) + ordinalSuffix(value)
+	}
+	return strconv.Itoa(value)
+}
+
+// letterNumber renders 1..26 as A..Z, then AA..ZZ and so on as Word does.
+func letterNumber(value int) string {
+	if value <= 0 {
+		return strconv.Itoa(value)
+	}
+	letter := string(rune('A' + (value-1)%26))
+	return strings.Repeat(letter, (value-1)/26+1)
+}
+
+func romanNumber(value int) string {
+	if value <= 0 || value >= 4000 {
+		return strconv.Itoa(value)
+	}
+	numerals := []struct {
+		value  int
+		symbol string
+	}{
+		{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"}, {100, "C"}, {90, "XC"},
+		{50, "L"}, {40, "XL"}, {10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+	}
+	var sb strings.Builder
+	for _, n := range numerals {
+		for value >= n.value {
+			sb.WriteString(n.symbol)
+			value -= n.value
+		}
+	}
+	return sb.String()
+}
+
+func ordinalSuffix(value int) string {
+	if value%100 >= 11 && value%100 <= 13 {
+		return "th"
+	}
+	switch value % 10 {
+	case 1:
+		return "st"
+	case 2:
+		return "nd"
+	case 3:
+		return "rd"
+	}
+	return "th"
+}
@@ -0,0 +1,420 @@
+package docx
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"godocx/dml"
+	"godocx/wml/ctypes"
+)
+
+// MarkdownOptions configures the Markdown export of RootDoc.ToMarkdown.
+type MarkdownOptions struct {
+	// ImageDir is the directory the images of the document are extracted to, it is created
+	// if needed. Images are left out of the Markdown when ImageDir is empty.
+	ImageDir string
+
+	// ImageURL is the prefix of the image links written in the Markdown, e.g. "/assets/doc1".
+	// It defaults to ImageDir.
+	ImageURL string
+}
+
+// ToMarkdown writes the document body as GitHub flavoured Markdown.
+//
+// Heading styles become "#" headings, list paragraphs become bullet or numbered items nested
+// by their level, bold, italic and struck through runs are emphasised, hyperlinks are resolved
+// through the document relationships and tables are written as GFM tables, the first row
+// being the header row. Images are extracted to opts.ImageDir and linked.
+//
+// Example:
+//
+//	f, _ := os.Create("page.md")
+//	defer f.Close()
+//	err := document.ToMarkdown(f, &docx.MarkdownOptions{ImageDir: "images"})
+func (rd *RootDoc) ToMarkdown(w io.Writer, opts *MarkdownOptions) error {
+	if opts == nil {
+		opts = &MarkdownOptions{}
+	}
+
+	mw := &mdWriter{
+		rd:     rd,
+		opts:   opts,
+		labels: newListLabeler(rd),
+		images: map[string]string{},
+	}
+	if rd.Document != nil && rd.Document.Body != nil {
+		mw.writeBlocks(rd.Document.Body.Children)
+	}
+	if mw.err != nil {
+		return mw.err
+	}
+	_, err := io.WriteString(w, mw.sb.String())
+	return err
+}
+
+// mdWriter accumulates the Markdown of the document body.
+type mdWriter struct {
+	rd      *RootDoc
+	opts    *MarkdownOptions
+	labels  *listLabeler
+	images  map[string]string // package path of extracted images -> link
+	cellPos *cellPosition     // cell being written, for the conditional formatting of its table
+
+	sb       strings.Builder
+	lastList bool // whether the last block written was a list item
+	err      error
+}
+
+// mdSpan is a piece of inline content sharing the same formatting.
+type mdSpan struct {
+	text                 string
+	raw                  bool // text is Markdown already, e.g. an image
+	bold, italic, strike bool
+	link                 string
+}
+
+func (mw *mdWriter) writeBlocks(children []DocumentChild) {
+	for _, child := range children {
+		if child.Para != nil {
+			mw.writePara(child.Para.GetCT())
+		}
+		if child.Table != nil {
+			mw.writeTable(child.Table.GetCT())
+		}
+	}
+}
+
+// startBlock separates blocks by a blank line, list items following each other excepted.
+func (mw *mdWriter) startBlock(listItem bool) {
+	if mw.sb.Len() > 0 && !(listItem && mw.lastList) {
+		mw.sb.WriteString("\n")
+	}
+	mw.lastList = listItem
+}
+
+func (mw *mdWriter) writePara(p *ctypes.Paragraph) {
+	item, isItem := mw.labels.item(p)
+	text := trimMdBreaks(mw.inline(p, false))
+
+	if level := mw.rd.headingLevel(p); level >= 0 && text != "" {
+		mw.startBlock(false)
+		mw.sb.WriteString(strings.Repeat("#", max(level, 1)))
+		mw.sb.WriteString(" ")
+		mw.sb.WriteString(strings.ReplaceAll(text, "\\\n", " "))
+		mw.sb.WriteString("\n")
+		return
+	}
+
+	if isItem {
+		marker := "-"
+		if !item.bullet {
+			marker = fmt.Sprintf("%d.", max(item.value, 0))
+		}
+		indent := strings.Repeat("    ", item.level)
+		mw.startBlock(true)
+		mw.sb.WriteString(indent)
+		mw.sb.WriteString(marker)
+		mw.sb.WriteString(" ")
+		mw.sb.WriteString(strings.ReplaceAll(text, "\n", "\n"+indent+strings.Repeat(" ", len(marker)+1)))
+		mw.sb.WriteString("\n")
+		return
+	}
+
+	if text == "" {
+		return
+	}
+	mw.startBlock(false)
+	mw.sb.WriteString(escapeMdBlockStart(text))
+	mw.sb.WriteString("\n")
+}
+
+// writeTable writes a GFM table, the first row being the header row.
+func (mw *mdWriter) writeTable(t *ctypes.Table) {
+	var rows [][]string
+	cols := 0
+	for _, rc := range t.RowContents {
+		if rc.Row == nil {
+			continue
+		}
+		var cells []string
+		for _, cc := range rc.Row.Contents {
+			if cc.Cell == nil {
+				continue
+			}
+			mw.cellPos = cellPositionOf(t, rc.Row, cc.Cell)
+			cells = append(cells, mw.cell(cc.Cell))
+			mw.cellPos = nil
+			if cc.Cell.Property != nil && cc.Cell.Property.GridSpan != nil {
+				for i := 1; i < cc.Cell.Property.GridSpan.Val; i++ {
+					cells = append(cells, "")
+				}
+			}
+		}
+		rows = append(rows, cells)
+		cols = max(cols, len(cells))
+	}
+	if len(rows) == 0 || cols == 0 {
+		return
+	}
+
+	mw.startBlock(false)
+	for i, cells := range rows {
+		for len(cells) < cols {
+			cells = append(cells, "")
+		}
+		mw.sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+		if i == 0 {
+			mw.sb.WriteString("|" + strings.Repeat(" --- |", cols) + "\n")
+		}
+	}
+}
+
+// cell renders the content of a table cell on a single line.
+func (mw *mdWriter) cell(c *ctypes.Cell) string {
+	var lines []string
+	for _, block := range c.Contents {
+		if block.Paragraph != nil {
+			if text := strings.TrimSpace(mw.inline(block.Paragraph, true)); text != "" {
+				lines = append(lines, text)
+			}
+		}
+		if block.Table != nil {
+			// GFM tables cannot be nested, keep the text of the nested table
+			tw := textWriter{}
+			tw.writeTable(block.Table)
+			text := strings.ReplaceAll(strings.TrimSpace(tw.sb.String()), "\t", " ")
+			lines = append(lines, strings.ReplaceAll(escapeMd(text, true), "\n", "<br>"))
+		}
+	}
+	return strings.Join(lines, "<br>")
+}
+
+// inline renders the runs and hyperlinks of the paragraph, with the tracked insertions and
+// without the tracked deletions. Line breaks are rendered as "<br>"
+// within tables and as a backslash hard line break otherwise. The emphasis of a heading
+// is written against the run formatting of its style, which the heading marker stands for.
+func (mw *mdWriter) inline(p *ctypes.Paragraph, inTable bool) string {
+	base := &ctypes.RunProperty{}
+	if mw.rd.headingLevel(p) >= 0 {
+		base = mw.rd.paraRunProp(p, mw.cellPos)
+	}
+
+	var spans []mdSpan
+	for _, child := range p.Children {
+		if child.Run != nil {
+			spans = append(spans, mw.runSpans(p, base, child.Run, "")...)
+		}
+		if child.Ins != nil {
+			for _, r := range child.Ins.Runs {
+				spans = append(spans, mw.runSpans(p, base, r, "")...)
+			}
+		}
+		if child.Link != nil {
+			link := ""
+			if child.Link.Anchor != "" {
+				link = "#" + child.Link.Anchor
+			}
+			if child.Link.ID != "" {
+				if rel := mw.rd.Document.relationship(child.Link.ID); rel != nil {
+					link = rel.Target
+				}
+			}
+			for _, r := range child.Link.Runs() {
+				spans = append(spans, mw.runSpans(p, base, r, link)...)
+			}
+		}
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(spans); {
+		// Group the spans of a hyperlink
+		j := i + 1
+		for j < len(spans) && spans[j].link == spans[i].link {
+			j++
+		}
+		text := renderMdSpans(spans[i:j], inTable)
+		if spans[i].link != "" && strings.TrimSpace(text) != "" {
+			text = "[" + text + "](" + strings.ReplaceAll(spans[i].link, " ", "%20") + ")"
+		}
+		sb.WriteString(text)
+		i = j
+	}
+	return sb.String()
+}
+
+// runSpans splits the content of a run into text and image spans, emphasized where the
+// resolved formatting of the run adds bold, italic or strikethrough to base.
+func (mw *mdWriter) runSpans(p *ctypes.Paragraph, base *ctypes.RunProperty, r *ctypes.Run, link string) []mdSpan {
+	rp := mw.rd.resolvedRunProp(p, mw.cellPos, r)
+	bold := rp.Bold.Bool() && !base.Bold.Bool()
+	italic := rp.Italic.Bool() && !base.Italic.Bool()
+	strike := (rp.Strike.Bool() || rp.DoubleStrike.Bool()) && !(base.Strike.Bool() || base.DoubleStrike.Bool())
+	var spans []mdSpan
+	for _, child := range r.Children {
+		if child.Drawing == nil {
+			text := runText(&ctypes.Run{Children: []ctypes.RunChild{child}})
+			if text != "" {
+				spans = append(spans, mdSpan{text: text, bold: bold, italic: italic, strike: strike, link: link})
+			}
+			continue
+		}
+
+		for _, inline := range child.Drawing.Inline {
+			spans = append(spans, mw.imageSpan(inline.Graphic, inline.DocProp, link)...)
+		}
+		for _, anchor := range child.Drawing.Anchor {
+			spans = append(spans, mw.imageSpan(anchor.Graphic, anchor.DocProp, link)...)
+		}
+	}
+	return spans
+}
+
+func (mw *mdWriter) imageSpan(graphic dml.Graphic, docProp dml.DocProp, link string) []mdSpan {
+	if graphic.Data == nil || graphic.Data.Pic == nil || graphic.Data.Pic.BlipFill.Blip == nil {
+		return nil
+	}
+	src := mw.image(graphic.Data.Pic.BlipFill.Blip.EmbedID)
+	if src == "" {
+		return nil
+	}
+	alt := docProp.Description
+	if alt == "" {
+		alt = docProp.Name
+	}
+	return []mdSpan{{text: "![" + escapeMd(alt, true) + "](" + src + ")", raw: true, link: link}}
+}
+
+// image extracts the image with the given relationship ID and returns its link, or "" if the
+// image is not extracted.
+func (mw *mdWriter) image(rID string) string {
+	if mw.opts.ImageDir == "" {
+		return ""
+	}
+	partPath, content, ok := mw.rd.imagePart(rID)
+	if !ok {
+		return ""
+	}
+	if link, ok := mw.images[partPath]; ok {
+		return link
+	}
+
+	link, err := extractImage(partPath, content, mw.opts.ImageDir, mw.opts.ImageURL)
+	if err != nil {
+		mw.err = err
+		return ""
+	}
+	mw.images[partPath] = link
+	return link
+}
+
+// trimMdBreaks trims the spaces and hard line breaks surrounding the Markdown of a paragraph.
+func trimMdBreaks(text string) string {
+	for {
+		trimmed := strings.Trim(text, " \t")
+		trimmed = strings.TrimPrefix(trimmed, "\\\n")
+		if strings.HasSuffix(trimmed, "\\\n") && !strings.HasSuffix(trimmed, "\\\\\n") {
+			trimmed = strings.TrimSuffix(trimmed, "\\\n")
+		}
+		if trimmed == text {
+			return strings.TrimSpace(text)
+		}
+		text = trimmed
+	}
+}
+
+// renderMdSpans merges the spans sharing the same formatting and emphasises them.
+func renderMdSpans(spans []mdSpan, inTable bool) string {
+	var sb strings.Builder
+	for i := 0; i < len(spans); {
+		span := spans[i]
+		if span.raw {
+			sb.WriteString(span.text)
+			i++
+			continue
+		}
+
+		text := span.text
+		j := i + 1
+		for ; j < len(spans) && !spans[j].raw && spans[j].bold == span.bold &&
+			spans[j].italic == span.italic && spans[j].strike == span.strike; j++ {
+			text += spans[j].text
+		}
+		i = j
+
+		text = escapeMd(text, inTable)
+		if inTable {
+			text = strings.ReplaceAll(text, "\n", "<br>")
+		} else {
+			text = strings.ReplaceAll(text, "\n", "\\\n")
+		}
+
+		// Emphasis markers must be next to non space characters
+		body := strings.TrimSpace(text)
+		if body == "" {
+			sb.WriteString(text)
+			continue
+		}
+		lead := text[:strings.Index(text, body)]
+		trail := text[len(lead)+len(body):]
+
+		marker := ""
+		if span.bold {
+			marker += "**"
+		}
+		if span.italic {
+			marker += "*"
+		}
+		if span.strike {
+			marker += "~~"
+		}
+		sb.WriteString(lead + marker + body + reverseMdMarker(marker) + trail)
+	}
+	return sb.String()
+}
+
+// reverseMdMarker returns the closing sequence of opening emphasis markers.
+func reverseMdMarker(marker string) string {
+	var parts []string
+	for _, m := range []string{"**", "*", "~~"} {
+		if strings.HasPrefix(marker, m) {
+			parts = append([]string{m}, parts...)
+			marker = strings.TrimPrefix(marker, m)
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// escapeMd escapes the characters having a meaning in inline Markdown.
+func escapeMd(text string, inTable bool) string {
+	replacer := mdEscaper
+	if inTable {
+		replacer = mdTableEscaper
+	}
+	return replacer.Replace(text)
+}
+
+var (
+	mdEscapes = []string{`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`, `<`, `\<`, `>`, `\>`, `~`, `\~`}
+
+	mdEscaper      = strings.NewReplacer(mdEscapes...)
+	mdTableEscaper = strings.NewReplacer(append(mdEscapes, `|`, `\|`)...)
+)
+
+// escapeMdBlockStart escapes the start of a paragraph that would otherwise read as a heading,
+// a list item or a thematic break.
+func escapeMdBlockStart(text string) string {
+	switch {
+	case strings.HasPrefix(text, "#"), strings.HasPrefix(text, "-"), strings.HasPrefix(text, "+"), strings.HasPrefix(text, "="):
+		return `\` + text
+	}
+
+	digits := 0
+	for digits < len(text) && text[digits] >= '0' && text[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && digits < len(text) && (text[digits] == '.' || text[digits] == ')') {
+		return text[:digits] + `\` + text[digits:]
+	}
+	return text
+}
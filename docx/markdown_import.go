@@ -0,0 +1,371 @@
+package docx
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"godocx/common/constants"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+)
+
+// MarkdownImportOptions configures RootDoc.AppendMarkdown.
+type MarkdownImportOptions struct {
+	// BaseDir is the directory relative image paths are resolved against, it defaults to the
+	// current directory. Images are only read from within BaseDir: absolute paths and paths
+	// leading out of it are rejected.
+	BaseDir string
+
+	// CodeFont is the font of code spans and code blocks, "Courier New" by default.
+	CodeFont string
+
+	// TableStyle is the ID or name of the style of the tables, "TableGrid" by default.
+	TableStyle string
+}
+
+// AppendMarkdown converts CommonMark and appends it to the end of the document body.
+//
+// Headings use the HeadingN styles, block quotes the Quote style and lists are numbered through
+// the NumberingManager, nested lists becoming deeper levels of their parent list. Emphasis,
+// strikethrough (~~text~~), code spans and blocks, hard line breaks, inline, reference and
+// autolinks, thematic breaks and GitHub flavoured tables are supported. Images must be local
+// files; images from URLs are written as links. Styles are found by their built-in name, such
+// as "heading 1", in templates with localised style IDs; styles missing from the document
+// template are not applied.
+//
+// Example:
+//
+//	notes, _ := os.ReadFile("release-notes.md")
+//	err := document.AppendMarkdown(notes, &docx.MarkdownImportOptions{BaseDir: "docs"})
+func (rd *RootDoc) AppendMarkdown(src []byte, opts *MarkdownImportOptions) error {
+	if opts == nil {
+		opts = &MarkdownImportOptions{}
+	}
+
+	mb := &mdBuilder{
+		rd:       rd,
+		parser:   newMdParser(),
+		baseDir:  opts.BaseDir,
+		codeFont: opts.CodeFont,
+	}
+	if mb.codeFont == "" {
+		mb.codeFont = "Courier New"
+	}
+	mb.tableStyle = opts.TableStyle
+	if mb.tableStyle == "" {
+		mb.tableStyle = "TableGrid"
+	}
+
+	blocks := mb.parser.parse(string(src))
+	return mb.writeBlocks(blocks, mdContext{level: -1})
+}
+
+// mdBuilder appends parsed Markdown blocks to the document.
+type mdBuilder struct {
+	rd         *RootDoc
+	parser     *mdParser
+	baseDir    string
+	codeFont   string
+	tableStyle string
+}
+
+// mdContext is the container of a block: block quotes and list items.
+type mdContext struct {
+	quote   int  // Block quote depth
+	numID   int  // Numbering instance of the enclosing list
+	level   int  // Level of the enclosing list, -1 outside lists
+	ordered bool // Whether the enclosing list is numbered
+}
+
+// mdListItemIndent is the indentation in twips of each list level and block quote depth.
+const mdListItemIndent = 720
+
+func (mb *mdBuilder) writeBlocks(blocks []*mdBlock, ctx mdContext) error {
+	for _, b := range blocks {
+		if err := mb.writeBlock(b, ctx, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeBlock appends a block. When numbered is set the block is the first of a list item.
+func (mb *mdBuilder) writeBlock(b *mdBlock, ctx mdContext, numbered bool) error {
+	switch b.kind {
+	case mdParagraph:
+		p := mb.newPara(ctx, numbered)
+		return mb.writeInline(p, mb.parser.inline(b.text, mdInlineStyle{}), false)
+
+	case mdHeading:
+		p := mb.newPara(ctx, numbered)
+		mb.applyStyle(p, fmt.Sprintf("heading %d", b.level))
+		return mb.writeInline(p, mb.parser.inline(b.text, mdInlineStyle{}), false)
+
+	case mdCode:
+		p := mb.newPara(ctx, numbered)
+		for i, line := range strings.Split(b.text, "\n") {
+			run := p.AddText(line).Font(mb.codeFont)
+			if i > 0 {
+				run.ct.Children = append([]ctypes.RunChild{{Break: &ctypes.Break{}}}, run.ct.Children...)
+			}
+		}
+
+	case mdRule:
+		p := mb.newPara(ctx, numbered)
+		size, space := 6, "1"
+		p.GetCT().Property.Border = &ctypes.ParaBorder{
+			Bottom: &ctypes.Border{Val: stypes.BorderStyleSingle, Size: &size, Space: &space},
+		}
+
+	case mdQuote:
+		inner := ctx
+		inner.quote++
+		if numbered && (len(b.children) == 0 || b.children[0].kind == mdList) {
+			mb.newPara(ctx, true)
+			numbered = false
+		}
+		for i, child := range b.children {
+			if err := mb.writeBlock(child, inner, numbered && i == 0); err != nil {
+				return err
+			}
+		}
+
+	case mdList:
+		if numbered {
+			mb.newPara(ctx, true)
+		}
+		return mb.writeList(b, ctx)
+
+	case mdTable:
+		if numbered {
+			mb.newPara(ctx, true)
+		}
+		return mb.writeTable(b)
+	}
+	return nil
+}
+
+// writeList appends the items of a list. A nested list of the same kind continues the
+// numbering instance of its parent at the next level.
+func (mb *mdBuilder) writeList(b *mdBlock, ctx mdContext) error {
+	inner := ctx
+	inner.level++
+	inner.ordered = b.ordered
+	if ctx.numID == 0 || ctx.ordered != b.ordered {
+		abstractNumID := BulletListID
+		if b.ordered {
+			abstractNumID = DecimalListID
+		}
+		inner.numID = mb.rd.NewListInstance(abstractNumID)
+	}
+
+	for _, item := range b.items {
+		if len(item) == 0 {
+			mb.newPara(inner, true)
+			continue
+		}
+		for i, block := range item {
+			if err := mb.writeBlock(block, inner, i == 0); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeTable appends a table, the cells of the header row being bold.
+func (mb *mdBuilder) writeTable(b *mdBlock) error {
+	tbl := mb.rd.AddTable()
+	if style := mb.rd.lookupStyle(mb.tableStyle, stypes.StyleTypeTable); style != nil {
+		tbl.Style(*style.ID)
+	}
+
+	for r, cells := range b.rows {
+		row := tbl.AddRow()
+		for c := range b.align {
+			p := row.AddCell().AddEmptyPara()
+			switch b.align[c] {
+			case "left":
+				p.Justification(stypes.JustificationLeft)
+			case "center":
+				p.Justification(stypes.JustificationCenter)
+			case "right":
+				p.Justification(stypes.JustificationRight)
+			}
+			if c >= len(cells) {
+				continue
+			}
+			if err := mb.writeInline(p, mb.parser.inline(cells[c], mdInlineStyle{}), r == 0); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// newPara appends a paragraph to the body, formatted for its container.
+func (mb *mdBuilder) newPara(ctx mdContext, numbered bool) *Paragraph {
+	p := newParagraph(mb.rd)
+	p.ensureProp()
+	mb.rd.Document.Body.Children = append(mb.rd.Document.Body.Children, DocumentChild{Para: p})
+
+	indent := 0
+	if ctx.level >= 0 {
+		mb.applyStyle(p, "List Paragraph")
+		if numbered {
+			p.Numbering(ctx.numID, ctx.level)
+		} else {
+			// Later paragraphs of a list item are aligned with its first paragraph
+			indent = mdListItemIndent * (ctx.level + 1)
+		}
+	}
+	if ctx.quote > 0 {
+		mb.applyStyle(p, "Quote")
+		indent += mdListItemIndent * ctx.quote
+	}
+	if indent > 0 {
+		p.Indent(&ctypes.Indent{Left: &indent})
+	}
+	return p
+}
+
+// applyStyle sets the paragraph style with the given built-in name when the document defines it.
+func (mb *mdBuilder) applyStyle(p *Paragraph, name string) {
+	if style := mb.rd.lookupStyle(name, stypes.StyleTypeParagraph); style != nil {
+		p.Style(*style.ID)
+	}
+}
+
+// writeInline appends inline content to the paragraph, hyperlinks grouping the runs sharing a destination.
+func (mb *mdBuilder) writeInline(p *Paragraph, inlines []mdInline, bold bool) error {
+	for i, in := range inlines {
+		// Images from URLs are not embedded, their alternative text links to them instead
+		if in.image != "" && isMdURL(in.image) {
+			if in.link == "" {
+				inlines[i].link = in.image
+			}
+			if in.text == "" {
+				inlines[i].text = in.image
+			}
+			inlines[i].image = ""
+		}
+	}
+
+	for i := 0; i < len(inlines); {
+		link := inlines[i].link
+		j := i + 1
+		for j < len(inlines) && inlines[j].link == link {
+			j++
+		}
+
+		var runs []*ctypes.Run
+		for _, in := range inlines[i:j] {
+			run, err := mb.inlineRun(in, bold)
+			if err != nil {
+				return err
+			}
+			runs = append(runs, run)
+		}
+		i = j
+
+		if link == "" {
+			for _, run := range runs {
+				p.GetCT().Children = append(p.GetCT().Children, ctypes.ParagraphChild{Run: run})
+			}
+			continue
+		}
+
+		hyperlink := &ctypes.Hyperlink{}
+		if anchor, ok := strings.CutPrefix(link, "#"); ok {
+			hyperlink.Anchor = anchor
+		} else {
+			hyperlink.ID = mb.rd.Document.addLinkRelation(link)
+		}
+		for _, run := range runs {
+			if run.Property == nil {
+				run.Property = &ctypes.RunProperty{}
+			}
+			run.Property.Style = ctypes.NewRunStyle(constants.HyperLinkStyle)
+			hyperlink.Children = append(hyperlink.Children, ctypes.ParagraphChild{Run: run})
+		}
+		p.GetCT().Children = append(p.GetCT().Children, ctypes.ParagraphChild{Link: hyperlink})
+	}
+	return nil
+}
+
+// inlineRun creates the run of a piece of inline content.
+func (mb *mdBuilder) inlineRun(in mdInline, bold bool) (*ctypes.Run, error) {
+	if in.image != "" {
+		return mb.imageRun(in)
+	}
+
+	ct := &ctypes.Run{}
+	run := newRun(mb.rd, nil, ct)
+	if in.brk {
+		ct.Children = append(ct.Children, ctypes.RunChild{Break: &ctypes.Break{}})
+		return ct, nil
+	}
+	ct.Children = append(ct.Children, ctypes.RunChild{Text: ctypes.TextFromString(in.text)})
+	if in.bold || bold {
+		run.Bold(true)
+	}
+	if in.italic {
+		run.Italic(true)
+	}
+	if in.strike {
+		run.Strike(true)
+	}
+	if in.code {
+		run.Font(mb.codeFont)
+	}
+	return ct, nil
+}
+
+// imageRun reads a local image and creates a run holding it at its natural size, scaled down
+// to the width of the page.
+func (mb *mdBuilder) imageRun(in mdInline) (*ctypes.Run, error) {
+	imgBytes, err := readLocalImage(mb.baseDir, in.image)
+	if err != nil {
+		return nil, fmt.Errorf("markdown image: %w", err)
+	}
+
+	width, height := mb.rd.fittedImageSize(imgBytes, 0, 0)
+	run, inline, err := mb.rd.newImageRun(imgBytes, width, height)
+	if err != nil {
+		return nil, fmt.Errorf("markdown image %s: %w", in.image, err)
+	}
+	inline.DocProp.Description = in.text
+	return run, nil
+}
+
+// readLocalImage reads an image given by a path or file URL relative to the base directory, the
+// current directory if empty. Absolute paths and paths leading out of the base directory are
+// rejected, so that the converted text cannot embed other files of the system.
+func readLocalImage(baseDir, src string) ([]byte, error) {
+	path := src
+	if unescaped, err := url.PathUnescape(strings.TrimPrefix(path, "file://")); err == nil {
+		path = unescaped
+	}
+	if filepath.IsAbs(path) || strings.HasPrefix(path, "/") || filepath.VolumeName(path) != "" {
+		return nil, fmt.Errorf("absolute image path %q", src)
+	}
+	if baseDir == "" {
+		baseDir = "."
+	}
+	root, err := os.OpenRoot(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+	return root.ReadFile(filepath.FromSlash(path))
+}
+
+// isMdURL reports whether the link destination is a URL rather than a local path.
+// Single letter schemes are Windows drive letters.
+func isMdURL(dest string) bool {
+	u, err := url.Parse(dest)
+	return err == nil && len(u.Scheme) > 1 && u.Scheme != "file"
+}
@@ -0,0 +1,136 @@
+package docx
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"godocx/internal"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const releaseNotes = "# Release 1.2\n" +
+	"\n" +
+	"Some **bold**, *italic*, ~~gone~~ and `code` with a [link](https://example.com \"Example\").\n" +
+	"\n" +
+	"- one\n" +
+	"- two\n" +
+	"  1. sub a\n" +
+	"  2. sub b\n" +
+	"- three\n" +
+	"\n" +
+	"> quoted *text*\n" +
+	"continued\n" +
+	"\n" +
+	"```go\n" +
+	"fmt.Println(\"hi\")\n" +
+	"  return\n" +
+	"```\n" +
+	"\n" +
+	"| Name | Qty |\n" +
+	"|:-----|----:|\n" +
+	"| a \\| b | 2 |\n" +
+	"\n" +
+	"See [the docs][ref] and <https://auto.example>.\n" +
+	"\n" +
+	"[ref]: https://ref.example\n"
+
+func TestAppendMarkdown(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.Numbering = NewNumberingManager(rd)
+	for _, id := range []string{"Heading1", "ListParagraph", "Quote"} {
+		rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, ctypes.Style{
+			ID:   internal.ToPtr(id),
+			Type: internal.ToPtr(stypes.StyleTypeParagraph),
+		})
+	}
+
+	require.NoError(t, rd.AppendMarkdown([]byte(releaseNotes), nil))
+
+	text, err := rd.Text(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Release 1.2\n"+
+		"Some bold, italic, gone and code with a link.\n"+
+		"one\ntwo\nsub a\nsub b\nthree\n"+
+		"quoted text continued\n"+
+		"fmt.Println(\"hi\")\n  return\n"+
+		"Name\tQty\n"+
+		"a | b\t2\n"+
+		"See the docs and https://auto.example.\n", text)
+
+	children := rd.Document.Body.Children
+	assert.Equal(t, "Heading1", children[0].Para.GetCT().Property.Style.Val)
+	assert.Equal(t, "Quote", children[7].Para.GetCT().Property.Style.Val)
+
+	// Nested lists of another kind get their own numbering instance
+	one, sub := children[2].Para.GetCT().Property.NumProp, children[4].Para.GetCT().Property.NumProp
+	assert.Equal(t, 0, one.ILvl.Val)
+	assert.Equal(t, 1, sub.ILvl.Val)
+	assert.NotEqual(t, one.NumID.Val, sub.NumID.Val)
+
+	var buf bytes.Buffer
+	require.NoError(t, rd.ToMarkdown(&buf, nil))
+	assert.Equal(t, "# Release 1.2\n"+
+		"\n"+
+		"Some **bold**, *italic*, ~~gone~~ and code with a [link](https://example.com).\n"+
+		"\n"+
+		"- one\n"+
+		"- two\n"+
+		"    1. sub a\n"+
+		"    2. sub b\n"+
+		"- three\n"+
+		"\n"+
+		"quoted *text* continued\n"+
+		"\n"+
+		"fmt.Println(\"hi\")\\\n  return\n"+
+		"\n"+
+		"| **Name** | **Qty** |\n"+
+		"| --- | --- |\n"+
+		"| a \\| b | 2 |\n"+
+		"\n"+
+		"See [the docs](https://ref.example) and [https://auto.example](https://auto.example).\n", buf.String())
+}
+
+func TestParseMarkdownInline(t *testing.T) {
+	mp := newMdParser()
+	inlines := mp.inline("a ***b*** _c_d_ \\*e\\* `x` f  \ng&amp;h", mdInlineStyle{})
+
+	var texts []string
+	for _, in := range inlines {
+		texts = append(texts, in.text)
+	}
+	assert.Equal(t, []string{"a ", "b", " ", "c_d", " *e* ", "x", " f", "", "g&h"}, texts)
+	assert.True(t, inlines[1].bold && inlines[1].italic)
+	assert.True(t, inlines[3].italic)
+	assert.True(t, inlines[5].code)
+	assert.True(t, inlines[7].brk)
+}
+
+func TestAppendMarkdown_Images(t *testing.T) {
+	dir := t.TempDir()
+	var img bytes.Buffer
+	require.NoError(t, png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 2, 2))))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "docs"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "docs", "logo.png"), img.Bytes(), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "secret.png"), img.Bytes(), 0o644))
+	opts := &MarkdownImportOptions{BaseDir: filepath.Join(dir, "docs")}
+
+	rd := setupRootDoc(t)
+	require.NoError(t, rd.AppendMarkdown([]byte("![logo](logo.png)\n"), opts))
+	run := rd.Document.Body.Children[0].Para.GetCT().Children[0].Run
+	require.NotNil(t, run)
+	assert.NotNil(t, run.Children[0].Drawing)
+
+	// Only the files of the base directory can be read
+	for _, src := range []string{"../secret.png", filepath.Join(dir, "secret.png"), "file:///etc/passwd"} {
+		err := rd.AppendMarkdown([]byte("![x]("+src+")\n"), opts)
+		assert.Error(t, err, src)
+	}
+}
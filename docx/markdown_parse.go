@@ -0,0 +1,702 @@
+package docx
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// mdBlockKind is the kind of a Markdown block.
+type mdBlockKind int
+
+const (
+	mdParagraph mdBlockKind = iota
+	mdHeading
+	mdCode
+	mdQuote
+	mdList
+	mdTable
+	mdRule
+)
+
+// mdBlock is a block of a parsed Markdown document.
+type mdBlock struct {
+	kind     mdBlockKind
+	level    int          // Heading level
+	text     string       // Inline source of paragraphs and headings, content of code blocks
+	children []*mdBlock   // Content of block quotes
+	ordered  bool         // Whether the list is numbered
+	items    [][]*mdBlock // Content of the list items
+	align    []string     // Alignment of the table columns: "", "left", "center" or "right"
+	rows     [][]string   // Inline source of the table cells, the first row being the header row
+}
+
+// mdInline is a piece of inline Markdown content.
+type mdInline struct {
+	mdInlineStyle
+	text  string // Text, or alternative text of an image
+	code  bool   // Code span
+	image string // Source of an image
+	brk   bool   // Hard line break
+}
+
+// mdInlineStyle is the formatting applied to inline content.
+type mdInlineStyle struct {
+	bold, italic, strike bool
+	link                 string // Destination of the enclosing link
+}
+
+var (
+	mdAtxRe     = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	mdSetextRe  = regexp.MustCompile(`^ {0,3}(=+|-+)[ \t]*$`)
+	mdRuleRe    = regexp.MustCompile(`^ {0,3}(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	mdFenceRe   = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ \t]*([^`]*)$")
+	mdListRe    = regexp.MustCompile(`^( {0,3})([-+*]|(\d{1,9})[.)])([ \t]+|$)(.*)$`)
+	mdDelimRe   = regexp.MustCompile(`^ {0,3}\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	mdRefDefRe  = regexp.MustCompile(`^ {0,3}\[([^\]]+)\]:[ \t]*<?([^\s>]+)>?(?:[ \t]+(?:"[^"]*"|'[^']*'|\([^)]*\)))?[ \t]*$`)
+	mdAutoRe    = regexp.MustCompile(`^<([A-Za-z][A-Za-z0-9+.-]{1,31}:[^\s<>]*)>`)
+	mdEmailRe   = regexp.MustCompile(`^<([A-Za-z0-9.!#$%&'*+/=?^_{|}~-]+@[A-Za-z0-9](?:[A-Za-z0-9-.]*[A-Za-z0-9])?)>`)
+	mdEntityRe  = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[A-Za-z][A-Za-z0-9]{1,31});`)
+	mdSpaceTail = regexp.MustCompile(`[ \t]+$`)
+)
+
+// mdParser parses Markdown following CommonMark and the GitHub table and strikethrough extensions.
+type mdParser struct {
+	refs map[string]string // Link reference definitions, keyed by normalised label
+}
+
+func newMdParser() *mdParser {
+	return &mdParser{refs: map[string]string{}}
+}
+
+// parse splits the source into lines and parses its blocks.
+func (mp *mdParser) parse(src string) []*mdBlock {
+	src = strings.ReplaceAll(strings.ReplaceAll(src, "\r\n", "\n"), "\r", "\n")
+	lines := strings.Split(src, "\n")
+	for i, line := range lines {
+		lines[i] = expandMdTabs(line)
+	}
+	return mp.blocks(lines)
+}
+
+// blocks parses a sequence of lines, the container prefixes (quote markers, list indentation)
+// being already removed.
+func (mp *mdParser) blocks(lines []string) []*mdBlock {
+	var blocks []*mdBlock
+	var para []string
+	flush := func() {
+		// Link reference definitions at the start of a paragraph are not content
+		for len(para) > 0 {
+			m := mdRefDefRe.FindStringSubmatch(para[0])
+			if m == nil {
+				break
+			}
+			label := normalizeMdLabel(m[1])
+			if _, ok := mp.refs[label]; !ok {
+				mp.refs[label] = unescapeMd(m[2])
+			}
+			para = para[1:]
+		}
+		if len(para) > 0 {
+			blocks = append(blocks, &mdBlock{kind: mdParagraph, text: strings.Join(para, "\n")})
+		}
+		para = nil
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		if isBlankMd(line) {
+			flush()
+			i++
+			continue
+		}
+
+		// Indented code cannot interrupt a paragraph
+		if len(para) == 0 && mdIndent(line) >= 4 {
+			var code []string
+			for ; i < len(lines) && (isBlankMd(lines[i]) || mdIndent(lines[i]) >= 4); i++ {
+				code = append(code, trimMdIndent(lines[i], 4))
+			}
+			for len(code) > 0 && isBlankMd(code[len(code)-1]) {
+				code = code[:len(code)-1]
+			}
+			blocks = append(blocks, &mdBlock{kind: mdCode, text: strings.Join(code, "\n")})
+			continue
+		}
+
+		if m := mdFenceRe.FindStringSubmatch(line); m != nil {
+			flush()
+			indent, fence := len(m[1]), m[2]
+			var code []string
+			for i++; i < len(lines); i++ {
+				trimmed := strings.TrimSpace(lines[i])
+				if mdIndent(lines[i]) < 4 && strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+					i++
+					break
+				}
+				code = append(code, trimMdIndent(lines[i], indent))
+			}
+			blocks = append(blocks, &mdBlock{kind: mdCode, text: strings.Join(code, "\n")})
+			continue
+		}
+
+		if m := mdAtxRe.FindStringSubmatch(line); m != nil {
+			flush()
+			blocks = append(blocks, &mdBlock{kind: mdHeading, level: len(m[1]), text: m[2]})
+			i++
+			continue
+		}
+
+		if len(para) > 0 {
+			if m := mdSetextRe.FindStringSubmatch(line); m != nil {
+				level := 1
+				if m[1][0] == '-' {
+					level = 2
+				}
+				blocks = append(blocks, &mdBlock{kind: mdHeading, level: level, text: strings.Join(para, "\n")})
+				para = nil
+				i++
+				continue
+			}
+		}
+
+		if mdRuleRe.MatchString(line) {
+			flush()
+			blocks = append(blocks, &mdBlock{kind: mdRule})
+			i++
+			continue
+		}
+
+		if isMdQuote(line) {
+			flush()
+			var quoted []string
+			for ; i < len(lines); i++ {
+				l := lines[i]
+				if isMdQuote(l) {
+					l = strings.TrimLeft(l, " ")[1:]
+					if strings.HasPrefix(l, " ") {
+						l = l[1:]
+					}
+					quoted = append(quoted, l)
+					continue
+				}
+				// Lazy continuation of a quoted paragraph
+				if !isBlankMd(l) && len(quoted) > 0 && !isBlankMd(quoted[len(quoted)-1]) && !mp.startsBlock(l) {
+					quoted = append(quoted, l)
+					continue
+				}
+				break
+			}
+			blocks = append(blocks, &mdBlock{kind: mdQuote, children: mp.blocks(quoted)})
+			continue
+		}
+
+		if i+1 < len(lines) && strings.Contains(line, "|") && strings.Contains(lines[i+1], "|") && mdDelimRe.MatchString(lines[i+1]) {
+			header := splitMdRow(line)
+			delims := splitMdRow(lines[i+1])
+			if len(header) == len(delims) {
+				flush()
+				table := &mdBlock{kind: mdTable, rows: [][]string{header}}
+				for _, d := range delims {
+					align := ""
+					switch {
+					case strings.HasPrefix(d, ":") && strings.HasSuffix(d, ":"):
+						align = "center"
+					case strings.HasSuffix(d, ":"):
+						align = "right"
+					case strings.HasPrefix(d, ":"):
+						align = "left"
+					}
+					table.align = append(table.align, align)
+				}
+				for i += 2; i < len(lines) && !isBlankMd(lines[i]) && !mp.startsBlock(lines[i]); i++ {
+					table.rows = append(table.rows, splitMdRow(lines[i]))
+				}
+				blocks = append(blocks, table)
+				continue
+			}
+		}
+
+		if m := mdListRe.FindStringSubmatch(line); m != nil {
+			// Only bullet items and items numbered 1 can interrupt a paragraph, as long as they are not empty
+			interrupts := strings.TrimSpace(m[5]) != "" && (m[3] == "" || m[3] == "1")
+			if len(para) == 0 || interrupts {
+				flush()
+				var list *mdBlock
+				list, i = mp.list(lines, i)
+				blocks = append(blocks, list)
+				continue
+			}
+		}
+
+		para = append(para, strings.TrimLeft(line, " "))
+		i++
+	}
+	flush()
+	return blocks
+}
+
+// list parses the items of the list starting at line i and returns the index of the line after the list.
+func (mp *mdParser) list(lines []string, i int) (*mdBlock, int) {
+	first := mdListRe.FindStringSubmatch(lines[i])
+	marker := mdListMarker(first)
+	list := &mdBlock{kind: mdList, ordered: first[3] != ""}
+
+	for i < len(lines) {
+		m := mdListRe.FindStringSubmatch(lines[i])
+		if m == nil || mdListMarker(m) != marker || mdRuleRe.MatchString(lines[i]) {
+			break
+		}
+
+		// The content of the item is indented to the column after the marker
+		width := len(m[1]) + len(m[2])
+		spaces := len(m[4])
+		if spaces == 0 || spaces > 4 || strings.TrimSpace(m[5]) == "" {
+			spaces = 1
+		}
+		contentIndent := width + spaces
+		item := []string{strings.Repeat(" ", max(len(m[4])-spaces, 0)) + m[5]}
+
+		for i++; i < len(lines); i++ {
+			l := lines[i]
+			if isBlankMd(l) {
+				item = append(item, "")
+				continue
+			}
+			if mdIndent(l) >= contentIndent {
+				item = append(item, trimMdIndent(l, contentIndent))
+				continue
+			}
+			// Lazy continuation of the item paragraph, unless the line is the next item
+			if !isBlankMd(item[len(item)-1]) && !mp.startsBlock(l) && !mdSetextRe.MatchString(l) && !mdListRe.MatchString(l) {
+				item = append(item, strings.TrimLeft(l, " "))
+				continue
+			}
+			break
+		}
+		list.items = append(list.items, mp.blocks(item))
+	}
+	return list, i
+}
+
+// startsBlock reports whether the line starts a block that interrupts a paragraph.
+func (mp *mdParser) startsBlock(line string) bool {
+	if mdAtxRe.MatchString(line) || mdFenceRe.MatchString(line) || mdRuleRe.MatchString(line) || isMdQuote(line) {
+		return true
+	}
+	m := mdListRe.FindStringSubmatch(line)
+	return m != nil && strings.TrimSpace(m[5]) != "" && (m[3] == "" || m[3] == "1")
+}
+
+// inline parses inline Markdown: emphasis, strikethrough, code spans, links, images,
+// autolinks, hard line breaks, backslash escapes and entities.
+func (mp *mdParser) inline(src string, style mdInlineStyle) []mdInline {
+	var out []mdInline
+	var buf strings.Builder
+	emit := func() {
+		if buf.Len() > 0 {
+			out = append(out, mdInline{mdInlineStyle: style, text: buf.String()})
+			buf.Reset()
+		}
+	}
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\\' && i+1 < len(src) && src[i+1] == '\n':
+			emit()
+			out = append(out, mdInline{mdInlineStyle: style, brk: true})
+			i += 2
+			continue
+
+		case c == '\\' && i+1 < len(src) && isMdPunct(src[i+1]):
+			buf.WriteByte(src[i+1])
+			i += 2
+			continue
+
+		case c == '`':
+			n := mdRun(src, i)
+			if end := mdCodeEnd(src, i+n, n); end >= 0 {
+				code := strings.ReplaceAll(src[i+n:end], "\n", " ")
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+					code = code[1 : len(code)-1]
+				}
+				emit()
+				out = append(out, mdInline{mdInlineStyle: style, text: code, code: true})
+				i = end + n
+				continue
+			}
+			buf.WriteString(src[i : i+n])
+			i += n
+			continue
+
+		case c == '!' && i+1 < len(src) && src[i+1] == '[':
+			if label, dest, end, ok := mp.link(src, i+1); ok {
+				emit()
+				var alt strings.Builder
+				for _, in := range mp.inline(label, mdInlineStyle{}) {
+					alt.WriteString(in.text)
+				}
+				out = append(out, mdInline{mdInlineStyle: style, text: alt.String(), image: dest})
+				i = end
+				continue
+			}
+
+		case c == '[':
+			if label, dest, end, ok := mp.link(src, i); ok {
+				emit()
+				linkStyle := style
+				linkStyle.link = dest
+				out = append(out, mp.inline(label, linkStyle)...)
+				i = end
+				continue
+			}
+
+		case c == '<':
+			if m := mdAutoRe.FindStringSubmatch(src[i:]); m != nil {
+				emit()
+				linkStyle := style
+				linkStyle.link = m[1]
+				out = append(out, mdInline{mdInlineStyle: linkStyle, text: m[1]})
+				i += len(m[0])
+				continue
+			}
+			if m := mdEmailRe.FindStringSubmatch(src[i:]); m != nil {
+				emit()
+				linkStyle := style
+				linkStyle.link = "mailto:" + m[1]
+				out = append(out, mdInline{mdInlineStyle: linkStyle, text: m[1]})
+				i += len(m[0])
+				continue
+			}
+
+		case c == '*' || c == '_' || c == '~':
+			n := mdRun(src, i)
+			if end := mdEmphasisEnd(src, i, n); end >= 0 {
+				emit()
+				inner := style
+				switch {
+				case c == '~':
+					inner.strike = true
+				case n == 1:
+					inner.italic = true
+				case n == 2:
+					inner.bold = true
+				default:
+					inner.bold, inner.italic = true, true
+				}
+				out = append(out, mp.inline(src[i+n:end], inner)...)
+				i = end + n
+				continue
+			}
+			buf.WriteString(src[i : i+n])
+			i += n
+			continue
+
+		case c == '&':
+			if m := mdEntityRe.FindString(src[i:]); m != "" {
+				buf.WriteString(html.UnescapeString(m))
+				i += len(m)
+				continue
+			}
+
+		case c == '\n':
+			// Two trailing spaces make a hard line break, otherwise the line break is a space
+			text := buf.String()
+			trimmed := mdSpaceTail.ReplaceAllString(text, "")
+			buf.Reset()
+			buf.WriteString(trimmed)
+			if len(text)-len(trimmed) >= 2 {
+				emit()
+				out = append(out, mdInline{mdInlineStyle: style, brk: true})
+			} else {
+				buf.WriteByte(' ')
+			}
+			for i++; i < len(src) && (src[i] == ' ' || src[i] == '\t'); i++ {
+			}
+			continue
+		}
+
+		buf.WriteByte(c)
+		i++
+	}
+	emit()
+	return out
+}
+
+// link parses an inline link "[label](destination "title")" or a reference link "[label][ref]",
+// "[label][]" or "[label]" starting at the opening bracket.
+func (mp *mdParser) link(src string, i int) (label, dest string, end int, ok bool) {
+	closing := mdBracketEnd(src, i)
+	if closing < 0 {
+		return "", "", 0, false
+	}
+	label = src[i+1 : closing]
+	j := closing + 1
+
+	if j < len(src) && src[j] == '(' {
+		if dest, end, ok = mdLinkDest(src, j+1); ok {
+			return label, dest, end, true
+		}
+	}
+
+	ref := label
+	end = j
+	if j < len(src) && src[j] == '[' {
+		if refEnd := mdBracketEnd(src, j); refEnd >= 0 {
+			if r := src[j+1 : refEnd]; r != "" {
+				ref = r
+			}
+			end = refEnd + 1
+		}
+	}
+	if dest, ok = mp.refs[normalizeMdLabel(ref)]; ok {
+		return label, dest, end, true
+	}
+	return "", "", 0, false
+}
+
+// mdLinkDest parses the destination and optional title of an inline link, i being just after
+// the opening parenthesis, and returns the index after the closing parenthesis.
+func mdLinkDest(src string, i int) (dest string, end int, ok bool) {
+	for i < len(src) && (src[i] == ' ' || src[i] == '\t' || src[i] == '\n') {
+		i++
+	}
+	start := i
+	if i < len(src) && src[i] == '<' {
+		closing := strings.IndexAny(src[i+1:], ">\n")
+		if closing < 0 || src[i+1+closing] != '>' {
+			return "", 0, false
+		}
+		dest = src[i+1 : i+1+closing]
+		i += closing + 2
+	} else {
+		depth := 0
+		for ; i < len(src) && src[i] > ' '; i++ {
+			if src[i] == '\\' && i+1 < len(src) {
+				i++
+				continue
+			}
+			if src[i] == '(' {
+				depth++
+			}
+			if src[i] == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			}
+		}
+		dest = src[start:i]
+	}
+
+	for i < len(src) && (src[i] == ' ' || src[i] == '\t' || src[i] == '\n') {
+		i++
+	}
+	if i < len(src) && (src[i] == '"' || src[i] == '\'' || src[i] == '(') {
+		closer := src[i]
+		if closer == '(' {
+			closer = ')'
+		}
+		closing := strings.IndexByte(src[i+1:], closer)
+		if closing < 0 {
+			return "", 0, false
+		}
+		i += closing + 2
+		for i < len(src) && (src[i] == ' ' || src[i] == '\t' || src[i] == '\n') {
+			i++
+		}
+	}
+	if i >= len(src) || src[i] != ')' {
+		return "", 0, false
+	}
+	return unescapeMd(dest), i + 1, true
+}
+
+// mdBracketEnd returns the index of the bracket closing the one at i, or -1.
+func mdBracketEnd(src string, i int) int {
+	depth := 0
+	for j := i; j < len(src); j++ {
+		switch src[j] {
+		case '\\':
+			j++
+		case '`':
+			n := mdRun(src, j)
+			if end := mdCodeEnd(src, j+n, n); end >= 0 {
+				j = end + n - 1
+			} else {
+				j += n - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// mdCodeEnd returns the index of the backtick run of length n closing a code span, or -1.
+func mdCodeEnd(src string, from, n int) int {
+	for j := from; j < len(src); {
+		if src[j] != '`' {
+			j++
+			continue
+		}
+		run := mdRun(src, j)
+		if run == n {
+			return j
+		}
+		j += run
+	}
+	return -1
+}
+
+// mdEmphasisEnd returns the index of the delimiter run closing the emphasis opened by the run
+// of n delimiters at i, or -1 if the run does not open an emphasis.
+func mdEmphasisEnd(src string, i, n int) int {
+	c := src[i]
+	if c == '~' && n > 2 {
+		return -1
+	}
+	if i+n >= len(src) || isMdSpace(src[i+n]) {
+		return -1
+	}
+	if c == '_' && i > 0 && isMdWordChar(src[i-1]) {
+		return -1
+	}
+
+	for j := i + n; j < len(src); {
+		switch src[j] {
+		case '\\':
+			j += 2
+			continue
+		case '`':
+			run := mdRun(src, j)
+			if end := mdCodeEnd(src, j+run, run); end >= 0 {
+				j = end + run
+			} else {
+				j += run
+			}
+			continue
+		case c:
+			run := mdRun(src, j)
+			closes := !isMdSpace(src[j-1]) && (c != '_' || j+run >= len(src) || !isMdWordChar(src[j+run]))
+			if closes && (run == n || (n >= 3 && run >= 3)) && j > i+n {
+				return j
+			}
+			j += run
+			continue
+		}
+		j++
+	}
+	return -1
+}
+
+// mdListMarker identifies the kind of a list item marker: the bullet character or the
+// delimiter of a numbered item.
+func mdListMarker(m []string) string {
+	if m[3] == "" {
+		return m[2]
+	}
+	return m[2][len(m[2])-1:]
+}
+
+// splitMdRow splits a table row into the inline source of its cells.
+func splitMdRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// expandMdTabs expands the tabs of the line indentation to the next multiple of 4 columns.
+func expandMdTabs(line string) string {
+	var sb strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case '\t':
+			sb.WriteString(strings.Repeat(" ", 4-sb.Len()%4))
+		case ' ':
+			sb.WriteByte(' ')
+		default:
+			return sb.String() + line[i:]
+		}
+	}
+	return sb.String()
+}
+
+func mdIndent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// trimMdIndent removes up to n columns of indentation.
+func trimMdIndent(line string, n int) string {
+	return line[min(n, mdIndent(line)):]
+}
+
+func mdRun(src string, i int) int {
+	n := 1
+	for i+n < len(src) && src[i+n] == src[i] {
+		n++
+	}
+	return n
+}
+
+func isBlankMd(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+func isMdQuote(line string) bool {
+	return mdIndent(line) < 4 && strings.HasPrefix(strings.TrimLeft(line, " "), ">")
+}
+
+func isMdSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
+
+func isMdWordChar(c byte) bool {
+	return c >= 0x80 || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c))
+}
+
+func isMdPunct(c byte) bool {
+	return c < 0x80 && unicode.IsPunct(rune(c)) || strings.IndexByte("$+<=>^`|~", c) >= 0
+}
+
+// unescapeMd resolves the backslash escapes and entities of a link destination.
+func unescapeMd(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isMdPunct(s[i+1]) {
+			i++
+		}
+		sb.WriteByte(s[i])
+	}
+	return html.UnescapeString(sb.String())
+}
+
+// normalizeMdLabel normalises a link label for case insensitive matching.
+func normalizeMdLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
@@ -0,0 +1,125 @@
+package docx
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"godocx/common/units"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToMarkdown(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.Numbering = NewNumberingManager(rd)
+
+	_, err := rd.AddHeading("Release *notes*", 1)
+	require.NoError(t, err)
+
+	p := rd.AddParagraph("Read ")
+	p.AddText("carefully").Bold(true)
+	p.AddText(" and ")
+	p.AddText("twice").Italic(true).Strike(true)
+	p.AddText(", see ")
+	p.AddLink("the site", "https://example.com/docs")
+	p.AddText(".")
+
+	numID := rd.NewListInstance(1)
+	rd.AddParagraph("First").Numbering(numID, 0)
+	rd.AddParagraph("Nested").Numbering(numID, 1)
+	rd.AddParagraph("Second").Numbering(numID, 0)
+
+	rd.AddParagraph("1. not a list")
+
+	tbl := rd.AddTable()
+	row := tbl.AddRow()
+	row.AddCell().AddParagraph("Name")
+	row.AddCell().AddParagraph("Value")
+	row = tbl.AddRow()
+	row.AddCell().AddParagraph("a|b")
+	cell := row.AddCell()
+	cell.AddParagraph("1")
+	cell.AddParagraph("2")
+
+	var buf bytes.Buffer
+	require.NoError(t, rd.ToMarkdown(&buf, nil))
+
+	expected := "# Release \\*notes\\*\n" +
+		"\n" +
+		"Read **carefully** and *~~twice~~*, see [the site](https://example.com/docs).\n" +
+		"\n" +
+		"1. First\n" +
+		"    1. Nested\n" +
+		"2. Second\n" +
+		"\n" +
+		"1\\. not a list\n" +
+		"\n" +
+		"| Name | Value |\n" +
+		"| --- | --- |\n" +
+		"| a\\|b | 1<br>2 |\n"
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestToMarkdown_Images(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.Document.DocRels = Relationships{}
+
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.Black)
+	var imgBuf bytes.Buffer
+	require.NoError(t, png.Encode(&imgBuf, img))
+
+	_, err := rd.AddImage(imgBuf.Bytes(), units.Inch(1), units.Inch(1))
+	require.NoError(t, err)
+	rd.AddParagraph("after").AddText("").AddBreak(stypes.BreakTypePage)
+
+	dir := t.TempDir()
+	var buf bytes.Buffer
+	require.NoError(t, rd.ToMarkdown(&buf, &MarkdownOptions{ImageDir: dir, ImageURL: "/assets"}))
+
+	assert.Equal(t, "![Image2](/assets/image2.png)\n\nafter\n", buf.String())
+	content, err := os.ReadFile(filepath.Join(dir, "image2.png"))
+	require.NoError(t, err)
+	assert.Equal(t, imgBuf.Bytes(), content)
+}
+
+func TestToMarkdown_StyleEmphasis(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.AddParagraphStyle("Lead", "Lead").Bold(true)
+	rd.AddCharacterStyle("Strong2", "Strong2").Bold(true).Italic(true)
+	list := rd.AddTableStyle("LightList", "Light List")
+	list.Conditional(stypes.TblStyleOverrideFirstRow).Bold(true)
+	require.NoError(t, list.Err())
+
+	p := rd.AddParagraph("Lead ")
+	p.Style("Lead")
+	p.AddText("toggled").Style("Strong2")
+
+	table := rd.AddTable()
+	table.Style("LightList")
+	table.GetCT().TableProp.TableLook = ctypes.NewCTString("04A0")
+	table.AddRow().AddCell().AddParagraph("Name")
+	table.AddRow().AddCell().AddParagraph("Ann")
+
+	var buf bytes.Buffer
+	require.NoError(t, rd.ToMarkdown(&buf, nil))
+	expected := "**Lead** *toggled*\n" +
+		"\n" +
+		"| **Name** |\n" +
+		"| --- |\n" +
+		"| Ann |\n"
+	assert.Equal(t, expected, buf.String())
+
+	buf.Reset()
+	require.NoError(t, rd.ToHTML(&buf, nil))
+	assert.Contains(t, buf.String(), "<em>toggled</em>")
+	assert.Contains(t, buf.String(), "<strong>Name</strong>")
+}
@@ -0,0 +1,108 @@
+package docx
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+// note is a footnote (w:footnote) or an endnote (w:endnote).
+type note struct {
+	ID       int
+	Type     string // separator, continuationSeparator, continuationNotice or "" for a regular note
+	Children []DocumentChild
+}
+
+// notesPart is the footnotes (w:footnotes) or endnotes (w:endnotes) part of the document.
+type notesPart struct {
+	root  *RootDoc
+	Notes []*note
+}
+
+// UnmarshalXML implements the xml.Unmarshaler interface for the notesPart type.
+func (np *notesPart) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	for {
+		var currentToken xml.Token
+		if currentToken, err = d.Token(); err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			if elem.Name.Local != "footnote" && elem.Name.Local != "endnote" {
+				if err = d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			n := &note{}
+			for _, attr := range elem.Attr {
+				switch attr.Name.Local {
+				case "id":
+					if n.ID, err = strconv.Atoi(attr.Value); err != nil {
+						return fmt.Errorf("%s id: %w", elem.Name.Local, err)
+					}
+				case "type":
+					n.Type = attr.Value
+				}
+			}
+			if err = np.unmarshalNote(d, n); err != nil {
+				return err
+			}
+			np.Notes = append(np.Notes, n)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+func (np *notesPart) unmarshalNote(d *xml.Decoder, n *note) (err error) {
+	for {
+		var currentToken xml.Token
+		if currentToken, err = d.Token(); err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			var child DocumentChild
+			if child, err = decodeBlockChild(np.root, d, elem); err != nil {
+				return err
+			}
+			if child.Para != nil || child.Table != nil {
+				n.Children = append(n.Children, child)
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// notes reads the regular notes of the footnotes or endnotes part, relType being the type of
+// the relationship referencing the part. Notes are indexed by ID, the map is empty when the
+// document has no such part.
+func (rd *RootDoc) notes(relType string) (map[int]*note, error) {
+	notes := map[int]*note{}
+	for _, rel := range rd.Document.DocRels.Relationships {
+		if rel.Type != relType {
+			continue
+		}
+
+		partPath := rd.Document.partPath(rel.Target)
+		content, ok := rd.FileMap.Load(partPath)
+		if !ok {
+			return nil, fmt.Errorf("part %s referenced by %s not found", partPath, rel.ID)
+		}
+
+		np := &notesPart{root: rd}
+		if err := xml.Unmarshal(content.([]byte), np); err != nil {
+			return nil, fmt.Errorf("%s: %w", partPath, err)
+		}
+		for _, n := range np.Notes {
+			if n.Type == "" || n.Type == "normal" {
+				notes[n.ID] = n
+			}
+		}
+	}
+	return notes, nil
+}
@@ -1,283 +1,453 @@
 package docx
 
 import (
+	"bytes"
 	"encoding/xml"
 	"fmt"
-	"regexp"
+	"hash/crc32"
+	"path"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
+
+	"godocx/common/constants"
+	"godocx/common/units"
+	"godocx/internal"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
 )
 
-// NumInstance represents a w:num element that creates an instance of abstract numbering
-type NumInstance struct {
-	XMLName       xml.Name `xml:"w:num"`
-	NumId         int      `xml:"w:numId,attr"`
-	AbstractNumId int      `xml:"w:abstractNumId"`
-}
+// numberingPath is the package path of the numbering part
+const numberingPath = "word/numbering.xml"
 
-// Numbering represents the numbering part of a Word document
-type Numbering struct {
-	XMLName   xml.Name       `xml:"w:numbering"`
-	Instances []*NumInstance `xml:"w:num"`
-}
+// numberingRelsPath is the package path of the relationships of the numbering part, which refer
+// to the images of picture bullets
+const numberingRelsPath = "word/_rels/numbering.xml.rels"
 
-// NumberingManager manages numbering instances for the document
+// numberingContentType is the content type of the numbering part
+const numberingContentType = "application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml"
+
+// Abstract numbering IDs of the lists generated by NewListInstance, whose definitions are added
+// to the document when first used.
+const (
+	DecimalListID = 201 // Multilevel decimal list: 1., a., i., A. and so on
+	BulletListID  = 202 // Multilevel bullet list
+)
+
+// NumberingManager manages the numbering definitions of the document. The numbering part is
+// parsed when first needed and written back when the document is saved, only if it changed.
 type NumberingManager struct {
 	mu        sync.Mutex
-	numbering *Numbering
-	nextNumId int
+	numbering *ctypes.Numbering // nil until loaded
+	rels      *Relationships    // Relationships of the numbering part, nil until an image is added
+	err       error             // Error parsing the numbering part
+	dirty     bool              // Whether the definitions may differ from the numbering part
+	read      []byte            // Definitions as handed out by Definitions, to detect changes
 	rootDoc   *RootDoc
 }
 
 // NewNumberingManager creates a new numbering manager
 func NewNumberingManager(root *RootDoc) *NumberingManager {
-	return &NumberingManager{
-		numbering: &Numbering{
-			XMLName:   xml.Name{Local: "w:numbering", Space: "http://schemas.openxmlformats.org/wordprocessingml/2006/main"},
-			Instances: make([]*NumInstance, 0),
-		},
-		nextNumId: 1,
-		rootDoc:   root,
+	return &NumberingManager{rootDoc: root}
+}
+
+// load returns the numbering definitions, parsing the numbering part of the document the first
+// time. The definitions are empty when the document has no numbering part or when it cannot be
+// parsed, the error being reported when the document is saved.
+func (nm *NumberingManager) load() *ctypes.Numbering {
+	if nm.numbering != nil {
+		return nm.numbering
 	}
+	nm.numbering = &ctypes.Numbering{}
+	if nm.rootDoc == nil {
+		return nm.numbering
+	}
+	if content, ok := nm.rootDoc.FileMap.Load(numberingPath); ok {
+		if err := xml.Unmarshal(content.([]byte), nm.numbering); err != nil {
+			nm.numbering = &ctypes.Numbering{}
+			nm.err = fmt.Errorf("numbering: %w", err)
+		}
+	}
+	return nm.numbering
+}
+
+// Definitions returns the numbering definitions of the document: its abstract numbering
+// definitions, holding the levels of the lists, and the numbering instances paragraphs refer to.
+// Changes to the returned definitions are saved with the document.
+//
+// Example:
+//
+//	defs, err := document.Numbering.Definitions()
+//	if err != nil {
+//		return err
+//	}
+//	for _, abstract := range defs.AbstractNums {
+//		if level := abstract.Level(0); level != nil && level.NumFmt != nil {
+//			fmt.Println(abstract.ID, level.NumFmt.Val)
+//		}
+//	}
+func (nm *NumberingManager) Definitions() (*ctypes.Numbering, error) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	n := nm.load()
+	if nm.err != nil {
+		return nil, nm.err
+	}
+	if !nm.dirty && nm.read == nil {
+		// Only written back if changed, the typed model dropping what it does not know
+		read, err := marshal(n)
+		if err != nil {
+			return nil, fmt.Errorf("numbering: %w", err)
+		}
+		nm.read = read
+	}
+	return n, nil
 }
 
 // NewListInstance creates a new numbering instance for the given abstract numbering ID
 // Returns the numId that can be used with paragraph.Numbering()
+//
+// DecimalListID and BulletListID stand for a multilevel decimal list and a multilevel bullet
+// list, whose definitions are added to the document when first used. The IDs 1 and 2 stand for
+// them as well when the document defines no abstract numbering with that ID. An instance of an
+// abstract numbering definition already used by another instance restarts its numbering.
 func (nm *NumberingManager) NewListInstance(abstractNumId int) int {
+	return nm.newInstance(abstractNumId, 0)
+}
+
+// newInstance creates a numbering instance of the abstract numbering, restarting the numbering
+// of the given level if the abstract numbering is already used.
+func (nm *NumberingManager) newInstance(abstractNumId, level int) int {
 	nm.mu.Lock()
 	defer nm.mu.Unlock()
 
-	// Avoid numId collisions with any template-defined w:num entries
-	nm.ensureNextNumIdFromTemplate()
-
-	numId := nm.nextNumId
-	nm.nextNumId++
-
-	instance := &NumInstance{
-		XMLName:       xml.Name{Local: "w:num"},
-		NumId:         numId,
-		AbstractNumId: nm.normalizeAbstract(abstractNumId),
+	n := nm.load()
+	abstractNumId = nm.normalizeAbstract(abstractNumId)
+	num := &ctypes.Num{
+		ID:            n.NextNumID(),
+		AbstractNumID: ctypes.DecimalNum{Val: abstractNumId},
 	}
-
-	nm.numbering.Instances = append(nm.numbering.Instances, instance)
-	return numId
+	if slices.ContainsFunc(n.Nums, func(other *ctypes.Num) bool { return other.AbstractNumID.Val == abstractNumId }) {
+		// Instances of the same definition continue each other's numbering otherwise
+		num.LvlOverrides = []*ctypes.LvlOverride{{ILvl: level, StartOverride: ctypes.NewDecimalNum(1)}}
+	}
+	n.Nums = append(n.Nums, num)
+	nm.dirty = true
+	return num.ID
 }
 
-// GetNumberingXML returns the XML representation of the numbering part
+// GetNumberingXML returns the XML representation of the numbering part, empty when the document
+// has no numbering definitions.
 func (nm *NumberingManager) GetNumberingXML() ([]byte, error) {
 	nm.mu.Lock()
 	defer nm.mu.Unlock()
 
-	if len(nm.numbering.Instances) == 0 {
+	n := nm.load()
+	if nm.err != nil {
+		return nil, nm.err
+	}
+	if len(n.PicBullets) == 0 && len(n.AbstractNums) == 0 && len(n.Nums) == 0 {
 		return []byte{}, nil
 	}
-
-	return xml.Marshal(nm.numbering)
+	return xml.Marshal(n)
 }
 
-// MarshalXML implements xml.Marshaler for NumInstance
-func (ni NumInstance) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	start.Name.Local = "w:num"
-	start.Attr = []xml.Attr{
-		{Name: xml.Name{Local: "w:numId"}, Value: fmt.Sprintf("%d", ni.NumId)},
-	}
+// applyToFileMap writes the numbering definitions into numbering.xml in the root document's
+// file map. The numbering part is left untouched when the definitions did not change. A
+// numbering part created by the definitions is related to the document, with its content type.
+func (nm *NumberingManager) applyToFileMap() error {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
 
-	if err := e.EncodeToken(start); err != nil {
-		return err
+	if nm.err != nil {
+		return nm.err
 	}
-
-	// Write w:abstractNumId element
-	abstractNumElem := xml.StartElement{
-		Name: xml.Name{Local: "w:abstractNumId"},
-		Attr: []xml.Attr{
-			{Name: xml.Name{Local: "w:val"}, Value: fmt.Sprintf("%d", ni.AbstractNumId)},
-		},
+	if (!nm.dirty && nm.read == nil) || nm.rootDoc == nil {
+		return nil
 	}
-	if err := e.EncodeElement("", abstractNumElem); err != nil {
-		return err
+	content, err := marshal(nm.numbering)
+	if err != nil {
+		return fmt.Errorf("numbering: %w", err)
 	}
-
-	// Ensure numbering restarts at 1 for level 0 in this instance
-	lvlOverride := xml.StartElement{
-		Name: xml.Name{Local: "w:lvlOverride"},
-		Attr: []xml.Attr{{Name: xml.Name{Local: "w:ilvl"}, Value: "0"}},
+	if !nm.dirty && bytes.Equal(content, nm.read) {
+		return nil
 	}
-	if err := e.EncodeToken(lvlOverride); err != nil {
-		return err
+	if _, ok := nm.rootDoc.FileMap.Load(numberingPath); !ok {
+		nm.rootDoc.addNumberingPart()
 	}
-	startOverride := xml.StartElement{
-		Name: xml.Name{Local: "w:startOverride"},
-		Attr: []xml.Attr{{Name: xml.Name{Local: "w:val"}, Value: "1"}},
+	nm.rootDoc.FileMap.Store(numberingPath, content)
+	if nm.rels != nil {
+		if content, err = marshal(nm.rels); err != nil {
+			return fmt.Errorf("numbering relationships: %w", err)
+		}
+		nm.rootDoc.FileMap.Store(numberingRelsPath, content)
 	}
-	if err := e.EncodeElement("", startOverride); err != nil {
-		return err
+	nm.dirty, nm.read = false, nil
+	return nil
+}
+
+// addNumberingPart relates the numbering part to the document and declares its content type,
+// unless the document already does.
+func (rd *RootDoc) addNumberingPart() {
+	if rd.Document != nil && !slices.ContainsFunc(rd.Document.DocRels.Relationships, func(rel *Relationship) bool {
+		return rel.Type == constants.SourceRelationshipNumbering
+	}) {
+		rd.Document.addRelation(constants.SourceRelationshipNumbering, path.Base(numberingPath))
 	}
-	if err := e.EncodeToken(xml.EndElement{Name: lvlOverride.Name}); err != nil {
-		return err
+	if !slices.ContainsFunc(rd.ContentType.Override, func(o Override) bool { return o.PartName == "/"+numberingPath }) {
+		_ = rd.ContentType.AddOverride("/"+numberingPath, numberingContentType)
 	}
-
-	return e.EncodeToken(xml.EndElement{Name: start.Name})
 }
 
-// applyToFileMap injects generated numbering instances into the existing numbering.xml
-// within the root document's file map. It preserves existing abstract numbering definitions
-// from the template and appends only the new w:num instance elements.
-func (nm *NumberingManager) applyToFileMap() error {
+// definitions returns a copy of the numbering definitions, nil if they cannot be parsed.
+func (nm *NumberingManager) definitions() *ctypes.Numbering {
 	nm.mu.Lock()
 	defer nm.mu.Unlock()
 
-	if len(nm.numbering.Instances) == 0 || nm.rootDoc == nil {
+	n := nm.load()
+	if nm.err != nil {
 		return nil
 	}
+	return internal.DeepCopy(n)
+}
 
-	// Determine which instances are already present to avoid duplicates
-	// Collect existing numIds from current numbering.xml content if present
-	existingIDs := make(map[int]struct{})
-	const numberingPath = "word/numbering.xml"
-	if existing, ok := nm.rootDoc.FileMap.Load(numberingPath); ok {
-		content := string(existing.([]byte))
-		idRe := regexp.MustCompile(`w:numId=\"(\d+)\"`)
-		for _, m := range idRe.FindAllStringSubmatch(content, -1) {
-			if len(m) == 2 {
-				if v, err := strconv.Atoi(m[1]); err == nil {
-					existingIDs[v] = struct{}{}
-				}
-			}
-		}
+// copyFrom replaces the numbering definitions by a copy of those of src.
+func (nm *NumberingManager) copyFrom(src *NumberingManager) {
+	src.mu.Lock()
+	defer src.mu.Unlock()
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	if src.numbering != nil {
+		nm.numbering = internal.DeepCopy(src.numbering)
 	}
+	if src.rels != nil {
+		nm.rels = internal.DeepCopy(src.rels)
+	}
+	nm.err, nm.dirty, nm.read = src.err, src.dirty, src.read
+}
 
-	// Build the XML snippet only for missing instances to keep operation idempotent
-	var sb strings.Builder
-	for _, inst := range nm.numbering.Instances {
-		if _, found := existingIDs[inst.NumId]; found {
-			continue
-		}
-		// <w:num w:numId="X"><w:abstractNumId w:val="Y"/><w:lvlOverride w:ilvl="0"><w:startOverride w:val="1"/></w:lvlOverride></w:num>
-		sb.WriteString("<w:num w:numId=\"")
-		sb.WriteString(fmt.Sprintf("%d", inst.NumId))
-		sb.WriteString("\"><w:abstractNumId w:val=\"")
-		sb.WriteString(fmt.Sprintf("%d", inst.AbstractNumId))
-		sb.WriteString("\"/>")
-		sb.WriteString("<w:lvlOverride w:ilvl=\"0\"><w:startOverride w:val=\"1\"/></w:lvlOverride>")
-		sb.WriteString("</w:num>")
-	}
-	instancesXML := sb.String()
-
-	if existing, ok := nm.rootDoc.FileMap.Load(numberingPath); ok {
-		// Insert before closing tag of w:numbering
-		content := string(existing.([]byte))
-		// Ensure our multilevel abstract definitions exist
-		content = nm.ensureMultilevelAbstracts(content)
-		if instancesXML == "" {
-			// Nothing new to add
-			nm.rootDoc.FileMap.Store(numberingPath, []byte(content))
-			return nil
+// clear removes the abstract numbering definitions and the numbering instances.
+func (nm *NumberingManager) clear() error {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	n := nm.load()
+	if nm.err != nil {
+		return nm.err
+	}
+	n.AbstractNums, n.Nums = nil, nil
+	nm.dirty = true
+	return nil
+}
+
+// update calls fn with the numbering definitions, which it may change.
+func (nm *NumberingManager) update(fn func(n *ctypes.Numbering) error) error {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	n := nm.load()
+	if nm.err != nil {
+		return nm.err
+	}
+	nm.dirty = true
+	return fn(n)
+}
+
+// defineList adds an abstract numbering definition with nine levels numbered like the
+// generated decimal list and returns its ID.
+func (nm *NumberingManager) defineList() (int, error) {
+	var id int
+	err := nm.update(func(n *ctypes.Numbering) error {
+		id = nextAbstractNumID(n)
+		abstract := &ctypes.AbstractNum{
+			ID: id,
+			// Word identifies lists by this number, derived from the ID for reproducible output
+			Nsid:           ctypes.NewCTString(fmt.Sprintf("%08X", crc32.ChecksumIEEE([]byte("list"+strconv.Itoa(id))))),
+			MultiLevelType: ctypes.NewGenSingleStrVal(stypes.MultiLevelTypeHybridMultilevel),
 		}
-		if strings.Contains(content, "</w:numbering>") {
-			updated := strings.Replace(content, "</w:numbering>", instancesXML+"</w:numbering>", 1)
-			nm.rootDoc.FileMap.Store(numberingPath, []byte(updated))
-			return nil
+		for lvl := range 9 {
+			abstract.Levels = append(abstract.Levels, multilevelLevel(lvl, false))
 		}
-		// Fallback: if unexpected structure, append instances at end
-		nm.rootDoc.FileMap.Store(numberingPath, []byte(content+instancesXML))
+		n.AbstractNums = append(n.AbstractNums, abstract)
 		return nil
+	})
+	return id, err
+}
+
+// addPicBullet adds a picture numbering symbol showing the image at the given size and returns
+// its ID. The image is stored in the package and referenced by the numbering part.
+func (nm *NumberingManager) addPicBullet(imgBytes []byte, size units.Point) (int, error) {
+	imgMIME, imgExt, err := imageType(imgBytes)
+	if err != nil {
+		return 0, err
 	}
 
-	// If numbering.xml doesn't exist (unlikely with the default template), create a minimal one
-	minimal := `<?xml version="1.0" encoding="UTF-8"?>` +
-		`<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
-		nm.multilevelAbstractsXML() + instancesXML + `</w:numbering>`
-	nm.rootDoc.FileMap.Store(numberingPath, []byte(minimal))
-	return nil
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	n := nm.load()
+	if nm.err != nil {
+		return 0, nm.err
+	}
+	if err := nm.loadRels(); err != nil {
+		return 0, err
+	}
+	target, err := nm.rootDoc.storeImage(imgBytes, imgExt, imgMIME)
+	if err != nil {
+		return 0, err
+	}
+	next := 1
+	for _, rel := range nm.rels.Relationships {
+		if v, err := strconv.Atoi(strings.TrimPrefix(rel.ID, "rId")); err == nil {
+			next = max(next, v+1)
+		}
+	}
+	rID := "rId" + strconv.Itoa(next)
+	nm.rels.Relationships = append(nm.rels.Relationships, &Relationship{
+		ID:     rID,
+		Type:   constants.SourceRelationshipImage,
+		Target: target,
+	})
+
+	bullet := &ctypes.NumPicBullet{ID: n.NextPicBulletID()}
+	bullet.Content = fmt.Sprintf(`<w:pict xmlns:v="urn:schemas-microsoft-com:vml" xmlns:o="urn:schemas-microsoft-com:office:office" xmlns:r="%s">`+
+		`<v:shape id="_x0000_i%d" type="#_x0000_t75" style="width:%dpt;height:%dpt" o:bullet="t">`+
+		`<v:imagedata r:id="%s" o:title=""/></v:shape></w:pict>`,
+		constants.XMLNS_R, 1025+bullet.ID, size, size, rID)
+	n.PicBullets = append(n.PicBullets, bullet)
+	nm.dirty = true
+	return bullet.ID, nil
 }
 
-// normalizeAbstract maps simple ids used by API to internal multilevel abstract ids.
-// 1 -> decimal multilevel, 2 -> bullet multilevel; others remain unchanged.
-func (nm *NumberingManager) normalizeAbstract(abstractNumId int) int {
-	switch abstractNumId {
-	case 1:
-		return 201
-	case 2:
-		return 202
-	default:
-		return abstractNumId
+// loadRels reads the relationships of the numbering part, an empty set if it has none.
+func (nm *NumberingManager) loadRels() error {
+	if nm.rels != nil {
+		return nil
+	}
+	rels := &Relationships{RelativePath: numberingRelsPath, Xmlns: constants.XMLNS}
+	if content, ok := nm.rootDoc.FileMap.Load(numberingRelsPath); ok {
+		if err := xml.Unmarshal(content.([]byte), rels); err != nil {
+			return fmt.Errorf("numbering relationships: %w", err)
+		}
 	}
+	nm.rels = rels
+	return nil
 }
 
-// ensureNextNumIdFromTemplate raises nextNumId above any existing numIds in the template
-func (nm *NumberingManager) ensureNextNumIdFromTemplate() {
-	const numberingPath = "word/numbering.xml"
-	existing, ok := nm.rootDoc.FileMap.Load(numberingPath)
-	if !ok {
-		return
-	}
-	content := string(existing.([]byte))
-	re := regexp.MustCompile(`w:numId=\"(\d+)\"`)
-	maxID := 0
-	for _, m := range re.FindAllStringSubmatch(content, -1) {
-		if len(m) == 2 {
-			if v, err := strconv.Atoi(m[1]); err == nil && v > maxID {
-				maxID = v
-			}
+// nextAbstractNumID returns the ID of a new abstract numbering definition, after those of the
+// document and the generated ones.
+func nextAbstractNumID(n *ctypes.Numbering) int {
+	return max(n.NextAbstractNumID(), BulletListID+1)
+}
+
+// eachStyleRef calls fn with the style references of the numbering definitions.
+func (nm *NumberingManager) eachStyleRef(fn func(ref *ctypes.CTString)) error {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	n := nm.load()
+	if nm.err != nil {
+		return nm.err
+	}
+	call := func(ref *ctypes.CTString) {
+		if ref == nil {
+			return
+		}
+		val := ref.Val
+		fn(ref)
+		if ref.Val != val {
+			nm.dirty = true
 		}
 	}
-	if nm.nextNumId <= maxID {
-		nm.nextNumId = maxID + 1
+	for _, abstract := range n.AbstractNums {
+		call(abstract.StyleLink)
+		call(abstract.NumStyleLink)
+		for _, level := range abstract.Levels {
+			call(level.PStyle)
+		}
 	}
+	for _, num := range n.Nums {
+		for _, override := range num.LvlOverrides {
+			if override.Level != nil {
+				call(override.Level.PStyle)
+			}
+		}
+	}
+	return nil
 }
 
-// ensureMultilevelAbstracts injects multilevel abstract definitions used by normalizeAbstract
-func (nm *NumberingManager) ensureMultilevelAbstracts(content string) string {
-	if strings.Contains(content, `w:abstractNumId="201"`) && strings.Contains(content, `w:abstractNumId="202"`) {
-		return content
+// normalizeAbstract maps the IDs of the generated lists to their abstract numbering ID, adding
+// their definition when missing. 1 -> decimal multilevel and 2 -> bullet multilevel unless the
+// document defines them; other IDs remain unchanged.
+func (nm *NumberingManager) normalizeAbstract(abstractNumId int) int {
+	n := nm.numbering
+	var bullet bool
+	switch {
+	case abstractNumId == DecimalListID, abstractNumId == 1 && n.AbstractNumByID(1) == nil:
+		abstractNumId = DecimalListID
+	case abstractNumId == BulletListID, abstractNumId == 2 && n.AbstractNumByID(2) == nil:
+		abstractNumId, bullet = BulletListID, true
+	default:
+		return abstractNumId
 	}
 
-	insert := nm.multilevelAbstractsXML()
-	// Place right after opening <w:numbering ...>
-	start := strings.Index(content, "<w:numbering")
-	if start >= 0 {
-		end := strings.Index(content[start:], ">")
-		if end >= 0 {
-			pos := start + end + 1
-			return content[:pos] + insert + content[pos:]
+	if n.AbstractNumByID(abstractNumId) == nil {
+		abstract := &ctypes.AbstractNum{
+			ID:             abstractNumId,
+			MultiLevelType: ctypes.NewGenSingleStrVal(stypes.MultiLevelTypeHybridMultilevel),
+		}
+		for lvl := range 9 {
+			abstract.Levels = append(abstract.Levels, multilevelLevel(lvl, bullet))
 		}
+		n.AbstractNums = append(n.AbstractNums, abstract)
 	}
-	// Fallback: append
-	return content + insert
+	return abstractNumId
 }
 
-func (nm *NumberingManager) multilevelAbstractsXML() string {
-	// Build 9 levels for decimal and bullet schemes
-	var dec, bul strings.Builder
-	dec.WriteString(`<w:abstractNum w:abstractNumId="201"><w:multiLevelType w:val="hybridMultilevel"/>`)
-	bul.WriteString(`<w:abstractNum w:abstractNumId="202"><w:multiLevelType w:val="hybridMultilevel"/>`)
-	for lvl := 0; lvl < 9; lvl++ {
-		pos := 360 * (lvl + 1)
+// multilevelLevel returns the definition of a level of the generated multilevel lists, indented
+// by a quarter inch per level.
+func multilevelLevel(lvl int, bullet bool) *ctypes.Level {
+	pos := 360 * (lvl + 1)
+	level := &ctypes.Level{
+		ILvl:          lvl,
+		Start:         ctypes.NewDecimalNum(1),
+		Justification: ctypes.NewGenSingleStrVal(stypes.JustificationLeft),
+		ParaProp: &ctypes.ParagraphProp{
+			Tabs:   ctypes.Tabs{Tab: []ctypes.Tab{{Val: stypes.CustTabStopNum, Position: pos}}},
+			Indent: &ctypes.Indent{Left: internal.ToPtr(pos), Hanging: internal.ToPtr(uint64(360))},
+		},
+	}
+	if !bullet {
 		// Ordered: cycle formats per level: decimal, lowerLetter, lowerRoman
-		fmtVal := orderedNumFmtForLevel(lvl)
-		dec.WriteString(`<w:lvl w:ilvl="` + strconv.Itoa(lvl) + `"><w:start w:val="1"/><w:numFmt w:val="` + fmtVal + `"/><w:lvlText w:val="%` + strconv.Itoa(lvl+1) + `."/><w:lvlJc w:val="left"/><w:pPr><w:tabs><w:tab w:val="num" w:pos="` + strconv.Itoa(pos) + `"/></w:tabs><w:ind w:left="` + strconv.Itoa(pos) + `" w:hanging="360"/></w:pPr></w:lvl>`)
-		// Bullets: cycle glyphs (•, o, square) and fonts (Symbol, Symbol, Wingdings)
-		glyph, font := bulletGlyphForLevel(lvl)
-		bul.WriteString(`<w:lvl w:ilvl="` + strconv.Itoa(lvl) + `"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val="` + glyph + `"/><w:lvlJc w:val="left"/><w:pPr><w:tabs><w:tab w:val="num" w:pos="` + strconv.Itoa(pos) + `"/></w:tabs><w:ind w:left="` + strconv.Itoa(pos) + `" w:hanging="360"/></w:pPr><w:rPr><w:rFonts w:ascii="` + font + `" w:hAnsi="` + font + `" w:hint="default"/></w:rPr></w:lvl>`)
-	}
-	dec.WriteString(`</w:abstractNum>`)
-	bul.WriteString(`</w:abstractNum>`)
-	return dec.String() + bul.String()
+		level.NumFmt = ctypes.NewGenSingleStrVal(orderedNumFmtForLevel(lvl))
+		level.LvlText = ctypes.NewCTString(fmt.Sprintf("%%%d.", lvl+1))
+		return level
+	}
+	// Bullets: cycle glyphs (•, o, square) and fonts (Symbol, Symbol, Wingdings)
+	glyph, font := bulletGlyphForLevel(lvl)
+	level.NumFmt = ctypes.NewGenSingleStrVal(stypes.NumFmtBullet)
+	level.LvlText = ctypes.NewCTString(glyph)
+	level.RunProp = &ctypes.RunProperty{
+		Fonts: &ctypes.RunFonts{Ascii: font, HAnsi: font, Hint: stypes.FontTypeHintDefault},
+	}
+	return level
 }
 
 // orderedNumFmtForLevel returns the WordprocessingML numFmt for a given level,
 // cycling through decimal, lowerLetter, and lowerRoman.
-func orderedNumFmtForLevel(level int) string {
+func orderedNumFmtForLevel(level int) stypes.NumFmt {
 	switch level % 4 {
 	case 0:
-		return "decimal"
+		return stypes.NumFmtDecimal
 	case 1:
-		return "lowerLetter"
+		return stypes.NumFmtLowerLetter
 	case 2:
-		return "lowerRoman"
+		return stypes.NumFmtLowerRoman
 	default:
-		return "upperLetter"
+		return stypes.NumFmtUpperLetter
 	}
 }
 
@@ -298,3 +468,74 @@ func bulletGlyphForLevel(level int) (glyph string, font string) {
 		return "♦", "Symbol" // diamond
 	}
 }
+
+// importNumbering copies the numbering instances numIDs of the numbering definitions src, with
+// their abstract numberings, into the numbering definitions of the document. The copies get new
+// IDs and refer to the styles renamed in styleIDs. Instances missing from src are left out.
+// It returns the new ID of each copied instance.
+func (nm *NumberingManager) importNumbering(src *ctypes.Numbering, numIDs []int, styleIDs map[string]string) (map[int]int, error) {
+	nm.mu.Lock()
+	defer nm.mu.Unlock()
+
+	n := nm.load()
+	if nm.err != nil {
+		return nil, nm.err
+	}
+	nextAbstract := nextAbstractNumID(n)
+
+	renameStyle := func(ref *ctypes.CTString) {
+		if ref == nil {
+			return
+		}
+		if newID, ok := styleIDs[ref.Val]; ok {
+			ref.Val = newID
+		}
+	}
+	renameLevel := func(level *ctypes.Level) {
+		if level != nil {
+			renameStyle(level.PStyle)
+		}
+	}
+
+	newIDs := map[int]int{}
+	newAbstracts := map[int]int{}
+	for _, numID := range slices.Sorted(slices.Values(numIDs)) {
+		num := src.NumByID(numID)
+		if _, done := newIDs[numID]; num == nil || done {
+			continue
+		}
+		abstractID, ok := newAbstracts[num.AbstractNumID.Val]
+		if !ok {
+			abstract := src.AbstractNumByID(num.AbstractNumID.Val)
+			if abstract == nil {
+				continue
+			}
+			copied := internal.DeepCopy(abstract)
+			copied.ID = nextAbstract
+			nextAbstract++
+			// The list identifier is regenerated by Word, a copy would join the original list
+			copied.Nsid = nil
+			renameStyle(copied.StyleLink)
+			renameStyle(copied.NumStyleLink)
+			for _, level := range copied.Levels {
+				renameLevel(level)
+			}
+			n.AbstractNums = append(n.AbstractNums, copied)
+			abstractID = copied.ID
+			newAbstracts[num.AbstractNumID.Val] = abstractID
+		}
+
+		copied := internal.DeepCopy(num)
+		copied.ID = n.NextNumID()
+		copied.AbstractNumID.Val = abstractID
+		for _, override := range copied.LvlOverrides {
+			renameLevel(override.Level)
+		}
+		n.Nums = append(n.Nums, copied)
+		newIDs[numID] = copied.ID
+	}
+	if len(newIDs) > 0 {
+		nm.dirty = true
+	}
+	return newIDs, nil
+}
@@ -6,11 +6,13 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"testing"
 
 	godocx "godocx"
+	"godocx/docx"
 
 	"github.com/stretchr/testify/require"
 )
@@ -24,8 +26,8 @@ func TestGenerateNumberingDocxSample(t *testing.T) {
 
 	rd := doc
 
-	// Ordered list instance A (abstract 1) with deeper nesting
-	ordA := rd.NewListInstance(1)
+	// Ordered list instance A (generated decimal list) with deeper nesting
+	ordA := rd.NewListInstance(docx.DecimalListID)
 	p := rd.AddParagraph("Ordered A 1")
 	p.Numbering(ordA, 0)
 	p = rd.AddParagraph("Nested A 1.1")
@@ -41,15 +43,15 @@ func TestGenerateNumberingDocxSample(t *testing.T) {
 	p = rd.AddParagraph("Ordered A 2")
 	p.Numbering(ordA, 0)
 
-	// Ordered list instance B (same abstract 1, numbering should reset)
-	ordB := rd.NewListInstance(1)
+	// Ordered list instance B (same decimal list, numbering should reset)
+	ordB := rd.NewListInstance(docx.DecimalListID)
 	p = rd.AddParagraph("Ordered B 1")
 	p.Numbering(ordB, 0)
 	p = rd.AddParagraph("Ordered B 2")
 	p.Numbering(ordB, 0)
 
-	// Unordered list instance C (abstract 2 bullets) with deeper nesting
-	bulC := rd.NewListInstance(2)
+	// Unordered list instance C (generated bullet list) with deeper nesting
+	bulC := rd.NewListInstance(docx.BulletListID)
 	p = rd.AddParagraph("Bullet C 1")
 	p.Numbering(bulC, 0)
 	p = rd.AddParagraph("Nested C 1.1")
@@ -65,8 +67,8 @@ func TestGenerateNumberingDocxSample(t *testing.T) {
 	p = rd.AddParagraph("Bullet C 2")
 	p.Numbering(bulC, 0)
 
-	// Unordered list instance D (same abstract 2, numbering should be independent)
-	bulD := rd.NewListInstance(2)
+	// Unordered list instance D (same bullet list, numbering should be independent)
+	bulD := rd.NewListInstance(docx.BulletListID)
 	p = rd.AddParagraph("Bullet D 1")
 	p.Numbering(bulD, 0)
 	p = rd.AddParagraph("Bullet D 2")
@@ -130,30 +132,28 @@ func TestGenerateNumberingDocxSample(t *testing.T) {
 		t.Fatalf("numbering.xml missing bullet instance D (202)")
 	}
 
-	// Verify restart override for ordered instances
-	if !strings.Contains(numberingXML, `w:num w:numId="`+strconv.Itoa(ordA)+`"`) ||
-		!strings.Contains(numberingXML, `<w:startOverride w:val="1"/>`) {
-		t.Fatalf("ordered instance A missing startOverride")
+	// Verify restart override for the second ordered instance
+	if !strings.Contains(numberingXML, `<w:num w:numId="`+strconv.Itoa(ordA)+`"><w:abstractNumId w:val="201"></w:abstractNumId></w:num>`) {
+		t.Fatalf("ordered instance A should not override its start")
 	}
-	if !strings.Contains(numberingXML, `w:num w:numId="`+strconv.Itoa(ordB)+`"`) ||
-		!strings.Contains(numberingXML, `<w:startOverride w:val="1"/>`) {
+	if !strings.Contains(numberingXML, `<w:num w:numId="`+strconv.Itoa(ordB)+`"><w:abstractNumId w:val="201"></w:abstractNumId><w:lvlOverride w:ilvl="0"><w:startOverride w:val="1"></w:startOverride></w:lvlOverride></w:num>`) {
 		t.Fatalf("ordered instance B missing startOverride")
 	}
 
 	// Verify multilevel formats for ordered abstract 201
 	if !strings.Contains(numberingXML, `<w:abstractNum w:abstractNumId="201"`) ||
-		!strings.Contains(numberingXML, `<w:lvl w:ilvl="0"><w:start w:val="1"/><w:numFmt w:val="decimal"/>`) ||
-		!strings.Contains(numberingXML, `<w:lvl w:ilvl="1"><w:start w:val="1"/><w:numFmt w:val="lowerLetter"/>`) ||
-		!strings.Contains(numberingXML, `<w:lvl w:ilvl="2"><w:start w:val="1"/><w:numFmt w:val="lowerRoman"/>`) {
+		!strings.Contains(numberingXML, `<w:lvl w:ilvl="0"><w:start w:val="1"></w:start><w:numFmt w:val="decimal"></w:numFmt>`) ||
+		!strings.Contains(numberingXML, `<w:lvl w:ilvl="1"><w:start w:val="1"></w:start><w:numFmt w:val="lowerLetter"></w:numFmt>`) ||
+		!strings.Contains(numberingXML, `<w:lvl w:ilvl="2"><w:start w:val="1"></w:start><w:numFmt w:val="lowerRoman"></w:numFmt>`) {
 		t.Fatalf("ordered abstract 201 missing expected numFmt per level")
 	}
 
 	// Verify bullet glyphs/fonts for abstract 202 (levels 0..3)
 	if !strings.Contains(numberingXML, `<w:abstractNum w:abstractNumId="202"`) ||
-		!strings.Contains(numberingXML, `<w:lvl w:ilvl="0"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val=""/>`) ||
-		!strings.Contains(numberingXML, `<w:lvl w:ilvl="1"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val="○"/>`) ||
-		!strings.Contains(numberingXML, `<w:lvl w:ilvl="2"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val="■"/>`) ||
-		!strings.Contains(numberingXML, `<w:lvl w:ilvl="3"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val="♦"/>`) {
+		!strings.Contains(numberingXML, `<w:lvl w:ilvl="0"><w:start w:val="1"></w:start><w:numFmt w:val="bullet"></w:numFmt><w:lvlText w:val=""></w:lvlText>`) ||
+		!strings.Contains(numberingXML, `<w:lvl w:ilvl="1"><w:start w:val="1"></w:start><w:numFmt w:val="bullet"></w:numFmt><w:lvlText w:val="○"></w:lvlText>`) ||
+		!strings.Contains(numberingXML, `<w:lvl w:ilvl="2"><w:start w:val="1"></w:start><w:numFmt w:val="bullet"></w:numFmt><w:lvlText w:val="■"></w:lvlText>`) ||
+		!strings.Contains(numberingXML, `<w:lvl w:ilvl="3"><w:start w:val="1"></w:start><w:numFmt w:val="bullet"></w:numFmt><w:lvlText w:val="♦"></w:lvlText>`) {
 		t.Fatalf("bullet abstract 202 missing expected glyphs per level")
 	}
 
@@ -168,3 +168,71 @@ func TestGenerateNumberingDocxSample(t *testing.T) {
 		t.Fatalf("document.xml missing nested level paragraphs (ilvl=1/2/3)")
 	}
 }
+
+// savedParts saves the document and returns the content of the parts of the saved package.
+func savedParts(t *testing.T, doc *docx.RootDoc) map[string]string {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, doc.Write(&buf))
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		content, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		require.NoError(t, rc.Close())
+		parts[f.Name] = string(content)
+	}
+	return parts
+}
+
+func TestNumbering_TemplateAbstracts(t *testing.T) {
+	doc, err := godocx.NewDocument()
+	require.NoError(t, err)
+
+	// The abstract numberings 1 and 2 of the template are its own
+	template := doc.NewListInstance(1)
+	generated := doc.NewListInstance(docx.DecimalListID)
+	doc.AddParagraph("Template").Numbering(template, 0)
+	doc.AddParagraph("Generated").Numbering(generated, 0)
+
+	numbering := savedParts(t, doc)["word/numbering.xml"]
+	require.Contains(t, numbering, `<w:num w:numId="`+strconv.Itoa(template)+`"><w:abstractNumId w:val="1"></w:abstractNumId>`)
+	require.Contains(t, numbering, `<w:num w:numId="`+strconv.Itoa(generated)+`"><w:abstractNumId w:val="201"></w:abstractNumId></w:num>`)
+}
+
+func TestNumbering_CreatesNumberingPart(t *testing.T) {
+	doc, err := godocx.NewDocument()
+	require.NoError(t, err)
+	doc.FileMap.Delete("word/numbering.xml")
+	doc.Document.DocRels.Relationships = slices.DeleteFunc(doc.Document.DocRels.Relationships, func(rel *docx.Relationship) bool {
+		return strings.HasSuffix(rel.Type, "/numbering")
+	})
+	doc.ContentType.Override = slices.DeleteFunc(doc.ContentType.Override, func(o docx.Override) bool {
+		return o.PartName == "/word/numbering.xml"
+	})
+
+	doc.AddBulletList().Item("Apples")
+	parts := savedParts(t, doc)
+	require.Contains(t, parts["word/numbering.xml"], `<w:abstractNum w:abstractNumId="202"`)
+	require.Contains(t, parts["word/_rels/document.xml.rels"], `Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/numbering" Target="numbering.xml"`)
+	require.Contains(t, parts["[Content_Types].xml"], `<Override PartName="/word/numbering.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.numbering+xml">`)
+}
+
+func TestNumbering_ReadOnlyDefinitions(t *testing.T) {
+	doc, err := godocx.NewDocument()
+	require.NoError(t, err)
+	original := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` +
+		`<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:w15="http://schemas.microsoft.com/office/word/2012/wordml">` +
+		`<w:abstractNum w:abstractNumId="0" w15:restartNumberingAfterBreak="0"><w:lvl w:ilvl="0"><w:start w:val="1"/><w:numFmt w:val="decimal"/>` +
+		`<w:lvlText w:val="%1."/><w:legacy w:legacy="1" w:legacySpace="0" w:legacyIndent="360"/></w:lvl></w:abstractNum>` +
+		`<w:num w:numId="1"><w:abstractNumId w:val="0"/></w:num><w:numIdMacAtCleanup w:val="1"/></w:numbering>`
+	doc.FileMap.Store("word/numbering.xml", []byte(original))
+
+	defs, err := doc.NumberingDefinitions()
+	require.NoError(t, err)
+	require.Len(t, defs.AbstractNums, 1)
+	require.Equal(t, original, savedParts(t, doc)["word/numbering.xml"])
+}
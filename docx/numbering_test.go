@@ -4,6 +4,9 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
 )
 
 func TestNewListInstance(t *testing.T) {
@@ -160,24 +163,79 @@ func TestNumbering_MultilevelAndBulletsXML(t *testing.T) {
 		t.Fatalf("bullet instance C missing or not mapped to 202")
 	}
 
-	// Restart overrides exist
-	if !strings.Contains(numberingXML, `<w:startOverride w:val="1"/>`) {
-		t.Fatalf("startOverride missing for instances")
+	// Only the second instance of an abstract restarts its numbering
+	if !strings.Contains(numberingXML, `<w:num w:numId="`+strconv.Itoa(ordA)+`"><w:abstractNumId w:val="201"></w:abstractNumId></w:num>`) {
+		t.Fatalf("first instance A should not override its start")
+	}
+	if !strings.Contains(numberingXML, `<w:num w:numId="`+strconv.Itoa(ordB)+`"><w:abstractNumId w:val="201"></w:abstractNumId><w:lvlOverride w:ilvl="0"><w:startOverride w:val="1"></w:startOverride></w:lvlOverride></w:num>`) {
+		t.Fatalf("startOverride missing for instance B")
 	}
 
 	// Ordered abstract formats
 	if !strings.Contains(numberingXML, `<w:abstractNum w:abstractNumId="201"`) ||
-		!strings.Contains(numberingXML, `<w:lvl w:ilvl="1"><w:start w:val="1"/><w:numFmt w:val="lowerLetter"/>`) ||
-		!strings.Contains(numberingXML, `<w:lvl w:ilvl="2"><w:start w:val="1"/><w:numFmt w:val="lowerRoman"/>`) {
+		!strings.Contains(numberingXML, `<w:lvl w:ilvl="1"><w:start w:val="1"></w:start><w:numFmt w:val="lowerLetter"></w:numFmt>`) ||
+		!strings.Contains(numberingXML, `<w:lvl w:ilvl="2"><w:start w:val="1"></w:start><w:numFmt w:val="lowerRoman"></w:numFmt>`) {
 		t.Fatalf("ordered abstract 201 missing expected formats")
 	}
 
 	// Bullet glyphs (disc, hollow circle, square, diamond)
 	if !strings.Contains(numberingXML, `<w:abstractNum w:abstractNumId="202"`) ||
-		!strings.Contains(numberingXML, `<w:lvl w:ilvl="0"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val=""/>`) ||
-		!strings.Contains(numberingXML, `<w:lvl w:ilvl="1"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val="○"/>`) ||
-		!strings.Contains(numberingXML, `<w:lvl w:ilvl="2"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val="■"/>`) ||
-		!strings.Contains(numberingXML, `<w:lvl w:ilvl="3"><w:start w:val="1"/><w:numFmt w:val="bullet"/><w:lvlText w:val="♦"/>`) {
+		!strings.Contains(numberingXML, `<w:lvl w:ilvl="0"><w:start w:val="1"></w:start><w:numFmt w:val="bullet"></w:numFmt><w:lvlText w:val=""></w:lvlText>`) ||
+		!strings.Contains(numberingXML, `<w:lvl w:ilvl="1"><w:start w:val="1"></w:start><w:numFmt w:val="bullet"></w:numFmt><w:lvlText w:val="○"></w:lvlText>`) ||
+		!strings.Contains(numberingXML, `<w:lvl w:ilvl="2"><w:start w:val="1"></w:start><w:numFmt w:val="bullet"></w:numFmt><w:lvlText w:val="■"></w:lvlText>`) ||
+		!strings.Contains(numberingXML, `<w:lvl w:ilvl="3"><w:start w:val="1"></w:start><w:numFmt w:val="bullet"></w:numFmt><w:lvlText w:val="♦"></w:lvlText>`) {
 		t.Fatalf("bullet abstract 202 missing expected glyphs")
 	}
 }
+
+func TestNumberingDefinitions(t *testing.T) {
+	rd := NewRootDoc()
+	original := `<?xml version="1.0" encoding="UTF-8"?>` +
+		`<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:abstractNum w:abstractNumId="0"><w:lvl w:ilvl="0"><w:start w:val="1"/><w:numFmt w:val="decimal"/><w:lvlText w:val="%1."/></w:lvl></w:abstractNum>` +
+		`<w:num w:numId="1"><w:abstractNumId w:val="0"/></w:num>` +
+		`</w:numbering>`
+	rd.FileMap.Store(numberingPath, []byte(original))
+
+	// A numbering part that is not changed is written back as it was
+	if err := rd.Numbering.applyToFileMap(); err != nil {
+		t.Fatalf("apply numbering: %v", err)
+	}
+	if content, _ := rd.FileMap.Load(numberingPath); string(content.([]byte)) != original {
+		t.Fatalf("unchanged numbering part rewritten: %s", content)
+	}
+
+	defs, err := rd.NumberingDefinitions()
+	if err != nil {
+		t.Fatalf("numbering definitions: %v", err)
+	}
+	level := defs.AbstractNumByID(0).Level(0)
+	if level == nil || level.LvlText.Val != "%1." {
+		t.Fatalf("level 0 of abstract 0 not parsed: %+v", level)
+	}
+	level.NumFmt = ctypes.NewGenSingleStrVal(stypes.NumFmtUpperRoman)
+
+	// The first instance of the template abstract keeps counting, the second one restarts
+	numID := rd.NewListInstance(0)
+	if numID != 2 || defs.NumByID(numID).Override(0).StartOverride.Val != 1 {
+		t.Fatalf("instance %d should restart its numbering: %+v", numID, defs.NumByID(numID))
+	}
+
+	first := savedNumbering(t, rd)
+	if !strings.Contains(first, `<w:numFmt w:val="upperRoman"></w:numFmt>`) {
+		t.Fatalf("edited level not saved: %s", first)
+	}
+	rd.Numbering = NewNumberingManager(rd)
+	if _, err := rd.NumberingDefinitions(); err != nil {
+		t.Fatalf("numbering definitions: %v", err)
+	}
+	if second := savedNumbering(t, rd); second != first {
+		t.Fatalf("numbering not written deterministically:\n%s\n%s", first, second)
+	}
+
+	rd.FileMap.Store(numberingPath, []byte(`<w:numbering><w:num w:numId="x"/></w:numbering>`))
+	rd.Numbering = NewNumberingManager(rd)
+	if _, err := rd.NumberingDefinitions(); err == nil {
+		t.Fatalf("expected an error for an invalid numbering part")
+	}
+}
@@ -26,10 +26,14 @@ import (
 type Paragraph struct {
 	root *RootDoc         // root is a reference to the root document.
 	ct   ctypes.Paragraph // ct holds the underlying Paragraph Complex Type.
+
+	// ref, when set, is the paragraph of a table cell, header or other part the Paragraph
+	// stands for, used instead of ct.
+	ref *ctypes.Paragraph
 }
 
 func (p *Paragraph) unmarshalXML(d *xml.Decoder, start xml.StartElement) error {
-	return p.ct.UnmarshalXML(d, start)
+	return p.GetCT().UnmarshalXML(d, start)
 }
 
 type paraOpts struct {
@@ -62,13 +66,16 @@ func paraWithText(text string) paraOption {
 }
 
 func (p *Paragraph) ensureProp() {
-	if p.ct.Property == nil {
-		p.ct.Property = ctypes.DefaultParaProperty()
+	if p.GetCT().Property == nil {
+		p.GetCT().Property = ctypes.DefaultParaProperty()
 	}
 }
 
 // GetCT returns a pointer to the underlying Paragraph Complex Type.
 func (p *Paragraph) GetCT() *ctypes.Paragraph {
+	if p.ref != nil {
+		return p.ref
+	}
 	return &p.ct
 }
 
@@ -97,7 +104,7 @@ func (rd *RootDoc) AddParagraph(text string) *Paragraph {
 */
 func (p *Paragraph) Spacing(before uint64, after uint64) {
 	p.ensureProp()
-	p.ct.Property.Spacing = ctypes.NewParagraphSpacing(before, after)
+	p.GetCT().Property.Spacing = ctypes.NewParagraphSpacing(before, after)
 }
 
 // Style sets the paragraph style.
@@ -111,7 +118,7 @@ func (p *Paragraph) Spacing(before uint64, after uint64) {
 //	paragraph.Style("List Number")
 func (p *Paragraph) Style(value string) {
 	p.ensureProp()
-	p.ct.Property.Style = ctypes.NewParagraphStyle(value)
+	p.GetCT().Property.Style = ctypes.NewParagraphStyle(value)
 }
 
 // Justification sets the paragraph justification type.
@@ -127,7 +134,7 @@ func (p *Paragraph) Style(value string) {
 func (p *Paragraph) Justification(value stypes.Justification) {
 	p.ensureProp()
 
-	p.ct.Property.Justification = ctypes.NewGenSingleStrVal(value)
+	p.GetCT().Property.Justification = ctypes.NewGenSingleStrVal(value)
 }
 
 // Numbering sets the paragraph numbering properties.
@@ -150,12 +157,12 @@ func (p *Paragraph) Numbering(id int, level int) {
 
 	p.ensureProp()
 
-	if p.ct.Property.NumProp == nil {
-		p.ct.Property.NumProp = &ctypes.NumProp{}
+	if p.GetCT().Property.NumProp == nil {
+		p.GetCT().Property.NumProp = &ctypes.NumProp{}
 	}
 
-	p.ct.Property.NumProp.NumID = ctypes.NewDecimalNum(id)
-	p.ct.Property.NumProp.ILvl = ctypes.NewDecimalNum(level)
+	p.GetCT().Property.NumProp.NumID = ctypes.NewDecimalNum(id)
+	p.GetCT().Property.NumProp.ILvl = ctypes.NewDecimalNum(level)
 }
 
 // Indent sets the paragraph indentation properties.
@@ -179,7 +186,7 @@ func (p *Paragraph) Indent(indentProp *ctypes.Indent) {
 
 	p.ensureProp()
 
-	p.ct.Property.Indent = indentProp
+	p.GetCT().Property.Indent = indentProp
 }
 
 // AddText appends a new text to the Paragraph.
@@ -204,9 +211,9 @@ func (p *Paragraph) AddText(text string) *Run {
 		Children: runChildren,
 	}
 
-	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Run: run})
+	p.GetCT().Children = append(p.GetCT().Children, ctypes.ParagraphChild{Run: run})
 
-	return newRun(p.root, run)
+	return newRun(p.root, p.GetCT(), run)
 }
 
 // AddEmptyParagraph adds a new empty paragraph to the document.
@@ -229,9 +236,9 @@ func (p *Paragraph) AddRun() *Run {
 
 	run := &ctypes.Run{}
 
-	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Run: run})
+	p.GetCT().Children = append(p.GetCT().Children, ctypes.ParagraphChild{Run: run})
 
-	return newRun(p.root, run)
+	return newRun(p.root, p.GetCT(), run)
 }
 
 // GetStyle retrieves the style information applied to the Paragraph.
@@ -240,11 +247,11 @@ func (p *Paragraph) AddRun() *Run {
 //   - *ctypes.Style: The style information of the Paragraph.
 //   - error: An error if the style information is not found.
 func (p *Paragraph) GetStyle() (*ctypes.Style, error) {
-	if p.ct.Property == nil || p.ct.Property.Style == nil {
+	if p.GetCT().Property == nil || p.GetCT().Property.Style == nil {
 		return nil, errors.New("No property for the style")
 	}
 
-	style := p.root.GetStyleByID(p.ct.Property.Style.Val, stypes.StyleTypeParagraph)
+	style := p.root.GetStyleByID(p.GetCT().Property.Style.Val, stypes.StyleTypeParagraph)
 	if style == nil {
 		return nil, errors.New("No style found for the paragraph")
 	}
@@ -269,16 +276,16 @@ func (p *Paragraph) AddLink(text string, link string) *Hyperlink {
 	}
 
 	hyperLink := &ctypes.Hyperlink{
-		ID:  rId,
-		Run: run,
+		ID:       rId,
+		Children: []ctypes.ParagraphChild{{Run: run}},
 	}
 
-	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Link: hyperLink})
+	p.GetCT().Children = append(p.GetCT().Children, ctypes.ParagraphChild{Link: hyperLink})
 
 	return newHyperlink(p.root, hyperLink)
 }
 
-// AddDrawing adds a new drawing (image) to the Paragraph.
+// newDrawingRun creates a new run holding a drawing (image).
 //
 // Parameters:
 //   - rID: The relationship ID of the image in the document.
@@ -287,8 +294,9 @@ func (p *Paragraph) AddLink(text string, link string) *Hyperlink {
 //   - height: The height of the image in inches.
 //
 // Returns:
+//   - *ctypes.Run: The created run containing the drawing.
 //   - *dml.Inline: The created Inline instance representing the added drawing.
-func (p *Paragraph) addDrawing(rID string, imgCount uint, width, height units.Units) *dml.Inline {
+func newDrawingRun(rID string, imgCount uint, width, height units.Units) (*ctypes.Run, *dml.Inline) {
 	eWidth := width.ToEmu()
 	eHeight := height.ToEmu()
 
@@ -314,9 +322,25 @@ func (p *Paragraph) addDrawing(rID string, imgCount uint, width, height units.Un
 		Children: runChildren,
 	}
 
-	p.ct.Children = append(p.ct.Children, ctypes.ParagraphChild{Run: run})
+	return run, &drawing.Inline[0]
+}
 
-	return &inline
+// addDrawing adds a new drawing (image) to the Paragraph.
+//
+// Parameters:
+//   - rID: The relationship ID of the image in the document.
+//   - imgCount: The count of images in the document.
+//   - width: The width of the image in inches.
+//   - height: The height of the image in inches.
+//
+// Returns:
+//   - *dml.Inline: The created Inline instance representing the added drawing.
+func (p *Paragraph) addDrawing(rID string, imgCount uint, width, height units.Units) *dml.Inline {
+	run, inline := newDrawingRun(rID, imgCount, width, height)
+
+	p.GetCT().Children = append(p.GetCT().Children, ctypes.ParagraphChild{Run: run})
+
+	return inline
 }
 
 // AddPictureFromFile adds a picture to the paragraph, use (rd *RootDoc) AddPictureFromFile
@@ -333,26 +357,34 @@ func (p *Paragraph) AddPictureFromFile(path string, width units.Inch, height uni
 // into new paragraph. If the height or width are nil then assume page width
 // If either the height or width is nil then preserve the aspect ratio
 func (p *Paragraph) AddImage(imgBytes []byte, width, height units.Units) (pm *PicMeta, err error) {
-	imgMIME := http.DetectContentType(imgBytes)
-	if !strings.HasPrefix(imgMIME, "image") {
-		if bytes.HasPrefix(imgBytes, []byte("<svg")) {
-			imgMIME = "image/svg+xml"
-		} else {
-			return nil, fmt.Errorf("unknown content type (%s)", imgMIME)
-		}
+	run, inline, err := p.root.newImageRun(imgBytes, width, height)
+	if err != nil {
+		return nil, err
 	}
-	var extensionTypes []string
-	if extensionTypes, err = mime.ExtensionsByType(imgMIME); err != nil || len(extensionTypes) == 0 {
-		return nil, fmt.Errorf("cannot determine extension for mime type (%s)", imgMIME)
+
+	p.GetCT().Children = append(p.GetCT().Children, ctypes.ParagraphChild{Run: run})
+
+	return &PicMeta{
+		Para:   p,
+		Inline: inline,
+	}, nil
+}
+
+// newImageRun stores the image in the document package, registers its content type and
+// relationship and returns a run holding the drawing. If the height or width are nil then
+// assume page width. If either the height or width is nil then preserve the aspect ratio
+func (rd *RootDoc) newImageRun(imgBytes []byte, width, height units.Units) (run *ctypes.Run, inline *dml.Inline, err error) {
+	imgMIME, imgExt, err := imageType(imgBytes)
+	if err != nil {
+		return nil, nil, err
 	}
-	imgExt := strings.TrimPrefix(extensionTypes[0], ".")
 
 	// If no dimensions are specified then try and determine the dimensions from the image
 	//
 	// If one is nil then set the dimension of the other to keep aspect ratio
 	if width == nil || height == nil {
 		// Until we add sections just use A4 less .5 inch margins as the maximum size of an image
-		maxWidth, maxHeight := p.root.UsableTextArea()
+		maxWidth, maxHeight := rd.UsableTextArea()
 		if width != nil {
 			maxWidth = width.ToTwip()
 		}
@@ -362,7 +394,7 @@ func (p *Paragraph) AddImage(imgBytes []byte, width, height units.Units) (pm *Pi
 		var img image.Image
 		img, _, err = image.Decode(bytes.NewReader(imgBytes))
 		if err != nil {
-			return nil, fmt.Errorf("cannot decode image to determine height and width")
+			return nil, nil, fmt.Errorf("cannot decode image to determine height and width")
 		}
 		scaleX := float64(maxWidth.ToEmu()) / float64(img.Bounds().Dx())
 		scaleY := float64(maxHeight.ToEmu()) / float64(img.Bounds().Dy())
@@ -375,29 +407,60 @@ func (p *Paragraph) AddImage(imgBytes []byte, width, height units.Units) (pm *Pi
 		}
 	}
 
-	p.root.ImageCount += 1
-	fileName := fmt.Sprintf("image%d.%s", p.root.ImageCount, imgExt)
-	fileIdxPath := fmt.Sprintf("%s%s", constants.MediaPath, fileName)
+	rID, err := rd.addImagePart(imgBytes, imgExt, imgMIME)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	if err = p.root.ContentType.AddExtension(imgExt, imgMIME); err != nil {
-		return nil, err
+	run, inline = newDrawingRun(rID, rd.ImageCount, width, height)
+
+	return run, inline, nil
+}
+
+// imageType returns the MIME type and the file extension of the image.
+func imageType(imgBytes []byte) (imgMIME, imgExt string, err error) {
+	imgMIME = http.DetectContentType(imgBytes)
+	if !strings.HasPrefix(imgMIME, "image") {
+		if bytes.HasPrefix(imgBytes, []byte("<svg")) {
+			imgMIME = "image/svg+xml"
+		} else {
+			return "", "", fmt.Errorf("unknown content type (%s)", imgMIME)
+		}
 	}
+	extensionTypes, err := mime.ExtensionsByType(imgMIME)
+	if err != nil || len(extensionTypes) == 0 {
+		return "", "", fmt.Errorf("cannot determine extension for mime type (%s)", imgMIME)
+	}
+	return imgMIME, strings.TrimPrefix(extensionTypes[0], "."), nil
+}
 
-	overridePart := fmt.Sprintf("/%s%s", constants.MediaPath, fileName)
-	if err = p.root.ContentType.AddOverride(overridePart, imgMIME); err != nil {
-		return nil, err
+// addImagePart stores the image in the media folder of the package under the next image
+// number, registers its content type and returns the ID of the relationship to it.
+func (rd *RootDoc) addImagePart(imgBytes []byte, imgExt, imgMIME string) (string, error) {
+	relName, err := rd.storeImage(imgBytes, imgExt, imgMIME)
+	if err != nil {
+		return "", err
 	}
+	return rd.Document.addRelation(constants.SourceRelationshipImage, relName), nil
+}
 
-	p.root.FileMap.Store(fileIdxPath, imgBytes)
+// storeImage stores the image in the media folder of the package under the next image number,
+// registers its content type and returns its path relative to the word folder.
+func (rd *RootDoc) storeImage(imgBytes []byte, imgExt, imgMIME string) (string, error) {
+	rd.ImageCount += 1
+	fileName := fmt.Sprintf("image%d.%s", rd.ImageCount, imgExt)
+	fileIdxPath := fmt.Sprintf("%s%s", constants.MediaPath, fileName)
 
-	relName := fmt.Sprintf("media/%s", fileName)
+	if err := rd.ContentType.AddExtension(imgExt, imgMIME); err != nil {
+		return "", err
+	}
 
-	rID := p.root.Document.addRelation(constants.SourceRelationshipImage, relName)
+	overridePart := fmt.Sprintf("/%s%s", constants.MediaPath, fileName)
+	if err := rd.ContentType.AddOverride(overridePart, imgMIME); err != nil {
+		return "", err
+	}
 
-	inline := p.addDrawing(rID, p.root.ImageCount, width, height)
+	rd.FileMap.Store(fileIdxPath, imgBytes)
 
-	return &PicMeta{
-		Para:   p,
-		Inline: inline,
-	}, nil
+	return fmt.Sprintf("media/%s", fileName), nil
 }
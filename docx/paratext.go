@@ -0,0 +1,220 @@
+package docx
+
+import (
+	"slices"
+	"strings"
+
+	"godocx/internal"
+	"godocx/wml/ctypes"
+)
+
+// textSeg locates one w:t element within the logical text of a paragraph.
+type textSeg struct {
+	owner *[]ctypes.ParagraphChild // children holding the run, nil for the single run of a hyperlink
+	link  *ctypes.Hyperlink        // hyperlink holding the run, if any
+	ins   *ctypes.RunTrackChange   // tracked insertion holding the run, if any
+	run   *ctypes.Run              // run holding the text
+	child int                      // index of the run within the owner, or within the insertion
+	rc    int                      // index of the text within the run children
+	text  *ctypes.Text             // the text element itself
+	start int                      // byte offset of the text within the logical paragraph text
+	end   int                      // byte offset just after the text
+}
+
+// paraSegments returns the text segments of the runs of the paragraph, including the
+// runs of hyperlinks and tracked insertions, together with the logical text they form when
+// joined.
+func paraSegments(p *ctypes.Paragraph) (segs []textSeg, text string) {
+	var sb strings.Builder
+	addRun := func(owner *[]ctypes.ParagraphChild, link *ctypes.Hyperlink, ins *ctypes.RunTrackChange, ci int, run *ctypes.Run) {
+		for rc, runChild := range run.Children {
+			if runChild.Text == nil {
+				continue
+			}
+			start := sb.Len()
+			sb.WriteString(runChild.Text.Text)
+			segs = append(segs, textSeg{
+				owner: owner,
+				link:  link,
+				ins:   ins,
+				run:   run,
+				child: ci,
+				rc:    rc,
+				text:  runChild.Text,
+				start: start,
+				end:   sb.Len(),
+			})
+		}
+	}
+	addIns := func(owner *[]ctypes.ParagraphChild, link *ctypes.Hyperlink, ins *ctypes.RunTrackChange) {
+		for ri, run := range ins.Runs {
+			if run != nil {
+				addRun(owner, link, ins, ri, run)
+			}
+		}
+	}
+	for ci, child := range p.Children {
+		if child.Run != nil {
+			addRun(&p.Children, nil, nil, ci, child.Run)
+		}
+		if child.Ins != nil {
+			addIns(&p.Children, nil, child.Ins)
+		}
+		if link := child.Link; link != nil {
+			if link.Run != nil {
+				addRun(nil, link, nil, 0, link.Run)
+			}
+			for li, linkChild := range link.Children {
+				if linkChild.Run != nil {
+					addRun(&link.Children, link, nil, li, linkChild.Run)
+				}
+				if linkChild.Ins != nil {
+					addIns(&link.Children, link, linkChild.Ins)
+				}
+			}
+		}
+	}
+	return segs, sb.String()
+}
+
+// runAt returns the run holding the byte offset of the logical paragraph text.
+func runAt(p *ctypes.Paragraph, pos int) *ctypes.Run {
+	segs, _ := paraSegments(p)
+	for _, seg := range segs {
+		if pos >= seg.start && pos < seg.end {
+			return seg.run
+		}
+	}
+	if len(segs) > 0 {
+		return segs[len(segs)-1].run
+	}
+	return nil
+}
+
+// replaceParaText replaces the logical text between start and end with repl.
+// The replacement inherits the formatting of the run in which the match starts,
+// text from the following runs covered by the match is removed.
+func replaceParaText(p *ctypes.Paragraph, start, end int, repl string) {
+	segs, _ := paraSegments(p)
+	for _, seg := range segs {
+		if start == end {
+			// Pure insertion, place it within the first text covering the offset
+			if start < seg.start || start > seg.end {
+				continue
+			}
+		} else if seg.end <= start || seg.start >= end {
+			continue
+		}
+		old := seg.text.Text
+		from := max(start-seg.start, 0)
+		to := min(end-seg.start, len(old))
+		setText(seg.text, old[:from]+repl+old[to:])
+		repl = ""
+		if start == end {
+			return
+		}
+		start = seg.end
+	}
+	if repl != "" {
+		p.AddText(repl)
+	}
+}
+
+// setText updates the text and keeps the space preservation attribute in step with it.
+func setText(t *ctypes.Text, text string) {
+	t.Text = text
+	if strings.TrimSpace(text) != text {
+		t.Space = internal.ToPtr(ctypes.TextSpacePreserve)
+	}
+}
+
+// insertParaRuns inserts runs at the given byte offset of the logical paragraph text,
+// splitting the run that holds the offset where necessary.
+func insertParaRuns(p *ctypes.Paragraph, pos int, runs ...*ctypes.Run) {
+	children := make([]ctypes.ParagraphChild, 0, len(runs))
+	for _, r := range runs {
+		children = append(children, ctypes.ParagraphChild{Run: r})
+	}
+
+	segs, _ := paraSegments(p)
+	target := -1
+	for i, seg := range segs {
+		if pos >= seg.start && (pos < seg.end || (pos == seg.end && seg.start == seg.end)) {
+			target = i
+			break
+		}
+		if pos == seg.end {
+			// End of this text, unless a following text starts here
+			target = i
+		}
+	}
+	if target < 0 {
+		p.Children = append(p.Children, children...)
+		return
+	}
+
+	seg := segs[target]
+	if seg.ins != nil {
+		// Runs inserted within a tracked insertion are part of it
+		if pos == seg.start && seg.rc == 0 {
+			seg.ins.Runs = slices.Insert(seg.ins.Runs, seg.child, runs...)
+			return
+		}
+		before, after := splitRun(seg, pos)
+		seg.ins.Runs[seg.child] = before
+		if after != nil {
+			runs = append(runs, after)
+		}
+		seg.ins.Runs = slices.Insert(seg.ins.Runs, seg.child+1, runs...)
+		return
+	}
+	if seg.owner == nil {
+		// The single run of a hyperlink, move it into the children so it can be split
+		seg.link.Children = append([]ctypes.ParagraphChild{{Run: seg.link.Run}}, seg.link.Children...)
+		seg.link.Run = nil
+		seg.owner = &seg.link.Children
+	}
+	owner := seg.owner
+	if pos == seg.start && seg.rc == 0 {
+		*owner = insertChildren(*owner, seg.child, children)
+		return
+	}
+
+	before, after := splitRun(seg, pos)
+	(*owner)[seg.child].Run = before
+	if after != nil {
+		children = append(children, ctypes.ParagraphChild{Run: after})
+	}
+	*owner = insertChildren(*owner, seg.child+1, children)
+}
+
+// splitRun splits the run holding the text of the segment in two around the offset of the
+// logical paragraph text. after is nil when nothing follows the offset.
+func splitRun(seg textSeg, pos int) (before, after *ctypes.Run) {
+	offset := pos - seg.start
+	before = &ctypes.Run{Property: seg.run.Property}
+	after = &ctypes.Run{}
+	if seg.run.Property != nil {
+		after.Property = internal.DeepCopy(seg.run.Property)
+	}
+	before.Children = append(before.Children, seg.run.Children[:seg.rc]...)
+	if offset > 0 {
+		before.Children = append(before.Children, ctypes.RunChild{Text: ctypes.TextFromString(seg.text.Text[:offset])})
+	}
+	if offset < len(seg.text.Text) {
+		after.Children = append(after.Children, ctypes.RunChild{Text: ctypes.TextFromString(seg.text.Text[offset:])})
+	}
+	after.Children = append(after.Children, seg.run.Children[seg.rc+1:]...)
+	if len(after.Children) == 0 {
+		after = nil
+	}
+	return before, after
+}
+
+// insertChildren inserts the children into the slice at position idx.
+func insertChildren(list []ctypes.ParagraphChild, idx int, children []ctypes.ParagraphChild) []ctypes.ParagraphChild {
+	result := make([]ctypes.ParagraphChild, 0, len(list)+len(children))
+	result = append(result, list[:idx]...)
+	result = append(result, children...)
+	return append(result, list[idx:]...)
+}
@@ -1,8 +1,14 @@
 package docx
 
 import (
+	"bytes"
+	"image"
 	"os"
+	"path"
+	"path/filepath"
+	"strings"
 
+	"godocx/common/constants"
 	"godocx/common/units"
 	"godocx/dml"
 )
@@ -61,3 +67,64 @@ func (rd *RootDoc) AddImage(imgBytes []byte, width, height units.Units) (pm *Pic
 	rd.Document.Body.Children = append(rd.Document.Body.Children, bodyElem)
 	return p.AddImage(imgBytes, width, height)
 }
+
+// imagePart returns the package path and content of the image part referenced by the given
+// relationship ID. External images are not returned.
+func (rd *RootDoc) imagePart(rID string) (partPath string, content []byte, ok bool) {
+	rel := rd.Document.relationship(rID)
+	if rel == nil || rel.Type != constants.SourceRelationshipImage || rel.TargetMode == "External" {
+		return "", nil, false
+	}
+	partPath = rd.Document.partPath(rel.Target)
+	data, ok := rd.FileMap.Load(partPath)
+	if !ok {
+		return "", nil, false
+	}
+	return partPath, data.([]byte), true
+}
+
+// extractImage writes the content of an image part to dir, which is created if needed, and
+// returns its link: urlPrefix, or dir when it is empty, followed by the name of the part.
+func extractImage(partPath string, content []byte, dir, urlPrefix string) (string, error) {
+	name := path.Base(partPath)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), content, 0o644); err != nil {
+		return "", err
+	}
+
+	if urlPrefix == "" {
+		urlPrefix = filepath.ToSlash(dir)
+	}
+	return strings.TrimSuffix(urlPrefix, "/") + "/" + name, nil
+}
+
+// fittedImageSize returns the size of an image laid out at 96 dpi, scaled down to the width of
+// the page. widthPx and heightPx override the natural size of the image in pixels when they
+// are positive, a single one keeping the aspect ratio. Nil sizes are returned when the size
+// of the image is unknown, leaving it to newImageRun.
+func (rd *RootDoc) fittedImageSize(imgBytes []byte, widthPx, heightPx float64) (width, height units.Units) {
+	if widthPx <= 0 || heightPx <= 0 {
+		cfg, _, err := image.DecodeConfig(bytes.NewReader(imgBytes))
+		switch {
+		case err != nil || cfg.Width <= 0 || cfg.Height <= 0:
+			if widthPx <= 0 || heightPx <= 0 {
+				return nil, nil
+			}
+		case widthPx > 0:
+			heightPx = widthPx * float64(cfg.Height) / float64(cfg.Width)
+		case heightPx > 0:
+			widthPx = heightPx * float64(cfg.Width) / float64(cfg.Height)
+		default:
+			widthPx, heightPx = float64(cfg.Width), float64(cfg.Height)
+		}
+	}
+
+	w, h := float64(units.Inch(widthPx/96).ToEmu()), float64(units.Inch(heightPx/96).ToEmu())
+	if maxWidth, _ := rd.UsableTextArea(); maxWidth > 0 && w > float64(maxWidth.ToEmu()) {
+		h = h * float64(maxWidth.ToEmu()) / w
+		w = float64(maxWidth.ToEmu())
+	}
+	return units.Emu(w), units.Emu(h)
+}
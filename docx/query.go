@@ -0,0 +1,151 @@
+package docx
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"godocx/wml/stypes"
+)
+
+// ParagraphFilter reports whether a paragraph is selected by RootDoc.FindParagraphs.
+type ParagraphFilter func(p *Paragraph) bool
+
+// ByStyle selects the paragraphs of the paragraph style with the given ID or name, the name
+// being compared ignoring case. Built-in styles are also selected through their canonical ID
+// or name, such as Heading1 for a heading style with a localised ID. Paragraphs without style
+// have the default paragraph style.
+func ByStyle(style string) ParagraphFilter {
+	return func(p *Paragraph) bool {
+		styleID := paraStyleID(p.GetCT())
+		if styleID == "" && p.root != nil {
+			styleID = p.root.defaultStyleID(stypes.StyleTypeParagraph)
+		}
+		if styleID == style {
+			return true
+		}
+		if p.root == nil {
+			return false
+		}
+		s := p.root.lookupStyle(style, stypes.StyleTypeParagraph)
+		return s != nil && s.ID != nil && *s.ID == styleID
+	}
+}
+
+// ContainingText selects the paragraphs whose text contains the given text.
+func ContainingText(text string) ParagraphFilter {
+	return func(p *Paragraph) bool {
+		return strings.Contains(p.Text(), text)
+	}
+}
+
+// MatchingText selects the paragraphs whose text matches the regular expression.
+func MatchingText(re *regexp.Regexp) ParagraphFilter {
+	return func(p *Paragraph) bool {
+		return re.MatchString(p.Text())
+	}
+}
+
+// FindParagraphs returns the paragraphs of the document body selected by all the filters in
+// document order, including the paragraphs of table cells. The returned paragraphs may be
+// modified in place.
+//
+// Example:
+//
+//	for _, p := range document.FindParagraphs(docx.ByStyle("Heading2"), docx.ContainingText("Total")) {
+//		p.Style("Heading1")
+//	}
+func (rd *RootDoc) FindParagraphs(filters ...ParagraphFilter) []*Paragraph {
+	var found []*Paragraph
+paragraphs:
+	for p := range rd.Paragraphs() {
+		for _, filter := range filters {
+			if !filter(p) {
+				continue paragraphs
+			}
+		}
+		found = append(found, p)
+	}
+	return found
+}
+
+// TableAfterHeading returns the first table of the document body following the heading with
+// the given text, before the next heading of the same or a higher level. Leading and trailing
+// white space of the heading text is ignored. It returns nil when there is no such table.
+//
+// Example:
+//
+//	if tbl := document.TableAfterHeading("Pricing"); tbl != nil {
+//		tbl.AddRow().AddCell().AddParagraph("Support")
+//	}
+func (rd *RootDoc) TableAfterHeading(heading string) *Table {
+	heading = strings.TrimSpace(heading)
+	level := -1
+	for _, child := range rd.Document.Body.Children {
+		if child.Table != nil && level >= 0 {
+			return child.Table
+		}
+		if child.Para == nil {
+			continue
+		}
+
+		childLevel := rd.headingLevel(child.Para.GetCT())
+		if childLevel < 0 {
+			continue
+		}
+		if strings.TrimSpace(child.Para.Text()) == heading {
+			level = childLevel
+		} else if level >= 0 && childLevel <= level {
+			level = -1
+		}
+	}
+	return nil
+}
+
+// ParagraphsBetween returns the paragraphs of the document body following the paragraph
+// holding the start of the bookmark named from and preceding the paragraph holding the start
+// of the bookmark named to, including the paragraphs of table cells. It returns an error when
+// a bookmark is missing or when to comes before from.
+//
+// Example:
+//
+//	paras, err := document.ParagraphsBetween("ClausesStart", "ClausesEnd")
+func (rd *RootDoc) ParagraphsBetween(from, to string) ([]*Paragraph, error) {
+	var found []*Paragraph
+	started := false
+	for p := range rd.Paragraphs() {
+		names := p.bookmarks()
+		if started && names[to] {
+			return found, nil
+		}
+		if started {
+			found = append(found, p)
+			continue
+		}
+		if names[from] {
+			started = true
+			if names[to] {
+				return nil, nil
+			}
+			continue
+		}
+		if names[to] {
+			return nil, fmt.Errorf("bookmark %q comes before bookmark %q", to, from)
+		}
+	}
+	if started {
+		return nil, fmt.Errorf("bookmark %q not found", to)
+	}
+	return nil, fmt.Errorf("bookmark %q not found", from)
+}
+
+// bookmarks returns the names of the bookmarks starting in the paragraph.
+func (p *Paragraph) bookmarks() map[string]bool {
+	names := map[string]bool{}
+	for _, child := range p.GetCT().Children {
+		if child.BookmarkStart != nil {
+			names[child.BookmarkStart.Name] = true
+		}
+	}
+	return names
+}
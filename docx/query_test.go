@@ -0,0 +1,105 @@
+package docx
+
+import (
+	"regexp"
+	"testing"
+
+	"godocx/internal"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func queryTestDoc(t *testing.T) *RootDoc {
+	rd := setupRootDoc(t)
+	for _, id := range []string{"Heading1", "Heading2"} {
+		rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, ctypes.Style{
+			ID:   internal.ToPtr(id),
+			Name: &ctypes.CTString{Val: "heading " + id[len(id)-1:]},
+			Type: internal.ToPtr(stypes.StyleTypeParagraph),
+		})
+	}
+
+	_, err := rd.AddHeading("Overview", 1)
+	require.NoError(t, err)
+	start := rd.AddParagraph("Intro")
+	start.ct.Children = append([]ctypes.ParagraphChild{{BookmarkStart: &ctypes.BookmarkStart{ID: 1, Name: "from"}}}, start.GetCT().Children...)
+	rd.AddParagraph("Total so far")
+	_, err = rd.AddHeading("Pricing", 2)
+	require.NoError(t, err)
+	rd.AddParagraph("Prices are:")
+	tbl := rd.AddTable()
+	tbl.AddRow().AddCell().AddParagraph("Total: 10")
+	end := rd.AddParagraph("Closing")
+	end.ct.Children = append(end.GetCT().Children, ctypes.ParagraphChild{BookmarkStart: &ctypes.BookmarkStart{ID: 2, Name: "to"}})
+	_, err = rd.AddHeading("Total", 2)
+	require.NoError(t, err)
+	return rd
+}
+
+func texts(paras []*Paragraph) []string {
+	var texts []string
+	for _, p := range paras {
+		texts = append(texts, p.Text())
+	}
+	return texts
+}
+
+func TestFindParagraphs(t *testing.T) {
+	rd := queryTestDoc(t)
+
+	assert.Equal(t, []string{"Pricing", "Total"}, texts(rd.FindParagraphs(ByStyle("Heading2"))))
+	assert.Equal(t, []string{"Overview"}, texts(rd.FindParagraphs(ByStyle("Heading 1"))))
+	assert.Equal(t, []string{"Total"}, texts(rd.FindParagraphs(ByStyle("Heading2"), ContainingText("Total"))))
+	assert.Equal(t, []string{"Total so far", "Total: 10", "Total"}, texts(rd.FindParagraphs(ContainingText("Total"))))
+	assert.Equal(t, []string{"Total: 10"}, texts(rd.FindParagraphs(MatchingText(regexp.MustCompile(`\d+$`)))))
+
+	// Found paragraphs are modified in place
+	for _, p := range rd.FindParagraphs(ContainingText("Total:")) {
+		p.AddText(" EUR")
+	}
+	assert.Equal(t, []string{"Total: 10 EUR"}, texts(rd.FindParagraphs(ContainingText("EUR"))))
+}
+
+func TestFindParagraphs_LocalisedStyle(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, ctypes.Style{
+		ID:   internal.ToPtr("berschrift1"),
+		Name: &ctypes.CTString{Val: "heading 1"},
+		Type: internal.ToPtr(stypes.StyleTypeParagraph),
+	})
+	rd.AddParagraph("Overview").Style("berschrift1")
+	rd.AddParagraph("Body")
+
+	assert.Equal(t, []string{"Overview"}, texts(rd.FindParagraphs(ByStyle("Heading1"))))
+	assert.Equal(t, []string{"Overview"}, texts(rd.FindParagraphs(ByStyle("Heading 1"))))
+	assert.Equal(t, []string{"Overview"}, texts(rd.FindParagraphs(ByStyle("berschrift1"))))
+}
+
+func TestTableAfterHeading(t *testing.T) {
+	rd := queryTestDoc(t)
+
+	tbl := rd.TableAfterHeading(" Pricing ")
+	require.NotNil(t, tbl)
+	assert.Same(t, rd.Document.Body.Children[5].Table, tbl)
+
+	// The table is under Pricing, not directly under Overview
+	assert.Same(t, tbl, rd.TableAfterHeading("Overview"))
+	assert.Nil(t, rd.TableAfterHeading("Total"))
+	assert.Nil(t, rd.TableAfterHeading("Missing"))
+}
+
+func TestParagraphsBetween(t *testing.T) {
+	rd := queryTestDoc(t)
+
+	paras, err := rd.ParagraphsBetween("from", "to")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Total so far", "Pricing", "Prices are:", "Total: 10"}, texts(paras))
+
+	_, err = rd.ParagraphsBetween("to", "from")
+	assert.ErrorContains(t, err, `bookmark "from" comes before bookmark "to"`)
+	_, err = rd.ParagraphsBetween("from", "missing")
+	assert.ErrorContains(t, err, `bookmark "missing" not found`)
+}
@@ -0,0 +1,431 @@
+package docx
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"godocx/common/constants"
+	"godocx/internal"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+)
+
+// styleChain returns the style with the given ID followed by its ancestors, the root of the
+// hierarchy coming first. The default style of the type is used when styleID is empty.
+func (rd *RootDoc) styleChain(styleID string, styleType stypes.StyleType) []*ctypes.Style {
+	if styleID == "" {
+		styleID = rd.defaultStyleID(styleType)
+	}
+
+	var chain []*ctypes.Style
+	// Follow the style hierarchy, guarding against loops
+	for range 10 {
+		if styleID == "" {
+			break
+		}
+		style := rd.GetStyleByID(styleID, styleType)
+		if style == nil {
+			break
+		}
+		chain = append([]*ctypes.Style{style}, chain...)
+		if style.BasedOn == nil {
+			break
+		}
+		styleID = style.BasedOn.Val
+	}
+	return chain
+}
+
+// defaultStyleID returns the ID of the default style of the given type, or "" if there is none.
+func (rd *RootDoc) defaultStyleID(styleType stypes.StyleType) string {
+	if rd.DocStyles == nil {
+		return ""
+	}
+	for _, style := range rd.DocStyles.StyleList {
+		if style.ID != nil && style.Type != nil && *style.Type == styleType && isDefaultStyle(&style) {
+			return *style.ID
+		}
+	}
+	return ""
+}
+
+// isDefaultStyle reports whether the style is the default style of its type.
+func isDefaultStyle(style *ctypes.Style) bool {
+	if style.Default == nil {
+		return false
+	}
+	switch *style.Default {
+	case stypes.OnOffOne, stypes.OnOffTrue, stypes.OnOffOn:
+		return true
+	}
+	return false
+}
+
+// resolvedParaProp returns the paragraph properties in effect for the paragraph: the document
+// defaults, overridden by the table style when the paragraph is in a table cell, overridden by
+// the paragraph style hierarchy, overridden by the direct formatting. The run properties of the
+// paragraph mark, the section and revision properties are left out.
+func (rd *RootDoc) resolvedParaProp(p *ctypes.Paragraph, cell *cellPosition) *ctypes.ParagraphProp {
+	prop := &ctypes.ParagraphProp{}
+	if rd.DocStyles != nil && rd.DocStyles.DocDefaults != nil && rd.DocStyles.DocDefaults.ParaProp != nil {
+		overlayParaProp(prop, rd.DocStyles.DocDefaults.ParaProp.ParaProp)
+	}
+	if cell != nil {
+		tablePara, _ := rd.tableStyleProps(cell)
+		overlayParaProp(prop, tablePara)
+	}
+	for _, style := range rd.styleChain(paraStyleID(p), stypes.StyleTypeParagraph) {
+		overlayParaProp(prop, style.ParaProp)
+	}
+	if p != nil {
+		overlayParaProp(prop, p.Property)
+	}
+	return prop
+}
+
+// paraRunProp returns the run properties shared by the runs of the paragraph: the document
+// defaults overridden by the run properties of the table style when the paragraph is in a table
+// cell, overridden by those of the paragraph style hierarchy.
+func (rd *RootDoc) paraRunProp(p *ctypes.Paragraph, cell *cellPosition) *ctypes.RunProperty {
+	prop := &ctypes.RunProperty{}
+	if rd.DocStyles != nil && rd.DocStyles.DocDefaults != nil && rd.DocStyles.DocDefaults.RunProp != nil {
+		overlayRunProp(prop, rd.DocStyles.DocDefaults.RunProp.RunProp)
+	}
+	if cell != nil {
+		_, tableRun := rd.tableStyleProps(cell)
+		overlayStyleRunProp(prop, tableRun)
+	}
+	overlayStyleRunProp(prop, rd.chainRunProp(paraStyleID(p), stypes.StyleTypeParagraph))
+	return prop
+}
+
+// resolvedRunProp returns the run properties in effect for a run of the paragraph: the
+// properties of the paragraph, overridden by the character style hierarchy, overridden by the
+// direct formatting of the run.
+func (rd *RootDoc) resolvedRunProp(p *ctypes.Paragraph, cell *cellPosition, r *ctypes.Run) *ctypes.RunProperty {
+	prop := rd.paraRunProp(p, cell)
+	if r == nil || r.Property == nil {
+		return prop
+	}
+	if r.Property.Style != nil {
+		overlayStyleRunProp(prop, rd.chainRunProp(r.Property.Style.Val, stypes.StyleTypeCharacter))
+	}
+	overlayRunProp(prop, r.Property)
+	return prop
+}
+
+// chainRunProp returns the run properties of a style hierarchy, each style overriding the
+// styles it is based on.
+func (rd *RootDoc) chainRunProp(styleID string, styleType stypes.StyleType) *ctypes.RunProperty {
+	prop := &ctypes.RunProperty{}
+	for _, style := range rd.styleChain(styleID, styleType) {
+		overlayRunProp(prop, style.RunProp)
+	}
+	return prop
+}
+
+// resolvedTableProp returns the table properties in effect for the table: the table style
+// hierarchy overridden by the direct formatting. Borders are merged side by side.
+func (rd *RootDoc) resolvedTableProp(t *ctypes.Table) *ctypes.TableProp {
+	styleID := ""
+	if t.TableProp.Style != nil {
+		styleID = t.TableProp.Style.Val
+	}
+
+	prop := &ctypes.TableProp{}
+	for _, style := range rd.styleChain(styleID, stypes.StyleTypeTable) {
+		overlayTableProp(prop, style.TableProp)
+	}
+	overlayTableProp(prop, &t.TableProp)
+	return prop
+}
+
+// cellPosition locates a table cell, for the conditional formatting of the table style.
+type cellPosition struct {
+	table      *ctypes.Table
+	row, col   int // Row index and grid column of the cell
+	span       int // Number of grid columns spanned by the cell
+	rows, cols int // Number of rows and grid columns of the table
+}
+
+// Bits of the table look, selecting the conditional formatting applied to a table
+const (
+	lookFirstRow    = 0x0020
+	lookLastRow     = 0x0040
+	lookFirstColumn = 0x0080
+	lookLastColumn  = 0x0100
+	lookNoHBand     = 0x0200
+	lookNoVBand     = 0x0400
+)
+
+// regions returns the conditional formatting regions of the table style holding the cell, in
+// the order they override each other.
+func (c *cellPosition) regions() []stypes.TblStyleOverrideType {
+	look := int64(0)
+	if prop := c.table.TableProp; prop.TableLook != nil {
+		look, _ = strconv.ParseInt(prop.TableLook.Val, 16, 32)
+	}
+	firstRow := look&lookFirstRow != 0 && c.row == 0
+	lastRow := look&lookLastRow != 0 && c.row == c.rows-1
+	firstCol := look&lookFirstColumn != 0 && c.col == 0
+	lastCol := look&lookLastColumn != 0 && c.col+c.span >= c.cols
+
+	regions := []stypes.TblStyleOverrideType{stypes.TblStyleOverrideWholeTable}
+	band := func(index, size int, first bool, odd, even stypes.TblStyleOverrideType) {
+		if first {
+			index--
+		}
+		if (index/max(size, 1))%2 == 0 {
+			regions = append(regions, odd)
+		} else {
+			regions = append(regions, even)
+		}
+	}
+	if look&lookNoVBand == 0 && !firstCol && !lastCol {
+		band(c.col, bandSize(c.table.TableProp.RowCountInColBand), look&lookFirstColumn != 0,
+			stypes.TblStyleOverrideBand1Vert, stypes.TblStyleOverrideBand2Vert)
+	}
+	if look&lookNoHBand == 0 && !firstRow && !lastRow {
+		band(c.row, bandSize(c.table.TableProp.RowCountInRowBand), look&lookFirstRow != 0,
+			stypes.TblStyleOverrideBand1Horz, stypes.TblStyleOverrideBand2Horz)
+	}
+	for _, r := range []struct {
+		applies bool
+		region  stypes.TblStyleOverrideType
+	}{
+		{firstCol, stypes.TblStyleOverrideFirstCol},
+		{lastCol, stypes.TblStyleOverrideLastCol},
+		{firstRow, stypes.TblStyleOverrideFirstRow},
+		{lastRow, stypes.TblStyleOverrideLastRow},
+		{firstRow && lastCol, stypes.TblStyleOverrideNeCell},
+		{firstRow && firstCol, stypes.TblStyleOverrideNwCell},
+		{lastRow && lastCol, stypes.TblStyleOverrideSeCell},
+		{lastRow && firstCol, stypes.TblStyleOverrideSwCell},
+	} {
+		if r.applies {
+			regions = append(regions, r.region)
+		}
+	}
+	return regions
+}
+
+// bandSize returns the number of rows or columns of a band, 1 by default.
+func bandSize(size *ctypes.DecimalNum) int {
+	if size == nil {
+		return 1
+	}
+	return size.Val
+}
+
+// tableStyleProps returns the paragraph and run properties of the table style applying to the
+// content of the cell: those of the whole table, overridden by the conditional formatting of the
+// regions holding the cell. The run properties are not combined with the document defaults.
+func (rd *RootDoc) tableStyleProps(cell *cellPosition) (*ctypes.ParagraphProp, *ctypes.RunProperty) {
+	styleID := ""
+	if cell.table.TableProp.Style != nil {
+		styleID = cell.table.TableProp.Style.Val
+	}
+	chain := rd.styleChain(styleID, stypes.StyleTypeTable)
+
+	paraProp, runProp := &ctypes.ParagraphProp{}, &ctypes.RunProperty{}
+	for _, region := range cell.regions() {
+		for _, style := range chain {
+			if region == stypes.TblStyleOverrideWholeTable {
+				overlayParaProp(paraProp, style.ParaProp)
+				overlayRunProp(runProp, style.RunProp)
+			}
+			for _, cond := range style.TableStylePr {
+				if cond.Type == region {
+					overlayParaProp(paraProp, cond.ParaProp)
+					overlayRunProp(runProp, cond.RunProp)
+				}
+			}
+		}
+	}
+	return paraProp, runProp
+}
+
+// overlayTableProp overrides the properties of dst with those set in src, borders being merged
+// side by side. Revision properties are left out.
+func overlayTableProp(dst, src *ctypes.TableProp) {
+	if src == nil {
+		return
+	}
+	borders := dst.Borders
+	internal.Overlay(dst, src)
+	dst.Borders = overlayed(borders, src.Borders)
+	dst.PrChange = nil
+}
+
+// paraStyleID returns the style ID of the paragraph, or "" for the default style.
+func paraStyleID(p *ctypes.Paragraph) string {
+	if p == nil || p.Property == nil || p.Property.Style == nil {
+		return ""
+	}
+	return p.Property.Style.Val
+}
+
+// overlayParaProp overrides the properties of dst with those set in src. Spacing, indentation
+// and borders are merged attribute by attribute.
+func overlayParaProp(dst, src *ctypes.ParagraphProp) {
+	if src == nil {
+		return
+	}
+	spacing, indent, border, runProp := dst.Spacing, dst.Indent, dst.Border, dst.RunProperty
+	internal.Overlay(dst, src)
+
+	dst.Spacing = overlayed(spacing, src.Spacing)
+	dst.Border = overlayed(border, src.Border)
+	dst.Indent = overlayed(indent, src.Indent)
+	if src.Indent != nil && dst.Indent != nil {
+		// The first line indentation and the hanging indentation exclude each other
+		if src.Indent.Hanging != nil && src.Indent.FirstLine == nil {
+			dst.Indent.FirstLine = nil
+		}
+		if src.Indent.FirstLine != nil && src.Indent.Hanging == nil {
+			dst.Indent.Hanging = nil
+		}
+	}
+	dst.RunProperty, dst.SectPr, dst.PPrChange = runProp, nil, nil
+}
+
+// overlayRunProp overrides the properties of dst with those set in src. Fonts are merged
+// attribute by attribute, the character style of src is not followed.
+func overlayRunProp(dst, src *ctypes.RunProperty) {
+	if src == nil {
+		return
+	}
+	fonts := dst.Fonts
+	internal.Overlay(dst, src)
+	dst.Fonts = overlayed(fonts, src.Fonts)
+	// Revision marks of the paragraph mark are not formatting
+	dst.Ins, dst.Del = nil, nil
+	if src.Fonts != nil {
+		// Theme fonts take precedence over font names, an inherited theme font gives way to a named font
+		if src.Fonts.Ascii != "" && src.Fonts.AsciiTheme == "" {
+			dst.Fonts.AsciiTheme = ""
+		}
+		if src.Fonts.HAnsi != "" && src.Fonts.HAnsiTheme == "" {
+			dst.Fonts.HAnsiTheme = ""
+		}
+	}
+}
+
+// toggleProps returns pointers to the toggle properties of the run properties: a toggle
+// property set by a style inverts the value set by the styles of another type it is applied
+// over, such as an italic character style in an italic paragraph.
+func toggleProps(prop *ctypes.RunProperty) []**ctypes.OnOff {
+	return []**ctypes.OnOff{
+		&prop.Bold, &prop.BoldCS, &prop.Italic, &prop.ItalicCS, &prop.Caps, &prop.SmallCaps,
+		&prop.Strike, &prop.DoubleStrike, &prop.Outline, &prop.Shadow, &prop.Emboss, &prop.Imprint,
+		&prop.Vanish,
+	}
+}
+
+// overlayStyleRunProp overrides the properties of dst with those of a style hierarchy, the
+// toggle properties set to true in src inverting those of dst and those set to false leaving
+// them unchanged.
+func overlayStyleRunProp(dst, src *ctypes.RunProperty) {
+	if src == nil {
+		return
+	}
+	dstToggles := toggleProps(dst)
+	inherited := make([]*ctypes.OnOff, len(dstToggles))
+	for i, toggle := range dstToggles {
+		inherited[i] = *toggle
+	}
+	overlayRunProp(dst, src)
+	for i, toggle := range toggleProps(src) {
+		switch {
+		case *toggle == nil:
+		case (*toggle).Bool():
+			*dstToggles[i] = ctypes.OnOffFromBool(!inherited[i].Bool())
+		default:
+			*dstToggles[i] = inherited[i]
+		}
+	}
+}
+
+// overlayed returns dst with the fields set in src copied over it, allocating dst if needed.
+func overlayed[T any](dst, src *T) *T {
+	if src == nil {
+		return dst
+	}
+	if dst == nil {
+		dst = new(T)
+	}
+	internal.Overlay(dst, src)
+	return dst
+}
+
+// themeFonts is the part of the theme part needed to resolve theme fonts.
+type themeFonts struct {
+	Major struct {
+		Latin struct {
+			Typeface string `xml:"typeface,attr"`
+		} `xml:"latin"`
+	} `xml:"themeElements>fontScheme>majorFont"`
+	Minor struct {
+		Latin struct {
+			Typeface string `xml:"typeface,attr"`
+		} `xml:"latin"`
+	} `xml:"themeElements>fontScheme>minorFont"`
+}
+
+// fontFamily returns the name of the font used for Latin text, resolving theme fonts through
+// the theme part. It returns "" if the font is not known.
+func (rd *RootDoc) fontFamily(fonts *ctypes.RunFonts) string {
+	if fonts == nil {
+		return ""
+	}
+	for _, theme := range []stypes.ThemeFont{fonts.AsciiTheme, fonts.HAnsiTheme} {
+		if name := rd.themeFont(theme); name != "" {
+			return name
+		}
+	}
+	if fonts.Ascii != "" {
+		return fonts.Ascii
+	}
+	return fonts.HAnsi
+}
+
+// parsedTheme is the content of the theme part with the theme fonts parsed from it.
+type parsedTheme struct {
+	content []byte
+	fonts   themeFonts
+}
+
+// themeFont returns the typeface of a theme font, or "" if it is not defined by the theme part.
+// The theme part is parsed again only when its content is replaced.
+func (rd *RootDoc) themeFont(theme stypes.ThemeFont) string {
+	if theme == "" {
+		return ""
+	}
+	var content []byte
+	for _, rel := range rd.Document.DocRels.Relationships {
+		if rel.Type == constants.SourceRelationshipTheme {
+			if stored, ok := rd.FileMap.Load(rd.Document.partPath(rel.Target)); ok {
+				content = stored.([]byte)
+			}
+			break
+		}
+	}
+	if len(content) == 0 {
+		return ""
+	}
+	if rd.theme == nil || len(rd.theme.content) != len(content) || &rd.theme.content[0] != &content[0] {
+		parsed := &parsedTheme{content: content}
+		if err := xml.Unmarshal(content, &parsed.fonts); err != nil {
+			return ""
+		}
+		rd.theme = parsed
+	}
+	tf := rd.theme.fonts
+	switch theme {
+	case stypes.ThemeFontMajorAscii, stypes.ThemeFontMajorHAnsi:
+		return tf.Major.Latin.Typeface
+	case stypes.ThemeFontMinorAscii, stypes.ThemeFontMinorHAnsi:
+		return tf.Minor.Latin.Typeface
+	}
+	return ""
+}
@@ -21,6 +21,15 @@ type RootDoc struct {
 
 	rID        int // rId is used to generate unique relationship IDs.
 	ImageCount uint
+	bookmarkID int // Last bookmark ID given to copied content
+
+	hdrFtr       []*HeaderFooter // Header and footer parts read by HeadersFooters
+	hdrFtrLoaded bool
+
+	paraIndex map[*ctypes.Paragraph]paraLocation // Locations of the paragraphs, see paragraphCell
+	theme     *parsedTheme                       // Theme part last parsed by themeFont
+
+	insertMu sync.Mutex // Serializes the insertion of fragments
 }
 
 // NewRootDoc creates a new instance of the RootDoc structure.
@@ -69,15 +78,36 @@ func LoadStyles(fileName string, fileBytes []byte) (*ctypes.Styles, error) {
 // Returns the numId that can be used with paragraph.Numbering().
 //
 // Parameters:
-//   - abstractNumId: An integer representing the abstract numbering definition ID (1-8 or custom).
+//   - abstractNumId: An integer representing the abstract numbering definition ID, such as
+//     DecimalListID or BulletListID for the generated lists.
 //
 // Returns:
 //   - numId: An integer that can be used with paragraph.Numbering(numId, level).
 //
 // Example:
 //
-//	numId := doc.NewListInstance(1)
+//	numId := doc.NewListInstance(docx.DecimalListID)
 //	p.Numbering(numId, 0)
 func (rd *RootDoc) NewListInstance(abstractNumId int) int {
 	return rd.Numbering.NewListInstance(abstractNumId)
 }
+
+// NumberingDefinitions returns the numbering definitions of the document, parsed from its
+// numbering part, for reading and editing. Changes are saved with the document.
+//
+// Example:
+//
+//	defs, err := doc.NumberingDefinitions()
+//	if err != nil {
+//		return err
+//	}
+//	// Number the first level of the list of paragraph p with upper roman numerals
+//	num := defs.NumByID(p.GetCT().Property.NumProp.NumID.Val)
+//	level := defs.AbstractNumByID(num.AbstractNumID.Val).Level(0)
+//	level.NumFmt = ctypes.NewGenSingleStrVal(stypes.NumFmtUpperRoman)
+func (rd *RootDoc) NumberingDefinitions() (*ctypes.Numbering, error) {
+	if rd.Numbering == nil {
+		rd.Numbering = NewNumberingManager(rd)
+	}
+	return rd.Numbering.Definitions()
+}
@@ -6,12 +6,13 @@ import (
 )
 
 type Run struct {
-	root *RootDoc    // root is the root document to which this run belongs.
-	ct   *ctypes.Run // ct is the underlying run element from the wml/ctypes package.
+	root *RootDoc          // root is the root document to which this run belongs.
+	para *ctypes.Paragraph // para is the paragraph holding the run, nil if not known.
+	ct   *ctypes.Run       // ct is the underlying run element from the wml/ctypes package.
 }
 
-func newRun(root *RootDoc, ct *ctypes.Run) *Run {
-	return &Run{root: root, ct: ct}
+func newRun(root *RootDoc, para *ctypes.Paragraph, ct *ctypes.Run) *Run {
+	return &Run{root: root, para: para, ct: ct}
 }
 
 // getProp returns the run properties. If not initialized, it creates and returns a new instance.
@@ -14,7 +14,7 @@ import (
 func (rd *RootDoc) SectionBreak() {
 	p := rd.AddEmptyParagraph()
 	p.ensureProp()
-	p.ct.Property.SectPr = rd.Document.Body.SectPr
+	p.GetCT().Property.SectPr = rd.Document.Body.SectPr
 	rd.Document.Body.SectPr = ctypes.NewSectionProper()
 }
 
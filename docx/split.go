@@ -0,0 +1,428 @@
+package docx
+
+import (
+	"cmp"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"godocx/common/constants"
+	"godocx/internal"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+)
+
+// SplitRule returns the indexes of the body children starting a new part of a document split
+// by RootDoc.Split.
+type SplitRule func(rd *RootDoc) ([]int, error)
+
+// SplitAtHeadings splits the document before each body heading of the given level or above,
+// SplitAtHeadings(1) starting a part at each Heading 1.
+func SplitAtHeadings(level int) SplitRule {
+	return func(rd *RootDoc) ([]int, error) {
+		var splits []int
+		for i, child := range rd.Document.Body.Children {
+			if child.Para == nil {
+				continue
+			}
+			if l := rd.headingLevel(child.Para.GetCT()); l >= 1 && l <= level {
+				splits = append(splits, i)
+			}
+		}
+		return splits, nil
+	}
+}
+
+// SplitAtSections splits the document after each section break, each part holding a section.
+func SplitAtSections() SplitRule {
+	return func(rd *RootDoc) ([]int, error) {
+		var splits []int
+		for i, child := range rd.Document.Body.Children {
+			if child.Para != nil && child.Para.GetCT().Property != nil && child.Para.GetCT().Property.SectPr != nil {
+				splits = append(splits, i+1)
+			}
+		}
+		return splits, nil
+	}
+}
+
+// SplitEveryPages splits the document every n pages, pages being delimited by the explicit
+// page breaks of the body: page break characters, paragraphs starting on a new page and
+// section breaks. A page break inside a paragraph starts the page after the paragraph.
+func SplitEveryPages(n int) SplitRule {
+	return func(rd *RootDoc) ([]int, error) {
+		if n < 1 {
+			return nil, fmt.Errorf("split every %d pages: the number of pages must be positive", n)
+		}
+		var splits []int
+		pages := 1
+		newPage := func(i int) {
+			if pages%n == 0 && i > 0 && !slices.Contains(splits, i) {
+				splits = append(splits, i)
+			}
+			pages++
+		}
+		children := rd.Document.Body.Children
+		for i, child := range children {
+			if child.Para == nil {
+				continue
+			}
+			prop := child.Para.GetCT().Property
+			if i > 0 && prop != nil && prop.PageBreakBefore.Bool() {
+				newPage(i)
+			}
+			if i == len(children)-1 {
+				break
+			}
+			if pageBreak(child.Para) {
+				newPage(i + 1)
+			} else if prop != nil && prop.SectPr != nil &&
+				(prop.SectPr.Type == nil || prop.SectPr.Type.Val != stypes.SectionMarkNextContinuous) {
+				newPage(i + 1)
+			}
+		}
+		return splits, nil
+	}
+}
+
+// pageBreak reports whether the paragraph holds a page break character.
+func pageBreak(p *Paragraph) bool {
+	for run := range p.Runs() {
+		for _, rc := range run.ct.Children {
+			if rc.Break != nil && rc.Break.BreakType != nil && *rc.Break.BreakType == stypes.BreakTypePage {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SplitAtBookmarks splits the document before the body child holding the start of each of the
+// named bookmarks.
+func SplitAtBookmarks(names ...string) SplitRule {
+	return func(rd *RootDoc) ([]int, error) {
+		var splits []int
+		for _, name := range names {
+			index := slices.IndexFunc(rd.Document.Body.Children, func(child DocumentChild) bool {
+				found := false
+				forEachPara([]DocumentChild{child}, func(p *ctypes.Paragraph) bool {
+					found = (&Paragraph{ref: p}).bookmarks()[name]
+					return !found
+				})
+				return found
+			})
+			if index < 0 {
+				return nil, fmt.Errorf("bookmark %q not found", name)
+			}
+			splits = append(splits, index)
+		}
+		return splits, nil
+	}
+}
+
+// Split splits the body of the document into independent documents at the children selected
+// by the rule. Each document holds a copy of its part of the body, with the section properties
+// in effect at its end, and only the styles, lists, images and relationships its content refers
+// to. The other parts of the package, such as the theme, settings and fonts, are shared by all
+// documents. Empty parts are left out. The document is not changed.
+//
+// Example:
+//
+//	chapters, err := document.Split(docx.SplitAtHeadings(1))
+//	for i, chapter := range chapters {
+//		err = chapter.SaveTo(fmt.Sprintf("chapter%d.docx", i+1))
+//	}
+func (rd *RootDoc) Split(by SplitRule) ([]*RootDoc, error) {
+	splits, err := by(rd)
+	if err != nil {
+		return nil, fmt.Errorf("split: %w", err)
+	}
+	children := rd.Document.Body.Children
+	splits = append(splits, 0, len(children))
+	slices.Sort(splits)
+	splits = slices.Compact(splits)
+
+	var docs []*RootDoc
+	for i := 1; i < len(splits); i++ {
+		start, end := splits[i-1], splits[i]
+		if start < 0 || end > len(children) {
+			return nil, fmt.Errorf("split: position out of range [0, %d]", len(children))
+		}
+		if start == end {
+			continue
+		}
+		doc, err := rd.splitPart(children[start:end], rd.sectionAt(end-1))
+		if err != nil {
+			return nil, fmt.Errorf("split: %w", err)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// sectionAt returns a copy of the properties of the section holding the body child at the
+// index. A section without header or footer continues those of the previous section, which are
+// made explicit in the copy.
+func (rd *RootDoc) sectionAt(index int) *ctypes.SectionProp {
+	var sectPr *ctypes.SectionProp
+	var header *ctypes.HeaderReference
+	var footer *ctypes.FooterReference
+	for i, child := range rd.Document.Body.Children {
+		if child.Para == nil || child.Para.GetCT().Property == nil || child.Para.GetCT().Property.SectPr == nil {
+			continue
+		}
+		if i >= index {
+			sectPr = child.Para.GetCT().Property.SectPr
+			break
+		}
+		header = cmp.Or(child.Para.GetCT().Property.SectPr.HeaderReference, header)
+		footer = cmp.Or(child.Para.GetCT().Property.SectPr.FooterReference, footer)
+	}
+	if sectPr == nil {
+		sectPr = rd.Document.Body.SectPr
+	}
+	if sectPr == nil {
+		return nil
+	}
+
+	sectPr = internal.DeepCopy(sectPr)
+	if sectPr.HeaderReference == nil && header != nil {
+		sectPr.HeaderReference = internal.DeepCopy(header)
+	}
+	if sectPr.FooterReference == nil && footer != nil {
+		sectPr.FooterReference = internal.DeepCopy(footer)
+	}
+	return sectPr
+}
+
+// splitPart returns a document holding a copy of the content, ending with the section.
+func (rd *RootDoc) splitPart(children []DocumentChild, sectPr *ctypes.SectionProp) (*RootDoc, error) {
+	doc, err := rd.emptyCopy()
+	if err != nil {
+		return nil, err
+	}
+
+	ci := &contentImporter{src: rd, dst: doc}
+	if err := ci.prepare(children); err != nil {
+		return nil, err
+	}
+
+	// Drop the headers and footers of the other sections, the section of the last paragraph
+	// being the one of the part
+	sectPrs := []*ctypes.SectionProp{sectPr}
+	forEachPara(ci.children, func(p *ctypes.Paragraph) bool {
+		if p.Property != nil {
+			sectPrs = append(sectPrs, p.Property.SectPr)
+		}
+		return true
+	})
+	used := map[string]bool{}
+	for _, sectPr := range sectPrs {
+		if sectPr == nil {
+			continue
+		}
+		if sectPr.HeaderReference != nil {
+			used[sectPr.HeaderReference.ID] = true
+		}
+		if sectPr.FooterReference != nil {
+			used[sectPr.FooterReference.ID] = true
+		}
+	}
+	for _, rel := range slices.Clone(doc.Document.DocRels.Relationships) {
+		if (rel.Type == constants.SourceRelationshipHeader || rel.Type == constants.SourceRelationshipFooter) && !used[rel.ID] {
+			doc.removeRelation(rel)
+		}
+	}
+
+	// The kept headers, footers and notes use styles and lists as well
+	styleIDs, numIDs, err := doc.partRefs()
+	if err != nil {
+		return nil, err
+	}
+	sm := &styleMerger{src: rd, dst: doc}
+	sm.plan(ci.children, styleIDs...)
+
+	forEachPara(ci.children, func(p *ctypes.Paragraph) bool {
+		numIDs = appendNumID(numIDs, p.Property)
+		return true
+	})
+	for _, style := range sm.styles {
+		numIDs = appendNumID(numIDs, style.ParaProp)
+	}
+	instances := map[int]int{}
+	if src := rd.numberingDefs(); src != nil && len(numIDs) > 0 {
+		if instances, err = doc.Numbering.importNumbering(src, numIDs, nil); err != nil {
+			return nil, err
+		}
+	}
+	ci.listInstance = func(numID int) (int, bool) {
+		newID, ok := instances[numID]
+		return newID, ok
+	}
+	sm.apply(instances)
+
+	if doc.Document.Body.Children, err = ci.commit(); err != nil {
+		return nil, err
+	}
+	if err := doc.renamePartRefs(sm.renamed, instances); err != nil {
+		return nil, err
+	}
+
+	// The last section of a document is held by the body rather than its last paragraph
+	body := doc.Document.Body
+	if last := body.Children[len(body.Children)-1]; last.Para != nil && last.Para.GetCT().Property != nil {
+		last.Para.GetCT().Property.SectPr = nil
+	}
+	body.SectPr = sectPr
+	return doc, nil
+}
+
+var partNumIDRe = regexp.MustCompile(`(<w:numId w:val=")(\d+)(")`)
+
+// partRefs returns the styles and the numbering instances used by the headers, footers and
+// notes of the document.
+func (rd *RootDoc) partRefs() (styleIDs []string, numIDs []int, err error) {
+	parts, err := rd.HeadersFooters()
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, hf := range parts {
+		eachStyleRef(hf.Children, func(ref *ctypes.CTString) {
+			styleIDs = append(styleIDs, ref.Val)
+		})
+		forEachPara(hf.Children, func(p *ctypes.Paragraph) bool {
+			numIDs = appendNumID(numIDs, p.Property)
+			return true
+		})
+	}
+	for _, partPath := range rd.notesPaths() {
+		content, ok := rd.FileMap.Load(partPath)
+		if !ok {
+			continue
+		}
+		for _, m := range partStyleRefRe.FindAllSubmatch(content.([]byte), -1) {
+			styleIDs = append(styleIDs, string(m[2]))
+		}
+		for _, m := range partNumIDRe.FindAllSubmatch(content.([]byte), -1) {
+			if id, err := strconv.Atoi(string(m[2])); err == nil && id != 0 {
+				numIDs = append(numIDs, id)
+			}
+		}
+	}
+	return styleIDs, numIDs, nil
+}
+
+// renamePartRefs updates the references of the body, headers, footers, lists and notes to the
+// renamed styles, and the references of the headers, footers and notes to the renumbered lists.
+func (rd *RootDoc) renamePartRefs(styleIDs map[string]string, numIDs map[int]int) error {
+	err := rd.eachStyleUsage(func(ref *ctypes.CTString) {
+		if newID, ok := styleIDs[ref.Val]; ok {
+			ref.Val = newID
+		}
+	}, func(content []byte) []byte {
+		content = partStyleRefRe.ReplaceAllFunc(content, func(ref []byte) []byte {
+			m := partStyleRefRe.FindSubmatch(ref)
+			if newID, ok := styleIDs[string(m[2])]; ok {
+				return slices.Concat(m[1], []byte(newID), m[3])
+			}
+			return ref
+		})
+		return partNumIDRe.ReplaceAllFunc(content, func(ref []byte) []byte {
+			m := partNumIDRe.FindSubmatch(ref)
+			id, _ := strconv.Atoi(string(m[2]))
+			if newID, ok := numIDs[id]; ok {
+				return slices.Concat(m[1], []byte(strconv.Itoa(newID)), m[3])
+			}
+			return ref
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	parts, err := rd.HeadersFooters()
+	if err != nil {
+		return err
+	}
+	for _, hf := range parts {
+		forEachPara(hf.Children, func(p *ctypes.Paragraph) bool {
+			if p.Property != nil && p.Property.NumProp != nil && p.Property.NumProp.NumID != nil {
+				if newID, ok := numIDs[p.Property.NumProp.NumID.Val]; ok {
+					p.Property.NumProp.NumID.Val = newID
+				}
+			}
+			return true
+		})
+	}
+	return nil
+}
+
+// appendNumID appends the numbering instance of the paragraph properties to numIDs.
+func appendNumID(numIDs []int, prop *ctypes.ParagraphProp) []int {
+	if prop != nil && prop.NumProp != nil && prop.NumProp.NumID != nil && prop.NumProp.NumID.Val != 0 {
+		numIDs = append(numIDs, prop.NumProp.NumID.Val)
+	}
+	return numIDs
+}
+
+// emptyCopy returns a document with the package of the document but an empty body, without the
+// parts referenced by the body, the styles other than the default ones and the lists.
+func (rd *RootDoc) emptyCopy() (*RootDoc, error) {
+	doc := rd.clonePackage()
+	if rd.DocStyles != nil {
+		for _, style := range rd.DocStyles.StyleList {
+			if isDefaultStyle(&style) {
+				doc.DocStyles.StyleList = append(doc.DocStyles.StyleList, *internal.DeepCopy(&style))
+			}
+		}
+	}
+
+	// Parts referenced by the body are added back by the content using them
+	body := &contentImporter{children: rd.Document.Body.Children}
+	bodyRels := map[string]bool{}
+	body.eachRelRef(func(rID *string) {
+		bodyRels[*rID] = true
+	})
+	for _, rel := range slices.Clone(doc.Document.DocRels.Relationships) {
+		if bodyRels[rel.ID] {
+			doc.removeRelation(rel)
+		}
+	}
+
+	// Lists are added back by the content using them
+	if _, ok := doc.FileMap.Load(numberingPath); ok {
+		if err := doc.Numbering.clear(); err != nil {
+			return nil, err
+		}
+	}
+	return doc, nil
+}
+
+// removeRelation removes a relationship of the document, with the part it targets when no other
+// relationship targets it.
+func (rd *RootDoc) removeRelation(rel *Relationship) {
+	doc := rd.Document
+	doc.DocRels.Relationships = slices.DeleteFunc(doc.DocRels.Relationships, func(r *Relationship) bool {
+		return r == rel
+	})
+	if rel.TargetMode == "External" {
+		return
+	}
+	for _, r := range doc.DocRels.Relationships {
+		if r.TargetMode != "External" && doc.partPath(r.Target) == doc.partPath(rel.Target) {
+			return
+		}
+	}
+
+	partPath := doc.partPath(rel.Target)
+	rd.FileMap.Delete(partPath)
+	rd.FileMap.Delete(path.Join(path.Dir(partPath), "_rels", path.Base(partPath)+".rels"))
+	rd.ContentType.Override = slices.DeleteFunc(rd.ContentType.Override, func(o Override) bool {
+		return o.PartName == "/"+partPath
+	})
+	rd.hdrFtr = slices.DeleteFunc(rd.hdrFtr, func(hf *HeaderFooter) bool {
+		return hf.Path == partPath
+	})
+}
@@ -0,0 +1,135 @@
+package docx
+
+import (
+	"fmt"
+	"testing"
+
+	"godocx/common/constants"
+	"godocx/common/units"
+	"godocx/internal"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func splitTestDoc(t *testing.T) *RootDoc {
+	rd := setupRootDoc(t)
+	rd.FileMap.Store(numberingPath, []byte(appendTestNumbering))
+	for _, id := range []string{"Normal", "Heading1", "Unused"} {
+		style := ctypes.Style{
+			ID:   internal.ToPtr(id),
+			Name: &ctypes.CTString{Val: id},
+			Type: internal.ToPtr(stypes.StyleTypeParagraph),
+		}
+		if id == "Normal" {
+			style.Default = internal.ToPtr(stypes.OnOffOne)
+		}
+		rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, style)
+	}
+	for i, name := range []string{"header1.xml", "header2.xml"} {
+		rd.FileMap.Store("word/"+name, []byte("<w:hdr/>"))
+		rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships, &Relationship{
+			ID: []string{"rId90", "rId91"}[i], Type: constants.SourceRelationshipHeader, Target: name,
+		})
+	}
+
+	rd.AddParagraph("one").Style("Heading1")
+	_, err := rd.AddImage([]byte("GIF89a"), units.Emu(9525), units.Emu(9525))
+	require.NoError(t, err)
+	item := rd.AddParagraph("item")
+	item.Numbering(1, 0)
+	item.AddText("").AddBreak(stypes.BreakTypePage)
+	rd.AddParagraph("page two")
+	brk := rd.AddEmptyParagraph()
+	brk.ensureProp()
+	brk.GetCT().Property.SectPr = &ctypes.SectionProp{HeaderReference: &ctypes.HeaderReference{ID: "rId90"}}
+	rd.AddParagraph("two").Style("Heading1")
+	rd.AddParagraph("").AddLink("link", "https://example.com")
+	rd.Document.Body.SectPr = &ctypes.SectionProp{HeaderReference: &ctypes.HeaderReference{ID: "rId91"}}
+	return rd
+}
+
+func TestSplit(t *testing.T) {
+	rd := splitTestDoc(t)
+	docs, err := rd.Split(SplitAtHeadings(1))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.Equal(t, "one\n\nitem\n\npage two\n\n", bodyText(t, docs[0]))
+	assert.Equal(t, "two\nlink\n", bodyText(t, docs[1]))
+	assert.Len(t, rd.Document.Body.Children, 7)
+
+	// Only what the content refers to is kept
+	first, second := docs[0], docs[1]
+	assert.NotNil(t, first.GetStyleByID("Heading1", stypes.StyleTypeParagraph))
+	assert.NotNil(t, first.GetStyleByID("Normal", stypes.StyleTypeParagraph))
+	assert.Nil(t, first.GetStyleByID("Unused", stypes.StyleTypeParagraph))
+	require.Len(t, first.Document.DocRels.Relationships, 2)
+	assert.Equal(t, "rId90", first.Document.DocRels.Relationships[0].ID)
+	assert.Equal(t, constants.SourceRelationshipImage, first.Document.DocRels.Relationships[1].Type)
+	require.Len(t, second.Document.DocRels.Relationships, 2)
+	assert.Equal(t, "rId91", second.Document.DocRels.Relationships[0].ID)
+	assert.Equal(t, "https://example.com", second.Document.DocRels.Relationships[1].Target)
+	_, ok := second.FileMap.Load("word/header1.xml")
+	assert.False(t, ok)
+	_, ok = second.FileMap.Load("word/media/image2.gif")
+	assert.False(t, ok)
+
+	assert.Contains(t, savedNumbering(t, first), `<w:num w:numId="1"><w:abstractNumId w:val="203"></w:abstractNumId></w:num>`)
+	assert.Equal(t, 1, first.Document.Body.Children[2].Para.GetCT().Property.NumProp.NumID.Val)
+	assert.NotContains(t, savedNumbering(t, second), "<w:num ")
+
+	// The section break of the first part becomes its last section
+	require.NotNil(t, first.Document.Body.SectPr)
+	assert.Equal(t, "rId90", first.Document.Body.SectPr.HeaderReference.ID)
+	assert.Nil(t, first.Document.Body.Children[4].Para.GetCT().Property.SectPr)
+
+	docs, err = rd.Split(SplitAtSections())
+	require.NoError(t, err)
+	assert.Len(t, docs, 2)
+	assert.Equal(t, "two\nlink\n", bodyText(t, docs[1]))
+
+	docs, err = rd.Split(SplitEveryPages(1))
+	require.NoError(t, err)
+	require.Len(t, docs, 3)
+	assert.Equal(t, "page two\n\n", bodyText(t, docs[1]))
+
+	_, err = rd.Split(SplitAtBookmarks("missing"))
+	assert.ErrorContains(t, err, `bookmark "missing" not found`)
+}
+
+func TestSplit_HeaderAndNoteStyles(t *testing.T) {
+	rd := splitTestDoc(t)
+	for _, id := range []string{"HdrCustom", "NoteText"} {
+		rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, ctypes.Style{
+			ID:   internal.ToPtr(id),
+			Name: &ctypes.CTString{Val: id},
+			Type: internal.ToPtr(stypes.StyleTypeParagraph),
+		})
+	}
+	rd.FileMap.Store("word/header2.xml", []byte(`<w:hdr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">`+
+		`<w:p><w:pPr><w:pStyle w:val="HdrCustom"/><w:numPr><w:ilvl w:val="0"/><w:numId w:val="1"/></w:numPr></w:pPr>`+
+		`<w:r><w:t>Header</w:t></w:r></w:p></w:hdr>`))
+	rd.FileMap.Store("word/footnotes.xml", []byte(`<w:footnotes xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">`+
+		`<w:footnote w:id="1"><w:p><w:pPr><w:pStyle w:val="NoteText"/></w:pPr></w:p></w:footnote></w:footnotes>`))
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships, &Relationship{
+		ID: "rId92", Type: constants.SourceRelationshipFootnotes, Target: "footnotes.xml",
+	})
+
+	docs, err := rd.Split(SplitAtHeadings(1))
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+
+	second := docs[1]
+	assert.NotNil(t, second.GetStyleByID("HdrCustom", stypes.StyleTypeParagraph))
+	assert.NotNil(t, second.GetStyleByID("NoteText", stypes.StyleTypeParagraph))
+	assert.Nil(t, second.GetStyleByID("Unused", stypes.StyleTypeParagraph))
+	assert.Nil(t, docs[0].GetStyleByID("HdrCustom", stypes.StyleTypeParagraph))
+
+	parts, err := second.HeadersFooters()
+	require.NoError(t, err)
+	require.Len(t, parts, 1)
+	numID := parts[0].Children[0].Para.GetCT().Property.NumProp.NumID.Val
+	assert.Contains(t, savedNumbering(t, second), fmt.Sprintf(`<w:num w:numId="%d">`, numID))
+}
@@ -0,0 +1,508 @@
+package docx
+
+import (
+	"fmt"
+
+	"godocx/internal"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+)
+
+// StyleBuilder defines a style added to the document. Its methods set the formatting of the
+// style and return the builder, so that calls can be chained. The first invalid call is
+// recorded and returned by Err, the following calls being ignored.
+type StyleBuilder struct {
+	root      *RootDoc
+	id        string
+	styleType stypes.StyleType
+	linked    string // ID of the character style linked to a paragraph style
+	err       error
+}
+
+// AddParagraphStyle adds a paragraph style with the given ID and name to the document.
+//
+// Example:
+//
+//	style := document.AddParagraphStyle("Quote", "Quote").
+//		BasedOn("Normal").Next("Normal").Font("Georgia").Italic(true).Spacing(120, 120)
+//	if err := style.Err(); err != nil {
+//		return err
+//	}
+//	document.AddParagraph("To be, or not to be").Style("Quote")
+func (rd *RootDoc) AddParagraphStyle(id, name string) *StyleBuilder {
+	return rd.addStyle(id, name, stypes.StyleTypeParagraph)
+}
+
+// AddCharacterStyle adds a character style with the given ID and name to the document. Only
+// run formatting can be set on a character style.
+func (rd *RootDoc) AddCharacterStyle(id, name string) *StyleBuilder {
+	return rd.addStyle(id, name, stypes.StyleTypeCharacter)
+}
+
+// AddLinkedStyle adds a paragraph style with the given ID and name and the character style
+// linked to it, with the ID suffixed by "Char" and the name by " Char". The run formatting of
+// the builder is set on both styles, so the style can be applied to paragraphs and to runs.
+func (rd *RootDoc) AddLinkedStyle(id, name string) *StyleBuilder {
+	b := rd.addStyle(id, name, stypes.StyleTypeParagraph)
+	if b.err != nil {
+		return b
+	}
+	linked := rd.addStyle(id+"Char", name+" Char", stypes.StyleTypeCharacter)
+	if linked.err != nil {
+		rd.removeStyle(id)
+		b.err = linked.err
+		return b
+	}
+	b.linked = linked.id
+	b.style().Link = ctypes.NewCTString(linked.id)
+	linked.style().Link = ctypes.NewCTString(id)
+	return b
+}
+
+// AddTableStyle adds a table style with the given ID and name to the document. Besides the
+// formatting of the whole table, a table style holds the formatting of regions of the table,
+// such as the header row, set with Conditional.
+//
+// Example:
+//
+//	grid := document.AddTableStyle("PlainGrid", "Plain Grid").Borders(&ctypes.TableBorders{
+//		Top:     ctypes.NewCellBorder(stypes.BorderStyleSingle, "auto", "0", 4),
+//		Bottom:  ctypes.NewCellBorder(stypes.BorderStyleSingle, "auto", "0", 4),
+//		InsideH: ctypes.NewCellBorder(stypes.BorderStyleSingle, "auto", "0", 4),
+//	})
+//	grid.Conditional(stypes.TblStyleOverrideFirstRow).Bold(true).Shading("D9D9D9")
+func (rd *RootDoc) AddTableStyle(id, name string) *StyleBuilder {
+	return rd.addStyle(id, name, stypes.StyleTypeTable)
+}
+
+// addStyle adds an empty style of the given type, unless the ID or the name is already used.
+func (rd *RootDoc) addStyle(id, name string, styleType stypes.StyleType) *StyleBuilder {
+	b := &StyleBuilder{root: rd, id: id, styleType: styleType}
+	switch {
+	case rd.DocStyles == nil:
+		b.err = fmt.Errorf("add style %q: the document has no styles part", id)
+	case id == "":
+		b.err = fmt.Errorf("add style %q: the style ID is empty", name)
+	case rd.styleIndex(id) >= 0:
+		b.err = fmt.Errorf("add style %q: a style with this ID already exists", id)
+	case name != "" && rd.StyleByName(name) != nil:
+		b.err = fmt.Errorf("add style %q: a style named %q already exists", id, name)
+	}
+	if b.err != nil {
+		return b
+	}
+
+	style := ctypes.Style{
+		ID:          &id,
+		Type:        &styleType,
+		CustomStyle: internal.ToPtr(stypes.OnOffOne),
+	}
+	if name != "" {
+		style.Name = ctypes.NewCTString(name)
+	}
+	if styleType != stypes.StyleTypeTable {
+		// Shown in the style gallery
+		style.QFormat = &ctypes.OnOff{}
+	}
+	rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, style)
+	return b
+}
+
+// styleIndex returns the index of the style with the given ID in the style list, -1 if there
+// is none.
+func (rd *RootDoc) styleIndex(id string) int {
+	if rd.DocStyles == nil {
+		return -1
+	}
+	for i, style := range rd.DocStyles.StyleList {
+		if style.ID != nil && *style.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeStyle removes the style with the given ID from the style list.
+func (rd *RootDoc) removeStyle(id string) {
+	if i := rd.styleIndex(id); i >= 0 {
+		rd.DocStyles.StyleList = append(rd.DocStyles.StyleList[:i], rd.DocStyles.StyleList[i+1:]...)
+	}
+}
+
+// Err returns the first error met while defining the style, nil if the style is valid.
+func (b *StyleBuilder) Err() error {
+	return b.err
+}
+
+// ID returns the ID of the style, to be passed to Paragraph.Style, Run.Style or Table.Style.
+func (b *StyleBuilder) ID() string {
+	return b.id
+}
+
+// Style returns the definition of the style in the document, nil if it could not be added.
+func (b *StyleBuilder) Style() *ctypes.Style {
+	if !b.ok() {
+		return nil
+	}
+	return b.style()
+}
+
+// ok reports whether the style can be changed: no error was met and the style is still in
+// the document.
+func (b *StyleBuilder) ok() bool {
+	if b.err == nil && b.root.styleIndex(b.id) < 0 {
+		b.err = fmt.Errorf("style %q: the style was removed from the document", b.id)
+	}
+	if b.err == nil && b.linked != "" && b.root.styleIndex(b.linked) < 0 {
+		b.err = fmt.Errorf("style %q: the linked style %q was removed from the document", b.id, b.linked)
+	}
+	return b.err == nil
+}
+
+// style returns the definition of the style in the style list. Styles added afterwards may
+// move the list, so the definition is looked up on each change.
+func (b *StyleBuilder) style() *ctypes.Style {
+	return &b.root.DocStyles.StyleList[b.root.styleIndex(b.id)]
+}
+
+// paraProp returns the paragraph properties of the style, nil with the error recorded if the
+// style is a character style.
+func (b *StyleBuilder) paraProp(method string) *ctypes.ParagraphProp {
+	if !b.ok() {
+		return nil
+	}
+	if b.styleType == stypes.StyleTypeCharacter {
+		b.err = fmt.Errorf("style %q: %s sets paragraph formatting on a character style", b.id, method)
+		return nil
+	}
+	style := b.style()
+	if style.ParaProp == nil {
+		style.ParaProp = &ctypes.ParagraphProp{}
+	}
+	return style.ParaProp
+}
+
+// tableProp returns the table properties of the style, nil with the error recorded if the
+// style is not a table style.
+func (b *StyleBuilder) tableProp(method string) *ctypes.TableProp {
+	if !b.ok() {
+		return nil
+	}
+	if b.styleType != stypes.StyleTypeTable {
+		b.err = fmt.Errorf("style %q: %s sets table formatting on a %s style", b.id, method, b.styleType)
+		return nil
+	}
+	style := b.style()
+	if style.TableProp == nil {
+		style.TableProp = &ctypes.TableProp{}
+	}
+	return style.TableProp
+}
+
+// setRunProp calls set with the run properties of the style and of its linked character style.
+func (b *StyleBuilder) setRunProp(set func(prop *ctypes.RunProperty)) *StyleBuilder {
+	if !b.ok() {
+		return b
+	}
+	ids := []string{b.id}
+	if b.linked != "" {
+		ids = append(ids, b.linked)
+	}
+	for _, id := range ids {
+		style := &b.root.DocStyles.StyleList[b.root.styleIndex(id)]
+		if style.RunProp == nil {
+			style.RunProp = &ctypes.RunProperty{}
+		}
+		set(style.RunProp)
+	}
+	return b
+}
+
+// BasedOn makes the style inherit the formatting of an existing style of the same type, other
+// than the style itself and the styles inheriting from it.
+func (b *StyleBuilder) BasedOn(id string) *StyleBuilder {
+	if !b.ok() {
+		return b
+	}
+	parent := b.root.GetStyleByID(id, b.styleType)
+	if parent == nil {
+		b.err = fmt.Errorf("style %q: based on %q: no %s style with this ID", b.id, id, b.styleType)
+		return b
+	}
+	if b.root.inheritsFrom(id, b.id) {
+		b.err = fmt.Errorf("style %q: based on %q: the style would inherit from itself", b.id, id)
+		return b
+	}
+	b.style().BasedOn = ctypes.NewCTString(id)
+	if b.linked != "" && parent.Link != nil && b.root.GetStyleByID(parent.Link.Val, stypes.StyleTypeCharacter) != nil &&
+		!b.root.inheritsFrom(parent.Link.Val, b.linked) {
+		linked := &b.root.DocStyles.StyleList[b.root.styleIndex(b.linked)]
+		linked.BasedOn = ctypes.NewCTString(parent.Link.Val)
+	}
+	return b
+}
+
+// inheritsFrom reports whether the style id is the style ancestor or is based on it, directly
+// or through other styles.
+func (rd *RootDoc) inheritsFrom(id, ancestor string) bool {
+	seen := map[string]bool{}
+	for id != "" && !seen[id] {
+		if id == ancestor {
+			return true
+		}
+		seen[id] = true
+		index := rd.styleIndex(id)
+		if index < 0 || rd.DocStyles.StyleList[index].BasedOn == nil {
+			return false
+		}
+		id = rd.DocStyles.StyleList[index].BasedOn.Val
+	}
+	return false
+}
+
+// Next sets the paragraph style of the paragraph added after a paragraph of the style, in
+// an editor. The ID is the one of an existing paragraph style or of the style itself.
+func (b *StyleBuilder) Next(id string) *StyleBuilder {
+	if !b.ok() {
+		return b
+	}
+	if b.styleType != stypes.StyleTypeParagraph {
+		b.err = fmt.Errorf("style %q: next style set on a %s style", b.id, b.styleType)
+		return b
+	}
+	if id != b.id && b.root.GetStyleByID(id, stypes.StyleTypeParagraph) == nil {
+		b.err = fmt.Errorf("style %q: next %q: no paragraph style with this ID", b.id, id)
+		return b
+	}
+	b.style().Next = ctypes.NewCTString(id)
+	return b
+}
+
+// Font sets the font of the text.
+func (b *StyleBuilder) Font(font string) *StyleBuilder {
+	return b.setRunProp(func(prop *ctypes.RunProperty) {
+		prop.Fonts = &ctypes.RunFonts{Ascii: font, HAnsi: font}
+	})
+}
+
+// Size sets the font size of the text in points.
+func (b *StyleBuilder) Size(size uint64) *StyleBuilder {
+	return b.setRunProp(func(prop *ctypes.RunProperty) {
+		prop.Size = ctypes.NewFontSize(size * 2)
+	})
+}
+
+// Bold enables or disables bold text.
+func (b *StyleBuilder) Bold(value bool) *StyleBuilder {
+	return b.setRunProp(func(prop *ctypes.RunProperty) {
+		prop.Bold = ctypes.OnOffFromBool(value)
+	})
+}
+
+// Italic enables or disables italic text.
+func (b *StyleBuilder) Italic(value bool) *StyleBuilder {
+	return b.setRunProp(func(prop *ctypes.RunProperty) {
+		prop.Italic = ctypes.OnOffFromBool(value)
+	})
+}
+
+// Underline sets the underline of the text.
+func (b *StyleBuilder) Underline(value stypes.Underline) *StyleBuilder {
+	return b.setRunProp(func(prop *ctypes.RunProperty) {
+		prop.Underline = ctypes.NewGenSingleStrVal(value)
+	})
+}
+
+// Color sets the color of the text, e.g. "FF0000" for red.
+func (b *StyleBuilder) Color(colorCode string) *StyleBuilder {
+	return b.setRunProp(func(prop *ctypes.RunProperty) {
+		prop.Color = ctypes.NewColor(colorCode)
+	})
+}
+
+// Spacing sets the spacing above and below the paragraphs, in twips.
+func (b *StyleBuilder) Spacing(before, after uint64) *StyleBuilder {
+	if prop := b.paraProp("Spacing"); prop != nil {
+		prop.Spacing = ctypes.NewParagraphSpacing(before, after)
+	}
+	return b
+}
+
+// Justification sets the alignment of the paragraphs.
+func (b *StyleBuilder) Justification(value stypes.Justification) *StyleBuilder {
+	if prop := b.paraProp("Justification"); prop != nil {
+		prop.Justification = ctypes.NewGenSingleStrVal(value)
+	}
+	return b
+}
+
+// Indent sets the indentation of the paragraphs.
+func (b *StyleBuilder) Indent(indent *ctypes.Indent) *StyleBuilder {
+	if prop := b.paraProp("Indent"); prop != nil {
+		prop.Indent = indent
+	}
+	return b
+}
+
+// KeepNext keeps the paragraphs on the same page as the next paragraph.
+func (b *StyleBuilder) KeepNext(value bool) *StyleBuilder {
+	if prop := b.paraProp("KeepNext"); prop != nil {
+		prop.KeepNext = ctypes.OnOffFromBool(value)
+	}
+	return b
+}
+
+// OutlineLevel sets the outline level of the paragraphs, 0 for the level of Heading 1.
+func (b *StyleBuilder) OutlineLevel(level int) *StyleBuilder {
+	if prop := b.paraProp("OutlineLevel"); prop != nil {
+		prop.OutlineLvl = ctypes.NewDecimalNum(level)
+	}
+	return b
+}
+
+// Borders sets the borders of the table and between its cells.
+func (b *StyleBuilder) Borders(borders *ctypes.TableBorders) *StyleBuilder {
+	if prop := b.tableProp("Borders"); prop != nil {
+		prop.Borders = borders
+	}
+	return b
+}
+
+// CellMargin sets the default margins of the table cells.
+func (b *StyleBuilder) CellMargin(margins *ctypes.CellMargins) *StyleBuilder {
+	if prop := b.tableProp("CellMargin"); prop != nil {
+		prop.CellMargin = margins
+	}
+	return b
+}
+
+// Shading sets the background color of the table cells, e.g. "D9D9D9" for light gray.
+func (b *StyleBuilder) Shading(fill string) *StyleBuilder {
+	if b.tableProp("Shading") != nil {
+		style := b.style()
+		if style.TableCellProp == nil {
+			style.TableCellProp = &ctypes.CellProperty{}
+		}
+		style.TableCellProp.Shading = clearShading(fill)
+	}
+	return b
+}
+
+// Conditional returns the formatting of a region of the table, such as the first row or the
+// odd banded rows, applied over the formatting of the whole table. The regions of the table
+// are selected by the table look of each table using the style.
+func (b *StyleBuilder) Conditional(region stypes.TblStyleOverrideType) *TableRegionStyle {
+	r := &TableRegionStyle{style: b, region: region}
+	if b.tableProp("Conditional") == nil {
+		return r
+	}
+	style := b.style()
+	for _, tsp := range style.TableStylePr {
+		if tsp.Type == region {
+			return r
+		}
+	}
+	style.TableStylePr = append(style.TableStylePr, ctypes.TableStyleProp{Type: region})
+	return r
+}
+
+// TableRegionStyle defines the formatting of a region of a table style. Its methods return
+// the region, so that calls can be chained. Errors are recorded by the table style builder.
+type TableRegionStyle struct {
+	style  *StyleBuilder
+	region stypes.TblStyleOverrideType
+}
+
+// prop returns the conditional formatting of the region, nil if the table style is invalid.
+func (r *TableRegionStyle) prop() *ctypes.TableStyleProp {
+	if !r.style.ok() {
+		return nil
+	}
+	style := r.style.style()
+	for i := range style.TableStylePr {
+		if style.TableStylePr[i].Type == r.region {
+			return &style.TableStylePr[i]
+		}
+	}
+	return nil
+}
+
+// runProp returns the run properties of the region, nil if the table style is invalid.
+func (r *TableRegionStyle) runProp() *ctypes.RunProperty {
+	prop := r.prop()
+	if prop == nil {
+		return nil
+	}
+	if prop.RunProp == nil {
+		prop.RunProp = &ctypes.RunProperty{}
+	}
+	return prop.RunProp
+}
+
+// cellProp returns the cell properties of the region, nil if the table style is invalid.
+func (r *TableRegionStyle) cellProp() *ctypes.CellProperty {
+	prop := r.prop()
+	if prop == nil {
+		return nil
+	}
+	if prop.CellProp == nil {
+		prop.CellProp = &ctypes.CellProperty{}
+	}
+	return prop.CellProp
+}
+
+// Bold enables or disables bold text in the region.
+func (r *TableRegionStyle) Bold(value bool) *TableRegionStyle {
+	if prop := r.runProp(); prop != nil {
+		prop.Bold = ctypes.OnOffFromBool(value)
+	}
+	return r
+}
+
+// Italic enables or disables italic text in the region.
+func (r *TableRegionStyle) Italic(value bool) *TableRegionStyle {
+	if prop := r.runProp(); prop != nil {
+		prop.Italic = ctypes.OnOffFromBool(value)
+	}
+	return r
+}
+
+// Color sets the color of the text in the region.
+func (r *TableRegionStyle) Color(colorCode string) *TableRegionStyle {
+	if prop := r.runProp(); prop != nil {
+		prop.Color = ctypes.NewColor(colorCode)
+	}
+	return r
+}
+
+// Justification sets the alignment of the paragraphs in the region.
+func (r *TableRegionStyle) Justification(value stypes.Justification) *TableRegionStyle {
+	if prop := r.prop(); prop != nil {
+		if prop.ParaProp == nil {
+			prop.ParaProp = &ctypes.ParagraphProp{}
+		}
+		prop.ParaProp.Justification = ctypes.NewGenSingleStrVal(value)
+	}
+	return r
+}
+
+// Shading sets the background color of the cells in the region.
+func (r *TableRegionStyle) Shading(fill string) *TableRegionStyle {
+	if prop := r.cellProp(); prop != nil {
+		prop.Shading = clearShading(fill)
+	}
+	return r
+}
+
+// Borders sets the borders of the cells in the region.
+func (r *TableRegionStyle) Borders(borders *ctypes.CellBorders) *TableRegionStyle {
+	if prop := r.cellProp(); prop != nil {
+		prop.Borders = borders
+	}
+	return r
+}
+
+// clearShading returns a shading filling the background with the color.
+func clearShading(fill string) *ctypes.Shading {
+	return &ctypes.Shading{Val: stypes.ShdClear, Color: internal.ToPtr("auto"), Fill: &fill}
+}
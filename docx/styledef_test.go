@@ -0,0 +1,112 @@
+package docx
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"godocx/internal"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddStyles(t *testing.T) {
+	marshal := func(v any) string {
+		out, err := xml.Marshal(v)
+		require.NoError(t, err)
+		return string(out)
+	}
+	rd := setupRootDoc(t)
+	rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, ctypes.Style{
+		ID:      internal.ToPtr("Normal"),
+		Name:    ctypes.NewCTString("Normal"),
+		Type:    internal.ToPtr(stypes.StyleTypeParagraph),
+		Default: internal.ToPtr(stypes.OnOffOne),
+	})
+
+	quote := rd.AddParagraphStyle("Quote", "Quote").
+		BasedOn("Normal").Next("Normal").Font("Georgia").Italic(true).Spacing(120, 240)
+	require.NoError(t, quote.Err())
+	assert.Equal(t, `<w:style w:type="paragraph" w:styleId="Quote" w:customStyle="1">`+
+		`<w:name w:val="Quote"></w:name><w:basedOn w:val="Normal"></w:basedOn><w:next w:val="Normal"></w:next>`+
+		`<w:qFormat></w:qFormat><w:pPr><w:spacing w:before="120" w:after="240"></w:spacing></w:pPr>`+
+		`<w:rPr><w:rFonts w:ascii="Georgia" w:hAnsi="Georgia"></w:rFonts><w:i w:val="true"></w:i></w:rPr></w:style>`,
+		marshal(quote.Style()))
+
+	emphasis := rd.AddCharacterStyle("Emphasis", "Emphasis").Bold(true).Color("FF0000")
+	require.NoError(t, emphasis.Err())
+	assert.NotNil(t, rd.GetStyleByID("Emphasis", stypes.StyleTypeCharacter).RunProp.Color)
+
+	// Styles added afterwards do not lose the changes
+	rd.AddParagraphStyle("Other", "Other")
+	quote.Justification(stypes.JustificationCenter)
+	assert.NotNil(t, rd.GetStyleByID("Quote", stypes.StyleTypeParagraph).ParaProp.Justification)
+
+	note := rd.AddLinkedStyle("Note", "Note").BasedOn("Quote").Size(9)
+	require.NoError(t, note.Err())
+	para := rd.GetStyleByID("Note", stypes.StyleTypeParagraph)
+	char := rd.GetStyleByID("NoteChar", stypes.StyleTypeCharacter)
+	require.NotNil(t, char)
+	assert.Equal(t, "NoteChar", para.Link.Val)
+	assert.Equal(t, "Note", char.Link.Val)
+	assert.Equal(t, "Note Char", char.Name.Val)
+	assert.Equal(t, uint64(18), para.RunProp.Size.Value)
+	assert.Equal(t, uint64(18), char.RunProp.Size.Value)
+	assert.Nil(t, char.BasedOn)
+
+	grid := rd.AddTableStyle("Grid", "Grid").Borders(&ctypes.TableBorders{
+		Top: ctypes.NewCellBorder(stypes.BorderStyleSingle, "auto", "0", 4),
+	})
+	grid.Conditional(stypes.TblStyleOverrideFirstRow).Bold(true).Shading("D9D9D9")
+	grid.Conditional(stypes.TblStyleOverrideFirstRow).Justification(stypes.JustificationCenter)
+	grid.Conditional(stypes.TblStyleOverrideBand1Horz).Shading("F2F2F2")
+	require.NoError(t, grid.Err())
+	table := rd.GetStyleByID("Grid", stypes.StyleTypeTable)
+	require.Len(t, table.TableStylePr, 2)
+	assert.Equal(t, `<w:tblStylePr w:type="firstRow"><w:pPr><w:jc w:val="center"></w:jc></w:pPr>`+
+		`<w:rPr><w:b w:val="true"></w:b></w:rPr><w:tcPr><w:shd w:val="clear" w:color="auto" w:fill="D9D9D9"></w:shd></w:tcPr></w:tblStylePr>`,
+		marshal(&table.TableStylePr[0]))
+	assert.Nil(t, table.QFormat)
+}
+
+func TestAddStylesInvalid(t *testing.T) {
+	rd := setupRootDoc(t)
+	require.NoError(t, rd.AddParagraphStyle("Body", "Body").Err())
+	root := rd.AddParagraphStyle("Root", "Root")
+	require.NoError(t, rd.AddParagraphStyle("Leaf", "Leaf").BasedOn("Root").Err())
+
+	tests := []struct {
+		name  string
+		style *StyleBuilder
+		err   string
+	}{
+		{"ID taken", rd.AddCharacterStyle("Body", "Body text"), `a style with this ID already exists`},
+		{"name taken", rd.AddParagraphStyle("Body2", "Body"), `a style named "Body" already exists`},
+		{"empty ID", rd.AddParagraphStyle("", "Empty"), `the style ID is empty`},
+		{"missing base", rd.AddParagraphStyle("A", "A").BasedOn("Missing"), `no paragraph style with this ID`},
+		{"base of other type", rd.AddCharacterStyle("B", "B").BasedOn("Body"), `no character style with this ID`},
+		{"based on itself", rd.AddParagraphStyle("S", "S").BasedOn("S"), `the style would inherit from itself`},
+		{"based on a descendant", root.BasedOn("Leaf"), `the style would inherit from itself`},
+		{"missing next", rd.AddParagraphStyle("C", "C").Next("Missing"), `next "Missing"`},
+		{"paragraph formatting", rd.AddCharacterStyle("D", "D").Spacing(0, 0), `paragraph formatting on a character style`},
+		{"table formatting", rd.AddParagraphStyle("E", "E").Shading("FFFFFF"), `table formatting on a paragraph style`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorContains(t, tt.style.Err(), tt.err)
+		})
+	}
+
+	// The first error is kept and the following calls are ignored
+	first := rd.AddParagraphStyle("F", "F").BasedOn("Missing").Bold(true)
+	assert.ErrorContains(t, first.Err(), `"Missing"`)
+	assert.Nil(t, rd.GetStyleByID("F", stypes.StyleTypeParagraph).RunProp)
+	assert.Nil(t, first.Style())
+
+	// A linked style whose character style ID is taken is not added
+	rd.AddCharacterStyle("XChar", "X char")
+	assert.Error(t, rd.AddLinkedStyle("X", "X").Err())
+	assert.Nil(t, rd.GetStyleByID("X", stypes.StyleTypeParagraph))
+}
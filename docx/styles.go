@@ -1,6 +1,12 @@
 package docx
 
 import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"godocx/common/constants"
 	"godocx/wml/ctypes"
 	"godocx/wml/stypes"
 )
@@ -32,3 +38,245 @@ func (rd *RootDoc) GetStyleByID(styleID string, styleType stypes.StyleType) *cty
 	}
 	return nil
 }
+
+// StyleByName returns the style with the given name, ignoring case, nil if the document defines
+// none. Localised Word templates translate the IDs of the built-in styles but keep their names:
+// the "heading 1" style of a German template has the ID "berschrift1". The returned style is the
+// one of the document, changing it changes the document styles.
+//
+// Example:
+//
+//	if style := document.StyleByName("heading 1"); style != nil {
+//		document.AddParagraph("Introduction").Style(*style.ID)
+//	}
+func (rd *RootDoc) StyleByName(name string) *ctypes.Style {
+	if rd.DocStyles == nil {
+		return nil
+	}
+	for i, style := range rd.DocStyles.StyleList {
+		if style.Name != nil && strings.EqualFold(style.Name.Val, name) {
+			return &rd.DocStyles.StyleList[i]
+		}
+	}
+	return nil
+}
+
+// lookupStyle returns the style of the given type with the given ID or name, nil if the document
+// defines none. A built-in style is also found through its canonical ID or name, so that the
+// Heading1 style is found in templates where it has a localised ID.
+func (rd *RootDoc) lookupStyle(idOrName string, styleType stypes.StyleType) *ctypes.Style {
+	candidates := []string{idOrName}
+	if builtin := builtinStyle(idOrName); builtin != nil {
+		candidates = append(candidates, *builtin.ID, builtin.Name.Val)
+	}
+	for _, candidate := range candidates {
+		if style := rd.GetStyleByID(candidate, styleType); style != nil {
+			return style
+		}
+		if style := rd.StyleByName(candidate); style != nil && style.Type != nil && *style.Type == styleType {
+			return style
+		}
+	}
+	return nil
+}
+
+// partStyleRefRe matches the style references of the XML parts held as bytes, such as the
+// numbering, footnotes, endnotes and comments parts.
+var partStyleRefRe = regexp.MustCompile(`(<w:(?:pStyle|rStyle|tblStyle|styleLink|numStyleLink) w:val=")([^"]*)(")`)
+
+// RemoveUnusedStyles removes the styles that are not used by the body, the headers and footers,
+// the lists, the footnotes, endnotes and comments, nor by the styles they derive from, link to
+// or are followed by. The default styles are kept. It returns the IDs of the removed styles.
+//
+// Example:
+//
+//	removed, err := document.RemoveUnusedStyles()
+//	fmt.Printf("removed %d styles\n", len(removed))
+func (rd *RootDoc) RemoveUnusedStyles() ([]string, error) {
+	if rd.DocStyles == nil {
+		return nil, nil
+	}
+
+	used := map[string]bool{}
+	var queue []string
+	use := func(id string) {
+		if id != "" && !used[id] {
+			used[id] = true
+			queue = append(queue, id)
+		}
+	}
+	err := rd.eachStyleUsage(func(ref *ctypes.CTString) {
+		use(ref.Val)
+	}, func(content []byte) []byte {
+		for _, m := range partStyleRefRe.FindAllSubmatch(content, -1) {
+			use(string(m[2]))
+		}
+		return content
+	})
+	if err != nil {
+		return nil, fmt.Errorf("remove unused styles: %w", err)
+	}
+	for _, style := range rd.DocStyles.StyleList {
+		if style.ID != nil && isDefaultStyle(&style) {
+			use(*style.ID)
+		}
+	}
+	for len(queue) > 0 {
+		index := rd.styleIndex(queue[0])
+		queue = queue[1:]
+		if index < 0 {
+			continue
+		}
+		style := rd.DocStyles.StyleList[index]
+		for _, ref := range []*ctypes.CTString{style.BasedOn, style.Link, style.Next} {
+			if ref != nil {
+				use(ref.Val)
+			}
+		}
+	}
+
+	var removed []string
+	rd.DocStyles.StyleList = slices.DeleteFunc(rd.DocStyles.StyleList, func(style ctypes.Style) bool {
+		if style.ID == nil || used[*style.ID] {
+			return false
+		}
+		removed = append(removed, *style.ID)
+		return true
+	})
+	return removed, nil
+}
+
+// RenameStyle changes the ID of a style, updating the references of the content, the lists, the
+// notes, the comments and the other styles.
+//
+// Example:
+//
+//	err := document.RenameStyle("Heading1Custom", "Chapter")
+func (rd *RootDoc) RenameStyle(oldID, newID string) error {
+	index := rd.styleIndex(oldID)
+	switch {
+	case index < 0:
+		return fmt.Errorf("rename style %q: style not found", oldID)
+	case newID == "":
+		return fmt.Errorf("rename style %q: the new style ID is empty", oldID)
+	case oldID == newID:
+		return nil
+	case rd.styleIndex(newID) >= 0:
+		return fmt.Errorf("rename style %q: a style with ID %q already exists", oldID, newID)
+	}
+
+	if err := rd.replaceStyleRefs(oldID, newID); err != nil {
+		return fmt.Errorf("rename style %q: %w", oldID, err)
+	}
+	rd.DocStyles.StyleList[index].ID = &newID
+	for i := range rd.DocStyles.StyleList {
+		style := &rd.DocStyles.StyleList[i]
+		for _, ref := range []*ctypes.CTString{style.BasedOn, style.Link, style.Next} {
+			if ref != nil && ref.Val == oldID {
+				ref.Val = newID
+			}
+		}
+	}
+	return nil
+}
+
+// ReplaceStyle makes the content, the lists, the notes and the comments using the style oldID
+// use the style newID instead, and the styles based on or followed by oldID be based on or
+// followed by newID. Both styles must exist and be of the same type; the style oldID is kept and
+// can be removed by RemoveUnusedStyles.
+//
+// Example:
+//
+//	// Format the quotes of an imported document with the quote style of the template
+//	err := document.ReplaceStyle("ImportedQuote", "Quote")
+func (rd *RootDoc) ReplaceStyle(oldID, newID string) error {
+	oldIndex, newIndex := rd.styleIndex(oldID), rd.styleIndex(newID)
+	switch {
+	case oldIndex < 0:
+		return fmt.Errorf("replace style %q: style not found", oldID)
+	case newIndex < 0:
+		return fmt.Errorf("replace style %q: style %q not found", oldID, newID)
+	case oldID == newID:
+		return nil
+	}
+	oldStyle, newStyle := rd.DocStyles.StyleList[oldIndex], rd.DocStyles.StyleList[newIndex]
+	if oldStyle.Type == nil || newStyle.Type == nil || *oldStyle.Type != *newStyle.Type {
+		return fmt.Errorf("replace style %q: style %q is of another type", oldID, newID)
+	}
+
+	if err := rd.replaceStyleRefs(oldID, newID); err != nil {
+		return fmt.Errorf("replace style %q: %w", oldID, err)
+	}
+	for i := range rd.DocStyles.StyleList {
+		style := &rd.DocStyles.StyleList[i]
+		if i == newIndex {
+			// A style cannot be based on itself
+			continue
+		}
+		for _, ref := range []*ctypes.CTString{style.BasedOn, style.Next} {
+			if ref != nil && ref.Val == oldID {
+				ref.Val = newID
+			}
+		}
+	}
+	return nil
+}
+
+// replaceStyleRefs replaces the references to the style oldID by references to newID in the
+// content and the parts of the document, the styles being left unchanged.
+func (rd *RootDoc) replaceStyleRefs(oldID, newID string) error {
+	return rd.eachStyleUsage(func(ref *ctypes.CTString) {
+		if ref.Val == oldID {
+			ref.Val = newID
+		}
+	}, func(content []byte) []byte {
+		return partStyleRefRe.ReplaceAllFunc(content, func(ref []byte) []byte {
+			m := partStyleRefRe.FindSubmatch(ref)
+			if string(m[2]) != oldID {
+				return ref
+			}
+			return slices.Concat(m[1], []byte(newID), m[3])
+		})
+	})
+}
+
+// eachStyleUsage calls fn with the style references of the body, headers and footers and
+// numbering definitions, and rewrites the footnotes, endnotes and comments parts, held as
+// bytes, with part.
+func (rd *RootDoc) eachStyleUsage(fn func(ref *ctypes.CTString), part func(content []byte) []byte) error {
+	parts, err := rd.HeadersFooters()
+	if err != nil {
+		return err
+	}
+	eachStyleRef(rd.Document.Body.Children, fn)
+	for _, hf := range parts {
+		eachStyleRef(hf.Children, fn)
+	}
+
+	var paths []string
+	if rd.Numbering != nil {
+		if err := rd.Numbering.eachStyleRef(fn); err != nil {
+			return err
+		}
+	} else {
+		paths = append(paths, numberingPath)
+	}
+	for _, partPath := range append(paths, rd.notesPaths()...) {
+		if content, ok := rd.FileMap.Load(partPath); ok {
+			rd.FileMap.Store(partPath, part(content.([]byte)))
+		}
+	}
+	return nil
+}
+
+// notesPaths returns the paths of the footnotes, endnotes and comments parts of the document.
+func (rd *RootDoc) notesPaths() []string {
+	var paths []string
+	for _, rel := range rd.Document.DocRels.Relationships {
+		switch rel.Type {
+		case constants.SourceRelationshipFootnotes, constants.SourceRelationshipEndnotes, constants.SourceRelationshipComments:
+			paths = append(paths, rd.Document.partPath(rel.Target))
+		}
+	}
+	return paths
+}
@@ -0,0 +1,190 @@
+package docx
+
+import (
+	"testing"
+
+	"godocx/common/constants"
+	"godocx/internal"
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func styleCleanupTestDoc(t *testing.T) *RootDoc {
+	rd := setupRootDoc(t)
+	rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, ctypes.Style{
+		ID:      internal.ToPtr("Normal"),
+		Name:    ctypes.NewCTString("Normal"),
+		Type:    internal.ToPtr(stypes.StyleTypeParagraph),
+		Default: internal.ToPtr(stypes.OnOffOne),
+	})
+	require.NoError(t, rd.AddParagraphStyle("Base", "Base").Err())
+	require.NoError(t, rd.AddParagraphStyle("Heading", "Heading").BasedOn("Base").Err())
+	require.NoError(t, rd.AddParagraphStyle("Unused", "Unused").BasedOn("Heading").Err())
+	require.NoError(t, rd.AddLinkedStyle("Quote", "Quote").Err())
+	require.NoError(t, rd.AddCharacterStyle("Strong", "Strong").Err())
+	require.NoError(t, rd.AddTableStyle("Grid", "Grid").Err())
+	require.NoError(t, rd.AddParagraphStyle("ListItem", "List Item").Err())
+	require.NoError(t, rd.AddParagraphStyle("FootnoteText", "footnote text").Err())
+
+	rd.AddParagraph("Title").Style("Heading")
+	rd.AddParagraph("quoted").Style("Quote")
+	table := rd.AddTable()
+	table.Style("Grid")
+	table.AddRow().AddCell().AddParagraph("").AddText("strong").Style("Strong")
+
+	rd.FileMap.Store(numberingPath, []byte(`<w:numbering><w:abstractNum w:abstractNumId="1">`+
+		`<w:lvl w:ilvl="0"><w:pStyle w:val="ListItem"/></w:lvl></w:abstractNum></w:numbering>`))
+	rd.FileMap.Store("word/footnotes.xml", []byte(`<w:footnotes><w:footnote w:id="1"><w:p><w:pPr>`+
+		`<w:pStyle w:val="FootnoteText"/></w:pPr></w:p></w:footnote></w:footnotes>`))
+	rd.FileMap.Store("word/header1.xml", []byte(`<w:hdr><w:p><w:pPr><w:pStyle w:val="Base"/></w:pPr></w:p></w:hdr>`))
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships,
+		&Relationship{ID: "rId90", Type: constants.SourceRelationshipFootnotes, Target: "footnotes.xml"},
+		&Relationship{ID: "rId91", Type: constants.SourceRelationshipHeader, Target: "header1.xml"},
+	)
+	return rd
+}
+
+func styleIDs(rd *RootDoc) []string {
+	var ids []string
+	for _, style := range rd.DocStyles.StyleList {
+		ids = append(ids, *style.ID)
+	}
+	return ids
+}
+
+func TestRemoveUnusedStyles(t *testing.T) {
+	rd := styleCleanupTestDoc(t)
+	rd.DocStyles.StyleList[rd.styleIndex("Base")].Next = ctypes.NewCTString("Unused")
+	require.NoError(t, rd.AddCharacterStyle("Orphan", "Orphan").Err())
+
+	removed, err := rd.RemoveUnusedStyles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Orphan"}, removed)
+
+	// Without the next style reference, the derived style is unused as well
+	rd.DocStyles.StyleList[rd.styleIndex("Base")].Next = nil
+	removed, err = rd.RemoveUnusedStyles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Unused"}, removed)
+	assert.Equal(t, []string{"Normal", "Base", "Heading", "Quote", "QuoteChar", "Strong", "Grid", "ListItem", "FootnoteText"}, styleIDs(rd))
+}
+
+func TestRemoveUnusedStyles_KeepsFooterFields(t *testing.T) {
+	rd := styleCleanupTestDoc(t)
+	rd.Document.relativePath = "word/document.xml"
+	footer := []byte(`<w:ftr xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:p><w:pPr><w:pStyle w:val="Base"/></w:pPr><w:fldSimple w:instr="PAGE"><w:r><w:t>1</w:t></w:r></w:fldSimple></w:p></w:ftr>`)
+	rd.FileMap.Store("word/footer1.xml", footer)
+	rd.Document.DocRels.Relationships = append(rd.Document.DocRels.Relationships,
+		&Relationship{ID: "rId92", Type: constants.SourceRelationshipFooter, Target: "footer1.xml"})
+
+	removed, err := rd.RemoveUnusedStyles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Unused"}, removed)
+	assert.Equal(t, footer, savedPart(t, rd, "word/footer1.xml"))
+}
+
+func TestRenameStyle(t *testing.T) {
+	rd := styleCleanupTestDoc(t)
+	require.NoError(t, rd.RenameStyle("Base", "Chapter"))
+	require.NoError(t, rd.RenameStyle("Grid", "PlainGrid"))
+	require.NoError(t, rd.RenameStyle("QuoteChar", "QuoteCharacter"))
+	require.NoError(t, rd.RenameStyle("ListItem", "ListParagraph"))
+
+	assert.Equal(t, "Chapter", rd.GetStyleByID("Heading", stypes.StyleTypeParagraph).BasedOn.Val)
+	assert.Equal(t, "QuoteCharacter", rd.GetStyleByID("Quote", stypes.StyleTypeParagraph).Link.Val)
+	assert.NotNil(t, rd.GetStyleByID("PlainGrid", stypes.StyleTypeTable))
+	assert.Equal(t, "PlainGrid", rd.Document.Body.Children[2].Table.GetCT().TableProp.Style.Val)
+	parts, err := rd.HeadersFooters()
+	require.NoError(t, err)
+	assert.Equal(t, "Chapter", parts[0].Children[0].Para.GetCT().Property.Style.Val)
+	assert.Contains(t, savedNumbering(t, rd), `<w:pStyle w:val="ListParagraph"/>`)
+
+	assert.ErrorContains(t, rd.RenameStyle("Missing", "Other"), "style not found")
+	assert.ErrorContains(t, rd.RenameStyle("Heading", "Chapter"), `a style with ID "Chapter" already exists`)
+}
+
+func TestStyles_TrackedChanges(t *testing.T) {
+	rd := styleCleanupTestDoc(t)
+	require.NoError(t, rd.AddCharacterStyle("Added", "Added").Err())
+	require.NoError(t, rd.AddCharacterStyle("Removed", "Removed").Err())
+	styled := func(style string, child ctypes.RunChild) *ctypes.Run {
+		return &ctypes.Run{Property: &ctypes.RunProperty{Style: ctypes.NewCTString(style)}, Children: []ctypes.RunChild{child}}
+	}
+	p := rd.AddParagraph("Text ")
+	p.ct.Children = append(p.ct.Children,
+		ctypes.ParagraphChild{Ins: &ctypes.RunTrackChange{Runs: []*ctypes.Run{
+			styled("Added", ctypes.RunChild{Text: ctypes.TextFromString("new")}),
+		}}},
+		ctypes.ParagraphChild{Del: &ctypes.RunTrackChange{Runs: []*ctypes.Run{
+			styled("Removed", ctypes.RunChild{DelText: ctypes.TextFromString("old")}),
+		}}},
+	)
+
+	removed, err := rd.RemoveUnusedStyles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Unused"}, removed)
+
+	require.NoError(t, rd.RenameStyle("Added", "Inserted"))
+	require.NoError(t, rd.RenameStyle("Removed", "Deleted"))
+	assert.Equal(t, "Inserted", p.ct.Children[1].Ins.Runs[0].Property.Style.Val)
+	assert.Equal(t, "Deleted", p.ct.Children[2].Del.Runs[0].Property.Style.Val)
+}
+
+func TestReplaceStyle(t *testing.T) {
+	rd := styleCleanupTestDoc(t)
+	require.NoError(t, rd.ReplaceStyle("Heading", "Base"))
+	require.NoError(t, rd.ReplaceStyle("FootnoteText", "Normal"))
+
+	assert.Equal(t, "Base", rd.Document.Body.Children[0].Para.GetCT().Property.Style.Val)
+	assert.Equal(t, "Base", rd.GetStyleByID("Unused", stypes.StyleTypeParagraph).BasedOn.Val)
+	assert.Nil(t, rd.GetStyleByID("Base", stypes.StyleTypeParagraph).BasedOn)
+	footnotes, _ := rd.FileMap.Load("word/footnotes.xml")
+	assert.Contains(t, string(footnotes.([]byte)), `<w:pStyle w:val="Normal"/>`)
+
+	removed, err := rd.RemoveUnusedStyles()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Heading", "Unused", "FootnoteText"}, removed)
+
+	assert.ErrorContains(t, rd.ReplaceStyle("Strong", "Base"), "of another type")
+	assert.ErrorContains(t, rd.ReplaceStyle("Strong", "Missing"), `style "Missing" not found`)
+}
+
+func TestStyleByName(t *testing.T) {
+	// Styles of a German template, the built-in styles keeping their English names
+	rd := setupRootDoc(t)
+	rd.DocStyles.StyleList = append(rd.DocStyles.StyleList, ctypes.Style{
+		ID:      internal.ToPtr("Standard"),
+		Name:    ctypes.NewCTString("Normal"),
+		Type:    internal.ToPtr(stypes.StyleTypeParagraph),
+		Default: internal.ToPtr(stypes.OnOffOne),
+	})
+	require.NoError(t, rd.AddParagraphStyle("berschrift1", "heading 1").BasedOn("Standard").Err())
+	require.NoError(t, rd.AddParagraphStyle("Zitat", "Quote").BasedOn("Standard").Err())
+
+	assert.Equal(t, "berschrift1", *rd.StyleByName("Heading 1").ID)
+	assert.Nil(t, rd.StyleByName("heading 2"))
+
+	heading, err := rd.AddHeading("Einleitung", 1)
+	require.NoError(t, err)
+	assert.Equal(t, "berschrift1", heading.GetCT().Property.Style.Val)
+	require.NoError(t, rd.AppendMarkdown([]byte("## Abschnitt\n\n> Zitat\n"), nil))
+	assert.Equal(t, "Zitat", rd.Document.Body.Children[2].Para.GetCT().Property.Style.Val)
+
+	// Built-in styles are based on the styles of the template
+	id, err := rd.AddBuiltinStyle("heading 2")
+	require.NoError(t, err)
+	assert.Equal(t, "Heading2", id)
+	assert.Equal(t, "Standard", rd.GetStyleByID("Heading2", stypes.StyleTypeParagraph).BasedOn.Val)
+	id, err = rd.AddBuiltinStyle("Heading1")
+	require.NoError(t, err)
+	assert.Equal(t, "berschrift1", id)
+
+	rd.AddParagraph("Titel").Style("Heading1")
+	rd.addMissingBuiltinStyles()
+	assert.Equal(t, "berschrift1", rd.Document.Body.Children[3].Para.GetCT().Property.Style.Val)
+	assert.Nil(t, rd.GetStyleByID("Heading1", stypes.StyleTypeParagraph))
+}
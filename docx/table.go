@@ -13,10 +13,13 @@ type Table struct {
 
 	// Table Complex Type
 	ct ctypes.Table
+
+	// ref, when set, is the table of the document the wrapper stands for, used instead of ct
+	ref *ctypes.Table
 }
 
 func (t *Table) unmarshalXML(d *xml.Decoder, start xml.StartElement) error {
-	return t.ct.UnmarshalXML(d, start)
+	return t.GetCT().UnmarshalXML(d, start)
 }
 
 func (t *Table) Width(v int, u stypes.TableWidth) *Table {
@@ -24,7 +27,7 @@ func (t *Table) Width(v int, u stypes.TableWidth) *Table {
 		Width:     &v,
 		WidthType: &u,
 	}
-	t.ct.TableProp.Width = &w
+	t.GetCT().TableProp.Width = &w
 	return t
 }
 
@@ -37,13 +40,13 @@ func (t *Table) Grid(widths ...uint64) *Table {
 	for _, w := range widths {
 		tw := w
 		col := ctypes.Column{Width: &tw}
-		t.ct.Grid.Col = append(t.ct.Grid.Col, col)
+		t.GetCT().Grid.Col = append(t.GetCT().Grid.Col, col)
 	}
 	return t
 }
 
 func (t *Table) CellMargin(top *ctypes.TableWidth, left *ctypes.TableWidth, bottom *ctypes.TableWidth, right *ctypes.TableWidth) *Table {
-	t.ct.TableProp.CellMargin = &ctypes.CellMargins{
+	t.GetCT().TableProp.CellMargin = &ctypes.CellMargins{
 		Top:    top,
 		Left:   left,
 		Bottom: bottom,
@@ -53,7 +56,7 @@ func (t *Table) CellMargin(top *ctypes.TableWidth, left *ctypes.TableWidth, bott
 }
 
 func (t *Table) Layout(layout stypes.TableLayout) *Table {
-	t.ct.TableProp.Layout = &ctypes.TableLayout{
+	t.GetCT().TableProp.Layout = &ctypes.TableLayout{
 		LayoutType: &layout,
 	}
 
@@ -62,6 +65,9 @@ func (t *Table) Layout(layout stypes.TableLayout) *Table {
 
 // GetCT returns a pointer to the underlying Table Complex Type.
 func (t *Table) GetCT() *ctypes.Table {
+	if t.ref != nil {
+		return t.ref
+	}
 	return &t.ct
 }
 
@@ -120,8 +126,8 @@ func (t *Table) AddRow() *Row {
 		ct:   *ctypes.DefaultRow(),
 	}
 
-	t.ct.RowContents = append(t.ct.RowContents, ctypes.RowContent{
-		Row: &row.ct,
+	t.GetCT().RowContents = append(t.GetCT().RowContents, ctypes.RowContent{
+		Row: row.GetCT(),
 	})
 
 	return &row
@@ -135,7 +141,7 @@ func (t *Table) ensureProp() {
 // Parameters:
 //   - indent: An integer specifying the indent width
 func (t *Table) Indent(indent int) {
-	t.ct.TableProp.Indent = ctypes.NewTableWidth(indent, stypes.TableWidthAuto)
+	t.GetCT().TableProp.Indent = ctypes.NewTableWidth(indent, stypes.TableWidthAuto)
 }
 
 // Style sets the style for the table.
@@ -184,7 +190,7 @@ func (t *Table) Indent(indent int) {
 // Parameters:
 //   - value: A string representing the style value. It should match a valid table style defined in the WordprocessingML specification.
 func (t *Table) Style(value string) {
-	t.ct.TableProp.Style = ctypes.NewCTString(value)
+	t.GetCT().TableProp.Style = ctypes.NewCTString(value)
 }
 
 // Row Wrapper
@@ -194,6 +200,17 @@ type Row struct {
 
 	// Row Complex Type
 	ct ctypes.Row
+
+	// ref, when set, is the row of the document the wrapper stands for, used instead of ct
+	ref *ctypes.Row
+}
+
+// GetCT returns a pointer to the underlying Row Complex Type.
+func (r *Row) GetCT() *ctypes.Row {
+	if r.ref != nil {
+		return r.ref
+	}
+	return &r.ct
 }
 
 // Add Cell to row and returns Cell
@@ -203,8 +220,8 @@ func (r *Row) AddCell() *Cell {
 		ct:   *ctypes.DefaultCell(),
 	}
 
-	r.ct.Contents = append(r.ct.Contents, ctypes.TRCellContent{
-		Cell: &cell.ct,
+	r.GetCT().Contents = append(r.GetCT().Contents, ctypes.TRCellContent{
+		Cell: cell.GetCT(),
 	})
 
 	return &cell
@@ -217,16 +234,27 @@ type Cell struct {
 
 	// Cell Complex Type
 	ct ctypes.Cell
+
+	// ref, when set, is the cell of the document the wrapper stands for, used instead of ct
+	ref *ctypes.Cell
+}
+
+// GetCT returns a pointer to the underlying Cell Complex Type.
+func (c *Cell) GetCT() *ctypes.Cell {
+	if c.ref != nil {
+		return c.ref
+	}
+	return &c.ct
 }
 
 // Adds paragraph with text and returns Paragraph
 func (c *Cell) AddParagraph(text string) *Paragraph {
 	p := newParagraph(c.root, paraWithText(text))
 	tblContent := ctypes.TCBlockContent{
-		Paragraph: &p.ct,
+		Paragraph: p.GetCT(),
 	}
 
-	c.ct.Contents = append(c.ct.Contents, tblContent)
+	c.GetCT().Contents = append(c.GetCT().Contents, tblContent)
 
 	return p
 }
@@ -235,27 +263,27 @@ func (c *Cell) AddParagraph(text string) *Paragraph {
 func (c *Cell) AddEmptyPara() *Paragraph {
 	p := newParagraph(c.root)
 	tblContent := ctypes.TCBlockContent{
-		Paragraph: &p.ct,
+		Paragraph: p.GetCT(),
 	}
 
-	c.ct.Contents = append(c.ct.Contents, tblContent)
+	c.GetCT().Contents = append(c.GetCT().Contents, tblContent)
 
 	return p
 }
 
 // ColSpan sets the number of columns a cell should span across in a table.
 func (c *Cell) ColSpan(cols int) *Cell {
-	if c.ct.Property != nil {
-		c.ct.Property.GridSpan = &ctypes.DecimalNum{Val: cols}
+	if c.GetCT().Property != nil {
+		c.GetCT().Property.GridSpan = &ctypes.DecimalNum{Val: cols}
 	}
 	return c
 }
 
 // RowSpan sets the cell to span vertically in a table, indicating it is part of a vertically merged group of cells.
 func (c *Cell) RowSpan() *Cell {
-	if c.ct.Property != nil {
+	if c.GetCT().Property != nil {
 		vMerge := ctypes.AnnotationVMergeRest
-		c.ct.Property.CellMerge = &ctypes.CellMerge{
+		c.GetCT().Property.CellMerge = &ctypes.CellMerge{
 			VMerge: &vMerge,
 		}
 	}
@@ -264,32 +292,32 @@ func (c *Cell) RowSpan() *Cell {
 
 // VerticalAlign sets the vertical alignment of a cell based on the provided string: "top", "center", "middle", or "bottom".
 func (c *Cell) VerticalAlign(valign string) *Cell {
-	if c.ct.Property != nil {
+	if c.GetCT().Property != nil {
 		switch valign {
 		case "top":
-			c.ct.Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcTop)
+			c.GetCT().Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcTop)
 		case "center", "middle":
-			c.ct.Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcCenter)
+			c.GetCT().Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcCenter)
 		case "bottom":
-			c.ct.Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcBottom)
+			c.GetCT().Property.VAlign = ctypes.NewGenSingleStrVal(stypes.VerticalJcBottom)
 		}
 	}
 	return c
 }
 
 func (c *Cell) BackgroundColor(color string) *Cell {
-	c.ct.Property.Shading.Fill = &color
+	c.GetCT().Property.Shading.Fill = &color
 	return c
 }
 
 func (c *Cell) Width(width int, widthType stypes.TableWidth) *Cell {
-	c.ct.Property.Width = ctypes.NewTableWidth(width, widthType)
+	c.GetCT().Property.Width = ctypes.NewTableWidth(width, widthType)
 	return c
 }
 
 func (c *Cell) Borders(top *ctypes.Border, left *ctypes.Border, bottom *ctypes.Border, right *ctypes.Border,
 	insideH *ctypes.Border, insideV *ctypes.Border, tl2br *ctypes.Border, tr2bl *ctypes.Border) *Cell {
-	c.ct.Property.Borders = &ctypes.CellBorders{
+	c.GetCT().Property.Borders = &ctypes.CellBorders{
 		Top:     top,
 		Left:    left,
 		Bottom:  bottom,
@@ -0,0 +1,403 @@
+package docx
+
+import (
+	"strings"
+
+	"godocx/common/units"
+	"godocx/wml/ctypes"
+)
+
+// TemplateImage is a value that can be placed into a document using the {{image ...}} function.
+// A nil width or height is calculated from the image keeping the aspect ratio, see Paragraph.AddImage.
+type TemplateImage struct {
+	Data   []byte
+	Width  units.Units
+	Height units.Units
+}
+
+// ExecuteTemplate evaluates the template actions written in the document body against data.
+//
+// The text of the paragraphs is a text/template template, with its syntax, its functions and
+// the trim markers {{- and -}}. Paragraphs, tables, rows and cells are elements of the template:
+//   - {{.Field}}, {{.Total | printf "%.2f"}} or {{if gt (len .Items) 5}} are evaluated as by
+//     text/template. A value keeps the formatting of the run in which its action starts, even if
+//     the action spans several runs.
+//   - {{image .Logo}} is replaced by a picture, the value being []byte or TemplateImage.
+//   - A block action such as {{range .Items}}, {{if .Discount}}, {{with .Address}}, {{else}} or
+//     {{end}} written as the only content of a paragraph repeats or drops every paragraph and
+//     table up to its {{end}}. Written as the only content of a table row it repeats or drops
+//     rows. A paragraph or row whose text starts with a block action and ends with its {{end}} is
+//     itself repeated or dropped. A block action within the text of a paragraph repeats or drops
+//     that text only.
+//
+// Output outside a paragraph, such as text between two directive-only paragraphs, is ignored.
+// A block that would end an element it did not start, such as a range opened within a paragraph
+// and closed in the next one, is an error.
+//
+// Example:
+//
+//	err := document.ExecuteTemplate(invoice)
+func (rd *RootDoc) ExecuteTemplate(data any) error {
+	return rd.Document.Body.ExecuteTemplate(data)
+}
+
+// ExecuteTemplate evaluates the template actions in the body against data, see RootDoc.ExecuteTemplate.
+func (b *Body) ExecuteTemplate(data any) error {
+	var src tmplSource
+	for _, child := range b.Children {
+		if child.Para != nil {
+			src.paragraph(child.Para.GetCT(), child)
+		}
+		if child.Table != nil {
+			src.table(child.Table.GetCT(), child)
+		}
+	}
+
+	tr := templateRunner{root: b.root, src: &src}
+	out, err := tr.execute(data)
+	if err != nil {
+		return err
+	}
+	b.Children = tr.bodyChildren(out)
+	return nil
+}
+
+// ExecuteTemplate evaluates the template actions in the rows and cells of the table
+// against data, see RootDoc.ExecuteTemplate.
+func (t *Table) ExecuteTemplate(data any) error {
+	var src tmplSource
+	ct := t.GetCT()
+	for _, rc := range ct.RowContents {
+		if rc.Row != nil {
+			src.row(rc.Row)
+		}
+	}
+
+	tr := templateRunner{root: t.root, src: &src}
+	out, err := tr.execute(data)
+	if err != nil {
+		return err
+	}
+	ct.RowContents = tr.rows(out)
+	return nil
+}
+
+// tmplSpanKind tells what a part of the template source stands for.
+type tmplSpanKind int
+
+const (
+	tmplSpanIgnore tmplSpanKind = iota // Actions and line breaks outside the paragraphs
+	tmplSpanText                       // Text of a paragraph
+	tmplSpanOpen                       // Start of an element
+	tmplSpanClose                      // End of the element last started
+)
+
+// Marks standing for the start and the end of an element in the template source
+const (
+	tmplOpenMark  = "\x01"
+	tmplCloseMark = "\x02"
+)
+
+// tmplSpan is a part of the template source.
+type tmplSpan struct {
+	start, end int
+	kind       tmplSpanKind
+	elem       int // Element started, or paragraph holding the text
+	offset     int // Offset of the text within the text of the paragraph
+}
+
+// tmplElem is a paragraph, table, row or cell of the template.
+type tmplElem struct {
+	para  *ctypes.Paragraph
+	text  string // Text of the paragraph
+	table *ctypes.Table
+	row   *ctypes.Row
+	cell  *ctypes.Cell
+	child DocumentChild // Body child holding the element, if any
+}
+
+// tmplSource is the template source built from document elements: the text of the paragraphs
+// and the actions standing alone in a paragraph or row, between marks for the elements.
+type tmplSource struct {
+	sb    strings.Builder
+	spans []tmplSpan
+	elems []tmplElem
+
+	// Part of the text of the paragraphs of a wrapped row within the block
+	inner map[*ctypes.Paragraph][2]int
+}
+
+func (s *tmplSource) add(kind tmplSpanKind, text string, elem, offset int) {
+	start := s.sb.Len()
+	s.sb.WriteString(text)
+	s.spans = append(s.spans, tmplSpan{start: start, end: s.sb.Len(), kind: kind, elem: elem, offset: offset})
+}
+
+func (s *tmplSource) open(e tmplElem) int {
+	s.elems = append(s.elems, e)
+	id := len(s.elems) - 1
+	s.add(tmplSpanOpen, tmplOpenMark, id, 0)
+	return id
+}
+
+// close ends the element last started. The line break keeps an element per line in the
+// positions of the errors.
+func (s *tmplSource) close() {
+	s.add(tmplSpanClose, tmplCloseMark, 0, 0)
+	s.add(tmplSpanIgnore, "\n", 0, 0)
+}
+
+// blocks adds the paragraphs and tables of a cell.
+func (s *tmplSource) blocks(items []ctypes.TCBlockContent) {
+	for _, item := range items {
+		if item.Paragraph != nil {
+			s.paragraph(item.Paragraph, DocumentChild{})
+		}
+		if item.Table != nil {
+			s.table(item.Table, DocumentChild{})
+		}
+	}
+}
+
+func (s *tmplSource) paragraph(p *ctypes.Paragraph, child DocumentChild) {
+	_, text := paraSegments(p)
+	elem := tmplElem{para: p, text: text, child: child}
+	if r, ok := s.inner[p]; ok {
+		id := s.open(elem)
+		s.add(tmplSpanText, text[r[0]:r[1]], id, r[0])
+		s.close()
+		return
+	}
+
+	actions := tmplActions(text)
+	if standaloneAction(text, actions) {
+		s.add(tmplSpanIgnore, text, 0, 0)
+		s.add(tmplSpanIgnore, "\n", 0, 0)
+		return
+	}
+	if wrappingActions(text, actions) {
+		first, last := actions[0], actions[len(actions)-1]
+		s.add(tmplSpanIgnore, text[first.start:first.end], 0, 0)
+		id := s.open(elem)
+		s.add(tmplSpanText, text[first.end:last.start], id, first.end)
+		s.close()
+		s.add(tmplSpanIgnore, text[last.start:last.end], 0, 0)
+		s.add(tmplSpanIgnore, "\n", 0, 0)
+		return
+	}
+	id := s.open(elem)
+	s.add(tmplSpanText, text, id, 0)
+	s.close()
+}
+
+func (s *tmplSource) table(t *ctypes.Table, child DocumentChild) {
+	s.open(tmplElem{table: t, child: child})
+	for _, rc := range t.RowContents {
+		if rc.Row != nil {
+			s.row(rc.Row)
+		}
+	}
+	s.close()
+}
+
+func (s *tmplSource) row(r *ctypes.Row) {
+	// The text of the paragraphs held directly by the cells decides how the row is repeated
+	var (
+		paras  []*ctypes.Paragraph
+		texts  []string
+		starts []int
+		sb     strings.Builder
+	)
+	for _, c := range r.Contents {
+		if c.Cell == nil {
+			continue
+		}
+		for _, b := range c.Cell.Contents {
+			if b.Paragraph != nil {
+				_, text := paraSegments(b.Paragraph)
+				paras = append(paras, b.Paragraph)
+				texts = append(texts, text)
+				starts = append(starts, sb.Len())
+				sb.WriteString(text)
+			}
+		}
+	}
+	text := sb.String()
+	actions := tmplActions(text)
+
+	if standaloneAction(text, actions) {
+		s.add(tmplSpanIgnore, text, 0, 0)
+		s.add(tmplSpanIgnore, "\n", 0, 0)
+		return
+	}
+
+	// The wrapping actions must each be written within a paragraph
+	paraAt := func(a tmplAction) int {
+		for i := len(paras) - 1; i >= 0; i-- {
+			if a.start >= starts[i] {
+				if a.end > starts[i]+len(texts[i]) {
+					return -1
+				}
+				return i
+			}
+		}
+		return -1
+	}
+	var first, last tmplAction
+	firstPara, lastPara := -1, -1
+	if wrappingActions(text, actions) {
+		first, last = actions[0], actions[len(actions)-1]
+		firstPara, lastPara = paraAt(first), paraAt(last)
+	}
+	if firstPara < 0 || lastPara < 0 {
+		s.open(tmplElem{row: r})
+		s.cells(r)
+		s.close()
+		return
+	}
+
+	if s.inner == nil {
+		s.inner = map[*ctypes.Paragraph][2]int{}
+	}
+	for i, p := range paras {
+		inner := [2]int{0, len(texts[i])}
+		if i == firstPara {
+			inner[0] = first.end - starts[i]
+		}
+		if i == lastPara {
+			inner[1] = last.start - starts[i]
+		}
+		s.inner[p] = inner
+	}
+	s.add(tmplSpanIgnore, text[first.start:first.end], 0, 0)
+	s.open(tmplElem{row: r})
+	s.cells(r)
+	s.close()
+	s.add(tmplSpanIgnore, text[last.start:last.end], 0, 0)
+	s.add(tmplSpanIgnore, "\n", 0, 0)
+	for _, p := range paras {
+		delete(s.inner, p)
+	}
+}
+
+func (s *tmplSource) cells(r *ctypes.Row) {
+	for _, c := range r.Contents {
+		if c.Cell == nil {
+			continue
+		}
+		s.open(tmplElem{cell: c.Cell})
+		s.blocks(c.Cell.Contents)
+		s.close()
+	}
+}
+
+// tmplAction is an action found in the text of a paragraph.
+type tmplAction struct {
+	start, end int    // Offsets of the delimiters
+	keyword    string // First word of the action, such as if, range or end
+}
+
+// tmplActions returns the actions written in the text. Strings and comments are skipped when
+// looking for the end of an action.
+func tmplActions(text string) []tmplAction {
+	var actions []tmplAction
+	for i := 0; ; {
+		open := strings.Index(text[i:], "{{")
+		if open < 0 {
+			return actions
+		}
+		start := i + open
+		end := tmplActionEnd(text, start+2)
+		if end < 0 {
+			return actions
+		}
+		inner := text[start+2 : end-2]
+		if strings.HasPrefix(inner, "- ") {
+			inner = inner[2:]
+		}
+		var keyword string
+		if fields := strings.Fields(inner); len(fields) > 0 {
+			keyword = fields[0]
+		}
+		actions = append(actions, tmplAction{start: start, end: end, keyword: keyword})
+		i = end
+	}
+}
+
+// tmplActionEnd returns the offset after the closing delimiter of the action whose content
+// starts at i, -1 if it is not closed.
+func tmplActionEnd(text string, i int) int {
+	for i < len(text) {
+		switch {
+		case strings.HasPrefix(text[i:], "}}"):
+			return i + 2
+		case strings.HasPrefix(text[i:], "/*"):
+			end := strings.Index(text[i+2:], "*/")
+			if end < 0 {
+				return -1
+			}
+			i += end + 4
+		case text[i] == '"' || text[i] == '\'' || text[i] == '`':
+			quote := text[i]
+			i++
+			for i < len(text) && text[i] != quote {
+				if text[i] == '\\' && quote != '`' {
+					i++
+				}
+				i++
+			}
+			i++
+		default:
+			i++
+		}
+	}
+	return -1
+}
+
+// standaloneAction reports whether the text holds nothing but a single block action, which
+// then stands for the elements up to the next one.
+func standaloneAction(text string, actions []tmplAction) bool {
+	if len(actions) != 1 || strings.TrimSpace(text[:actions[0].start]+text[actions[0].end:]) != "" {
+		return false
+	}
+	switch actions[0].keyword {
+	case "if", "range", "with", "else", "end", "block", "define", "break", "continue":
+		return true
+	}
+	return false
+}
+
+// wrappingActions reports whether the text starts with a block action and ends with its
+// {{end}}, so that the block repeats or drops the whole element.
+func wrappingActions(text string, actions []tmplAction) bool {
+	if len(actions) < 2 {
+		return false
+	}
+	first, last := actions[0], actions[len(actions)-1]
+	if strings.TrimSpace(text[:first.start]) != "" || strings.TrimSpace(text[last.end:]) != "" {
+		return false
+	}
+	if (first.keyword != "if" && first.keyword != "range" && first.keyword != "with") || last.keyword != "end" {
+		return false
+	}
+	depth := 1
+	for _, a := range actions[1 : len(actions)-1] {
+		switch a.keyword {
+		case "if", "range", "with", "block", "define":
+			depth++
+		case "end":
+			depth--
+			if depth == 0 {
+				return false
+			}
+		case "else":
+			if depth == 1 {
+				return false
+			}
+		case "break", "continue":
+			// Leaving the block would skip the end of the element
+			return false
+		}
+	}
+	return depth == 1
+}
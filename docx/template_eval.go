@@ -0,0 +1,490 @@
+package docx
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"godocx/internal"
+	"godocx/wml/ctypes"
+)
+
+// tmplValueFunc is the function appended to the pipeline of every action printing a value, so
+// that the output of the actions can be told from the text of the template.
+const tmplValueFunc = "__value"
+
+// tmplImage is the result of the image function, placed as a picture by tmplValueFunc.
+type tmplImage struct {
+	run *ctypes.Run
+}
+
+// templateRunner evaluates a template built from document elements.
+type templateRunner struct {
+	root *RootDoc
+	src  *tmplSource
+
+	stack  []*tmplOut    // Elements being output, the root first
+	images []*ctypes.Run // Pictures created by the image function
+	used   map[int]bool  // Elements already output once
+}
+
+// tmplOut is an element output by the evaluation, with its content.
+type tmplOut struct {
+	elem     int
+	pieces   []tmplPiece
+	children []*tmplOut
+
+	// Element filled with the content: the element of the template the first time it is
+	// output, a copy otherwise
+	para  *ctypes.Paragraph
+	table *ctypes.Table
+	row   *ctypes.Row
+	cell  *ctypes.Cell
+	child DocumentChild
+}
+
+// tmplPiece is a part of the output of a paragraph: text of the paragraph, a value or a picture.
+type tmplPiece struct {
+	literal  bool
+	from, to int    // Offsets of the text within the text of the paragraph, of the action for a value
+	text     string // Value printed
+	image    *ctypes.Run
+}
+
+// execute parses and evaluates the template source against data, and returns the elements
+// output.
+func (tr *templateRunner) execute(data any) (_ *tmplOut, err error) {
+	// The pictures created by the image function are removed again when the evaluation fails
+	if tr.root != nil {
+		saved := tr.root.importState()
+		defer func() {
+			if err != nil {
+				tr.root.removeImages(saved)
+			}
+		}()
+	}
+
+	tmpl, err := template.New("document").Funcs(template.FuncMap{
+		"image":       tr.image,
+		tmplValueFunc: tr.value,
+	}).Parse(tr.src.sb.String())
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			rewriteTmplNode(t.Tree, t.Tree.Root)
+		}
+	}
+
+	root := &tmplOut{elem: -1}
+	tr.stack = []*tmplOut{root}
+	if err := tmpl.Execute(tr, data); err != nil {
+		return nil, err
+	}
+	if len(tr.stack) != 1 {
+		return nil, errTmplElements
+	}
+	tr.used = map[int]bool{}
+	tr.instantiate(root)
+	return root, nil
+}
+
+var errTmplElements = errors.New("template: block actions must enclose whole paragraphs, tables, rows or cells, or text within a paragraph")
+
+// rewriteTmplNode replaces the text of the template by the position of the text in the source,
+// and pipes the value of the actions to tmplValueFunc.
+func rewriteTmplNode(tree *parse.Tree, node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			rewriteTmplNode(tree, child)
+		}
+	case *parse.TextNode:
+		n.Text = fmt.Appendf(nil, "\x00L%d,%d", n.Pos, int(n.Pos)+len(n.Text))
+	case *parse.ActionNode:
+		if len(n.Pipe.Decl) == 0 {
+			// The value function is given the position of the action, for its formatting
+			pos := strconv.Itoa(int(n.Pos))
+			n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+				NodeType: parse.NodeCommand,
+				Pos:      n.Pos,
+				Args: []parse.Node{
+					parse.NewIdentifier(tmplValueFunc).SetTree(tree).SetPos(n.Pos),
+					&parse.StringNode{NodeType: parse.NodeString, Pos: n.Pos, Quoted: strconv.Quote(pos), Text: pos},
+				},
+			})
+		}
+	case *parse.IfNode:
+		rewriteTmplNode(tree, n.List)
+		rewriteTmplNode(tree, n.ElseList)
+	case *parse.RangeNode:
+		rewriteTmplNode(tree, n.List)
+		rewriteTmplNode(tree, n.ElseList)
+	case *parse.WithNode:
+		rewriteTmplNode(tree, n.List)
+		rewriteTmplNode(tree, n.ElseList)
+	}
+}
+
+// image creates the picture held by the value, placed by tmplValueFunc.
+func (tr *templateRunner) image(val any) (tmplImage, error) {
+	run, err := tr.imageRun(val)
+	return tmplImage{run: run}, err
+}
+
+// value prints the value of the action at the position pos of the source, or refers to the
+// picture created by the image function.
+func (tr *templateRunner) value(pos string, v any) string {
+	img, ok := v.(tmplImage)
+	if !ok {
+		return "\x00v" + pos + "," + strings.ReplaceAll(printValue(v), "\x00", "")
+	}
+	if img.run == nil {
+		return "\x00v" + pos + ","
+	}
+	tr.images = append(tr.images, img.run)
+	return "\x00i" + pos + "," + strconv.Itoa(len(tr.images)-1)
+}
+
+// printValue returns the text printed by an action for the value.
+func printValue(v any) string {
+	rv := reflect.ValueOf(v)
+	if !rv.IsValid() || ((rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface) && rv.IsNil()) {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// Write receives the output of the evaluation, each call holding the position of a text of the
+// template or the value of an action.
+func (tr *templateRunner) Write(p []byte) (int, error) {
+	if len(p) < 2 || p[0] != 0 {
+		return 0, fmt.Errorf("template: unexpected output %q", p)
+	}
+	top := tr.stack[len(tr.stack)-1]
+	switch p[1] {
+	case 'L':
+		from, to, _ := strings.Cut(string(p[2:]), ",")
+		start, _ := strconv.Atoi(from)
+		end, _ := strconv.Atoi(to)
+		if err := tr.text(start, end); err != nil {
+			return 0, err
+		}
+	case 'v', 'i':
+		pos, val, _ := strings.Cut(string(p[2:]), ",")
+		if val == "" {
+			break
+		}
+		if top.elem < 0 || tr.src.elems[top.elem].para == nil {
+			return 0, errTmplElements
+		}
+		at, _ := strconv.Atoi(pos)
+		piece := tmplPiece{from: tr.offset(top.elem, at), text: val}
+		if p[1] == 'i' {
+			i, _ := strconv.Atoi(val)
+			piece.text, piece.image = "", tr.images[i]
+		}
+		top.pieces = append(top.pieces, piece)
+	}
+	return len(p), nil
+}
+
+// offset returns the offset within the text of the paragraph of the position pos of the
+// source, -1 if the paragraph does not hold it.
+func (tr *templateRunner) offset(elem, pos int) int {
+	spans := tr.src.spans
+	i := sort.Search(len(spans), func(i int) bool { return spans[i].end > pos })
+	if i == len(spans) || spans[i].kind != tmplSpanText || spans[i].elem != elem {
+		return -1
+	}
+	return spans[i].offset + pos - spans[i].start
+}
+
+// text outputs the template source between start and end: the marks start and end elements,
+// the text of the paragraphs goes to their content.
+func (tr *templateRunner) text(start, end int) error {
+	spans := tr.src.spans
+	for i := sort.Search(len(spans), func(i int) bool { return spans[i].end > start }); i < len(spans) && spans[i].start < end; i++ {
+		span := spans[i]
+		top := tr.stack[len(tr.stack)-1]
+		switch span.kind {
+		case tmplSpanOpen:
+			out := &tmplOut{elem: span.elem}
+			top.children = append(top.children, out)
+			tr.stack = append(tr.stack, out)
+		case tmplSpanClose:
+			if len(tr.stack) == 1 {
+				return errTmplElements
+			}
+			tr.stack = tr.stack[:len(tr.stack)-1]
+		case tmplSpanText:
+			if top.elem != span.elem {
+				return errTmplElements
+			}
+			top.pieces = append(top.pieces, tmplPiece{
+				literal: true,
+				from:    span.offset + max(start, span.start) - span.start,
+				to:      span.offset + min(end, span.end) - span.start,
+			})
+		}
+	}
+	return nil
+}
+
+// removeImages removes the images added to the document since the state was saved.
+func (rd *RootDoc) removeImages(saved importState) {
+	ci := &contentImporter{dst: rd}
+	for _, rel := range rd.Document.DocRels.Relationships[saved.rels:] {
+		ci.stored = append(ci.stored, rd.Document.partPath(rel.Target))
+	}
+	ci.rollback(saved)
+}
+
+// instantiate assigns the elements to fill to the output: the elements of the template the
+// first time they are output, copies made before any is filled otherwise. The pictures and
+// bookmarks of the copied paragraphs get new IDs.
+func (tr *templateRunner) instantiate(out *tmplOut) {
+	var copies []DocumentChild
+	tr.assign(out, &copies)
+	tr.root.renumberCopy(copies)
+}
+
+func (tr *templateRunner) assign(out *tmplOut, copies *[]DocumentChild) {
+	if out.elem >= 0 {
+		elem := tr.src.elems[out.elem]
+		first := !tr.used[out.elem]
+		tr.used[out.elem] = true
+		if first {
+			out.child = elem.child
+		}
+		switch {
+		case elem.para != nil:
+			out.para = elem.para
+			if !first {
+				out.para = internal.DeepCopy(elem.para)
+				*copies = append(*copies, DocumentChild{Para: &Paragraph{ref: out.para}})
+			}
+		case elem.table != nil:
+			out.table = elem.table
+			if !first {
+				t := *elem.table
+				t.RowContents = nil
+				out.table = internal.DeepCopy(&t)
+			}
+		case elem.row != nil:
+			out.row = elem.row
+			if !first {
+				r := *elem.row
+				r.Contents = nil
+				out.row = internal.DeepCopy(&r)
+			}
+		case elem.cell != nil:
+			out.cell = elem.cell
+			if !first {
+				c := *elem.cell
+				c.Contents = nil
+				out.cell = internal.DeepCopy(&c)
+			}
+		}
+	}
+	for _, child := range out.children {
+		tr.assign(child, copies)
+	}
+}
+
+// bodyChildren fills the elements output and returns them as body children.
+func (tr *templateRunner) bodyChildren(root *tmplOut) []DocumentChild {
+	var children []DocumentChild
+	for _, out := range root.children {
+		child := out.child
+		switch {
+		case out.para != nil:
+			tr.fillPara(out)
+			if child.Para == nil {
+				child = DocumentChild{Para: &Paragraph{root: tr.root, ct: *out.para}}
+			}
+		case out.table != nil:
+			out.table.RowContents = tr.rows(out)
+			if child.Table == nil {
+				child = DocumentChild{Table: &Table{root: tr.root, ct: *out.table}}
+			}
+		default:
+			continue
+		}
+		children = append(children, child)
+	}
+	return children
+}
+
+// blocks fills the paragraphs and tables output within a cell.
+func (tr *templateRunner) blocks(cell *tmplOut) []ctypes.TCBlockContent {
+	var blocks []ctypes.TCBlockContent
+	for _, out := range cell.children {
+		switch {
+		case out.para != nil:
+			tr.fillPara(out)
+			blocks = append(blocks, ctypes.TCBlockContent{Paragraph: out.para})
+		case out.table != nil:
+			out.table.RowContents = tr.rows(out)
+			blocks = append(blocks, ctypes.TCBlockContent{Table: out.table})
+		}
+	}
+	if len(blocks) == 0 || blocks[len(blocks)-1].Paragraph == nil {
+		// A cell ends with a paragraph
+		blocks = append(blocks, ctypes.TCBlockContent{Paragraph: &ctypes.Paragraph{}})
+	}
+	return blocks
+}
+
+// rows fills the rows output within a table.
+func (tr *templateRunner) rows(table *tmplOut) []ctypes.RowContent {
+	var rows []ctypes.RowContent
+	for _, out := range table.children {
+		if out.row == nil {
+			continue
+		}
+		var cells []ctypes.TRCellContent
+		for _, c := range out.children {
+			if c.cell == nil {
+				continue
+			}
+			c.cell.Contents = tr.blocks(c)
+			cells = append(cells, ctypes.TRCellContent{Cell: c.cell})
+		}
+		out.row.Contents = cells
+		rows = append(rows, ctypes.RowContent{Row: out.row})
+	}
+	return rows
+}
+
+// tmplEdit replaces the text of a paragraph between start and end with the parts.
+type tmplEdit struct {
+	start, end int
+	parts      []tmplPiece
+}
+
+// fillPara replaces the text of the paragraph with its output. The text of the template kept
+// in order keeps its runs, and the values and the text repeated take the formatting of the
+// action or the text they come from.
+func (tr *templateRunner) fillPara(out *tmplOut) {
+	text := tr.src.elems[out.elem].text
+	var (
+		edits  []tmplEdit
+		parts  []tmplPiece
+		cursor int
+	)
+	for _, piece := range out.pieces {
+		switch {
+		case !piece.literal:
+			parts = append(parts, piece)
+		case piece.from < cursor:
+			parts = append(parts, tmplPiece{from: piece.from, text: text[piece.from:piece.to]})
+		default:
+			edits = append(edits, tmplEdit{start: cursor, end: piece.from, parts: parts})
+			parts = nil
+			cursor = piece.to
+		}
+	}
+	edits = append(edits, tmplEdit{start: cursor, end: len(text), parts: parts})
+
+	// The formatting of the text of the template, before any edit
+	segs, _ := paraSegments(out.para)
+	formatAt := func(pos int) *ctypes.Run {
+		for _, seg := range segs {
+			if pos >= seg.start && pos < seg.end {
+				return seg.run
+			}
+		}
+		return nil
+	}
+
+	// Work backwards so earlier offsets stay valid
+	for i := len(edits) - 1; i >= 0; i-- {
+		e := edits[i]
+		if !inPlace(e) {
+			// Repeated content: new runs formatted as the text they come from
+			replaceParaText(out.para, e.start, e.end, "")
+			runs := make([]*ctypes.Run, 0, len(e.parts))
+			for _, part := range e.parts {
+				if part.image != nil {
+					runs = append(runs, part.image)
+					continue
+				}
+				if part.text == "" {
+					continue
+				}
+				run := &ctypes.Run{Children: []ctypes.RunChild{{Text: ctypes.TextFromString(part.text)}}}
+				if from := formatAt(part.from); from != nil && from.Property != nil {
+					run.Property = internal.DeepCopy(from.Property)
+				}
+				runs = append(runs, run)
+			}
+			if len(runs) > 0 {
+				insertParaRuns(out.para, e.start, runs...)
+			}
+			continue
+		}
+
+		// Each value replaces the text from its action to the next one, taking the formatting
+		// of the run in which its action starts
+		ends := e.end
+		for j := len(e.parts) - 1; j >= 0; j-- {
+			part := e.parts[j]
+			replaceParaText(out.para, part.from, ends, part.text)
+			if part.image != nil {
+				insertParaRuns(out.para, part.from, part.image)
+			}
+			ends = part.from
+		}
+		if e.start < ends {
+			replaceParaText(out.para, e.start, ends, "")
+		}
+	}
+}
+
+// inPlace reports whether the parts of the edit are values of actions written in order within
+// the text it replaces, which then keeps its runs.
+func inPlace(e tmplEdit) bool {
+	last := e.start - 1
+	for _, part := range e.parts {
+		if part.from <= last || part.from >= e.end {
+			return false
+		}
+		last = part.from
+	}
+	return true
+}
+
+// imageRun creates a run with the picture held by the value.
+func (tr *templateRunner) imageRun(val any) (*ctypes.Run, error) {
+	var img TemplateImage
+	switch v := val.(type) {
+	case nil:
+		return nil, nil
+	case []byte:
+		img.Data = v
+	case TemplateImage:
+		img = v
+	case *TemplateImage:
+		if v == nil {
+			return nil, nil
+		}
+		img = *v
+	default:
+		return nil, fmt.Errorf("cannot use %T as an image", val)
+	}
+	if len(img.Data) == 0 {
+		return nil, nil
+	}
+	run, _, err := tr.root.newImageRun(img.Data, img.Width, img.Height)
+	return run, err
+}
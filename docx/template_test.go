@@ -0,0 +1,318 @@
+package docx
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"godocx/common/units"
+	"godocx/wml/ctypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type tmplItem struct {
+	Name  string
+	Price float64
+}
+
+type tmplInvoice struct {
+	Customer string
+	Discount float64
+	Items    []tmplItem
+	Notes    map[string]string
+}
+
+func bodyTexts(rd *RootDoc) []string {
+	var texts []string
+	for _, child := range rd.Document.Body.Children {
+		if child.Para != nil {
+			_, text := paraSegments(&child.Para.ct)
+			texts = append(texts, text)
+		}
+	}
+	return texts
+}
+
+// rowText joins the text of the paragraphs of the cells of the row.
+func rowText(r *ctypes.Row) string {
+	var sb strings.Builder
+	for _, c := range r.Contents {
+		for _, b := range c.Cell.Contents {
+			if b.Paragraph != nil {
+				_, text := paraSegments(b.Paragraph)
+				sb.WriteString(text)
+			}
+		}
+	}
+	return sb.String()
+}
+
+func TestExecuteTemplate_Paragraphs(t *testing.T) {
+	data := tmplInvoice{
+		Customer: "Acme",
+		Items:    []tmplItem{{"Bolt", 1.5}, {"Nut", 0.25}},
+		Notes:    map[string]string{"terms": "30 days"},
+	}
+
+	rd := setupRootDoc(t)
+	p := rd.AddParagraph("Dear ")
+	p.AddText("{{.Cust")
+	p.AddText("omer}},")
+	rd.AddParagraph("{{range $i, $item := .Items}}")
+	rd.AddParagraph(`{{$i}}: {{.Name}} {{printf "%.2f" .Price}}`)
+	rd.AddParagraph("{{end}}")
+	rd.AddParagraph("{{if .Discount}}Discount {{.Discount}}{{end}}")
+	rd.AddParagraph("{{if eq .Customer \"Acme\"}}")
+	rd.AddParagraph("Hello Acme")
+	rd.AddParagraph("{{else}}")
+	rd.AddParagraph("Hello stranger")
+	rd.AddParagraph("{{end}}")
+	rd.AddParagraph("Note: {{.Notes.terms}}")
+
+	require.NoError(t, rd.ExecuteTemplate(data))
+	assert.Equal(t, []string{
+		"Dear Acme,",
+		"0: Bolt 1.50",
+		"1: Nut 0.25",
+		"Hello Acme",
+		"Note: 30 days",
+	}, bodyTexts(rd))
+
+	// The replacement keeps the formatting runs of the paragraph
+	assert.Len(t, rd.Document.Body.Children[0].Para.ct.Children, 3)
+	// The paragraph output once is the paragraph of the template
+	assert.Same(t, p, rd.Document.Body.Children[0].Para)
+}
+
+func TestExecuteTemplate_Syntax(t *testing.T) {
+	data := tmplInvoice{
+		Customer: "Acme",
+		Items:    []tmplItem{{"Bolt", 1.5}, {"Nut", 0.25}},
+	}
+
+	rd := setupRootDoc(t)
+	rd.AddParagraph(`Total {{len .Items | printf "%03d"}} {{if gt (len .Items) 1}}items{{else}}item{{end}}`)
+	rd.AddParagraph(`Items: {{range $i, $item := .Items}}{{if $i}}, {{end}}{{.Name}}{{end}}.`)
+	rd.AddParagraph("{{range .Items -}}")
+	rd.AddParagraph("  {{- .Name}} {{.Price -}}  ")
+	rd.AddParagraph("{{- end}}")
+	rd.AddParagraph(`{{with .Customer}}{{. | printf "%q"}}{{end}}`)
+	rd.AddParagraph(`{{/* a comment */}}Customer {{.Customer}}`)
+
+	require.NoError(t, rd.ExecuteTemplate(data))
+	assert.Equal(t, []string{
+		"Total 002 items",
+		"Items: Bolt, Nut.",
+		"Bolt 1.5",
+		"Nut 0.25",
+		`"Acme"`,
+		"Customer Acme",
+	}, bodyTexts(rd))
+}
+
+func TestExecuteTemplate_InlineFormatting(t *testing.T) {
+	rd := setupRootDoc(t)
+	p := rd.AddParagraph("Items: ")
+	p.AddText("{{range .Items}}")
+	p.AddText("{{.Name}}").Bold(true)
+	p.AddText(" {{end}}done")
+
+	data := tmplInvoice{Items: []tmplItem{{"Bolt", 1.5}, {"Nut", 0.25}}}
+	require.NoError(t, rd.ExecuteTemplate(data))
+	assert.Equal(t, []string{"Items: Bolt Nut done"}, bodyTexts(rd))
+
+	// The repeated values take the formatting of the action they replace
+	var bold []string
+	for _, child := range p.ct.Children {
+		if run := child.Run; run != nil && run.Property != nil && run.Property.Bold != nil {
+			for _, rc := range run.Children {
+				if rc.Text != nil {
+					bold = append(bold, rc.Text.Text)
+				}
+			}
+		}
+	}
+	assert.Equal(t, []string{"Bolt", "Nut"}, bold)
+}
+
+func TestExecuteTemplate_Image(t *testing.T) {
+	var img bytes.Buffer
+	require.NoError(t, png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 2, 2))))
+	data := map[string]any{
+		"Logo":   img.Bytes(),
+		"Banner": TemplateImage{Data: img.Bytes()},
+		"None":   nil,
+	}
+
+	rd := setupRootDoc(t)
+	rd.Document.Body = NewBody(rd)
+	rd.AddParagraph("Logo {{image .Logo}} and {{image .Banner}}{{image .None}}!")
+	require.NoError(t, rd.ExecuteTemplate(data))
+
+	p := rd.Document.Body.Children[0].Para
+	var drawings int
+	var text strings.Builder
+	for _, child := range p.ct.Children {
+		for _, rc := range child.Run.Children {
+			if rc.Drawing != nil {
+				drawings++
+				text.WriteString("#")
+			}
+			if rc.Text != nil {
+				text.WriteString(rc.Text.Text)
+			}
+		}
+	}
+	assert.Equal(t, 2, drawings)
+	assert.Equal(t, "Logo # and #!", text.String())
+	assert.EqualValues(t, 3, rd.ImageCount)
+
+	rd = setupRootDoc(t)
+	rd.Document.Body = NewBody(rd)
+	rd.AddParagraph("{{image .Name}}")
+	assert.ErrorContains(t, rd.ExecuteTemplate(tmplItem{Name: "logo"}), "cannot use string as an image")
+
+	// The pictures of a failed evaluation are removed
+	rd = setupRootDoc(t)
+	rd.Document.Body = NewBody(rd)
+	count, rels, overrides := rd.ImageCount, len(rd.Document.DocRels.Relationships), len(rd.ContentType.Override)
+	rd.AddParagraph("{{image .Logo}} {{.Missing.Field}}")
+	assert.Error(t, rd.ExecuteTemplate(map[string]any{"Logo": img.Bytes(), "Missing": 1}))
+	assert.Equal(t, count, rd.ImageCount)
+	assert.Len(t, rd.Document.DocRels.Relationships, rels)
+	assert.Len(t, rd.ContentType.Override, overrides)
+	_, ok := rd.FileMap.Load(fmt.Sprintf("word/media/image%d.png", count+1))
+	assert.False(t, ok)
+}
+
+func TestExecuteTemplate_RepeatedIDs(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.Document.Body = NewBody(rd)
+	rd.AddParagraph("{{range .Items}}")
+	pic, err := rd.AddImage([]byte("GIF89a"), units.Emu(9525), units.Emu(9525))
+	require.NoError(t, err)
+	pic.Para.GetCT().Children = append(pic.Para.GetCT().Children,
+		ctypes.ParagraphChild{BookmarkStart: &ctypes.BookmarkStart{ID: 1, Name: "logo"}},
+		ctypes.ParagraphChild{BookmarkEnd: &ctypes.BookmarkEnd{ID: 1}})
+	rd.AddParagraph("{{end}}")
+
+	require.NoError(t, rd.ExecuteTemplate(map[string]any{"Items": []int{1, 2, 3}}))
+	children := rd.Document.Body.Children
+	require.Len(t, children, 3)
+	docPrIDs := map[uint64]bool{}
+	bookmarks := map[string]bool{}
+	for _, child := range children {
+		for run := range child.Para.Runs() {
+			for _, rc := range run.ct.Children {
+				if rc.Drawing != nil {
+					docPrIDs[rc.Drawing.Inline[0].DocProp.ID] = true
+				}
+			}
+		}
+		for _, pc := range child.Para.GetCT().Children {
+			if pc.BookmarkStart != nil {
+				bookmarks[fmt.Sprintf("%d %s", pc.BookmarkStart.ID, pc.BookmarkStart.Name)] = true
+			}
+		}
+	}
+	assert.Len(t, docPrIDs, 3)
+	assert.Equal(t, map[string]bool{"1 logo": true, "2 logo_2": true, "3 logo_3": true}, bookmarks)
+}
+
+func TestExecuteTemplate_TableRows(t *testing.T) {
+	rd := setupRootDoc(t)
+	tbl := rd.AddTable()
+	header := tbl.AddRow()
+	header.AddCell().AddParagraph("Item")
+	header.AddCell().AddParagraph("Price")
+	row := tbl.AddRow()
+	row.AddCell().AddParagraph("{{range .Items}}{{.Name}}")
+	row.AddCell().AddParagraph("{{.Price}}{{end}}")
+	tbl.AddRow().AddCell().AddParagraph("{{range .Items}}")
+	tbl.AddRow().AddCell().AddParagraph("Again {{.Name}}")
+	tbl.AddRow().AddCell().AddParagraph("{{end}}")
+
+	data := tmplInvoice{Items: []tmplItem{{"Bolt", 1.5}, {"Nut", 0.25}}}
+	require.NoError(t, rd.ExecuteTemplate(data))
+
+	rows := tbl.ct.RowContents
+	require.Len(t, rows, 5)
+	assert.Equal(t, "ItemPrice", rowText(rows[0].Row))
+	assert.Equal(t, "Bolt1.5", rowText(rows[1].Row))
+	assert.Equal(t, "Nut0.25", rowText(rows[2].Row))
+	assert.Equal(t, "Again Bolt", rowText(rows[3].Row))
+	assert.Equal(t, "Again Nut", rowText(rows[4].Row))
+	// The row output once is the row of the template
+	assert.Same(t, header.GetCT(), rows[0].Row)
+}
+
+func TestExecuteTemplate_NestedTable(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.AddParagraph("{{range .Items}}")
+	rd.AddParagraph("{{.Name}}")
+	tbl := rd.AddTable()
+	row := tbl.AddRow()
+	cell := row.AddCell()
+	cell.AddParagraph("{{if gt .Price 1.0}}")
+	cell.AddParagraph("Expensive {{.Price}}")
+	cell.AddParagraph("{{end}}")
+	row.AddCell().AddParagraph(" for {{.Name}}")
+	rd.AddParagraph("{{end}}")
+
+	data := tmplInvoice{Items: []tmplItem{{"Bolt", 1.5}, {"Nut", 0.25}}}
+	require.NoError(t, rd.ExecuteTemplate(data))
+
+	children := rd.Document.Body.Children
+	require.Len(t, children, 4)
+	assert.Equal(t, "Bolt", bodyTexts(rd)[0])
+	assert.Equal(t, "Expensive 1.5 for Bolt", rowText(children[1].Table.ct.RowContents[0].Row))
+	// The cell left without content keeps an empty paragraph
+	assert.Equal(t, " for Nut", rowText(children[3].Table.ct.RowContents[0].Row))
+	assert.Len(t, children[3].Table.ct.RowContents[0].Row.Contents[0].Cell.Contents, 1)
+}
+
+func TestExecuteTemplate_Errors(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.AddParagraph("{{range .Items}}")
+	assert.ErrorContains(t, rd.ExecuteTemplate(tmplInvoice{}), "unexpected EOF")
+
+	rd = setupRootDoc(t)
+	rd.AddParagraph("{{end}}")
+	assert.ErrorContains(t, rd.ExecuteTemplate(tmplInvoice{}), "unexpected {{end}}")
+
+	rd = setupRootDoc(t)
+	rd.AddParagraph("{{if lenient .Items}}x{{end}}")
+	assert.ErrorContains(t, rd.ExecuteTemplate(tmplInvoice{}), "not defined")
+
+	// A block must not end an element it did not start
+	rd = setupRootDoc(t)
+	rd.AddParagraph("Start {{if .Customer}}")
+	rd.AddTable().AddRow().AddCell().AddParagraph("x{{end}}")
+	assert.ErrorContains(t, rd.ExecuteTemplate(tmplInvoice{}), "block actions must enclose")
+
+	rd = setupRootDoc(t)
+	rd.AddParagraph("{{.Missing}}")
+	assert.ErrorContains(t, rd.ExecuteTemplate(tmplInvoice{}), "Missing")
+}
+
+func TestReplaceParaText(t *testing.T) {
+	p := &Paragraph{}
+	p.AddText("Hello ")
+	p.AddText("wor")
+	p.AddText("ld!")
+
+	replaceParaText(&p.ct, 6, 11, "there")
+	_, text := paraSegments(&p.ct)
+	assert.Equal(t, "Hello there!", text)
+	assert.Equal(t, "there", p.ct.Children[1].Run.Children[0].Text.Text)
+	assert.Equal(t, "!", p.ct.Children[2].Run.Children[0].Text.Text)
+
+	insertParaRuns(&p.ct, 2, p.ct.Children[0].Run)
+	_, text = paraSegments(&p.ct)
+	assert.Equal(t, "HeHello llo there!", text)
+}
@@ -0,0 +1,175 @@
+package docx
+
+import (
+	"strconv"
+	"strings"
+
+	"godocx/wml/ctypes"
+)
+
+// TextOptions configures the plain text extraction of RootDoc.Text.
+type TextOptions struct {
+	// ListLabels prefixes list paragraphs with their label (e.g. "1.", "a)" or "•") followed by a tab.
+	ListLabels bool
+
+	// HeadersFooters appends the text of the headers and footers after the body text.
+	HeadersFooters bool
+}
+
+// Text returns the plain text of the document body.
+//
+// Paragraphs are written on their own line in document order, tabs and breaks within
+// paragraphs are rendered as "\t" and "\n". Tables are written as one line per row with the
+// text of the cells separated by tabs.
+//
+// Example:
+//
+//	text, err := document.Text(&docx.TextOptions{ListLabels: true})
+func (rd *RootDoc) Text(opts *TextOptions) (string, error) {
+	if opts == nil {
+		opts = &TextOptions{}
+	}
+
+	tw := textWriter{}
+	if opts.ListLabels {
+		tw.labels = newListLabeler(rd)
+	}
+
+	err := rd.eachPart(!opts.HeadersFooters, func(part string, children []DocumentChild) {
+		tw.writeChildren(children)
+	})
+	return tw.sb.String(), err
+}
+
+// Text returns the plain text of the paragraph, including the text of its hyperlinks.
+// Tabs and breaks are rendered as "\t" and "\n".
+func (p *Paragraph) Text() string {
+	return paragraphText(p.GetCT())
+}
+
+// Text returns the plain text of the run. Tabs and breaks are rendered as "\t" and "\n".
+func (r *Run) Text() string {
+	return runText(r.ct)
+}
+
+// Text returns the plain text of the cell, one line per paragraph.
+func (c *Cell) Text() string {
+	return cellText(c.GetCT())
+}
+
+// textWriter accumulates the plain text of block level content.
+type textWriter struct {
+	sb     strings.Builder
+	labels *listLabeler
+}
+
+func (tw *textWriter) writeChildren(children []DocumentChild) {
+	for _, child := range children {
+		if child.Para != nil {
+			tw.writePara(child.Para.GetCT())
+		}
+		if child.Table != nil {
+			tw.writeTable(child.Table.GetCT())
+		}
+	}
+}
+
+func (tw *textWriter) writePara(p *ctypes.Paragraph) {
+	if tw.labels != nil {
+		if label := tw.labels.label(p); label != "" {
+			tw.sb.WriteString(label)
+			tw.sb.WriteString("\t")
+		}
+	}
+	tw.sb.WriteString(paragraphText(p))
+	tw.sb.WriteString("\n")
+}
+
+// writeTable writes one line per row, the cells being separated by tabs.
+func (tw *textWriter) writeTable(t *ctypes.Table) {
+	for _, rc := range t.RowContents {
+		if rc.Row == nil {
+			continue
+		}
+		for i, cc := range rc.Row.Contents {
+			if cc.Cell == nil {
+				continue
+			}
+			if i > 0 {
+				tw.sb.WriteString("\t")
+			}
+			text := cellText(cc.Cell)
+			text = strings.NewReplacer("\n", " ", "\t", " ").Replace(strings.TrimRight(text, "\n"))
+			tw.sb.WriteString(text)
+		}
+		tw.sb.WriteString("\n")
+	}
+}
+
+// paragraphText renders the runs and hyperlinks of the paragraph.
+func paragraphText(p *ctypes.Paragraph) string {
+	var sb strings.Builder
+	for _, child := range p.Children {
+		if child.Run != nil {
+			sb.WriteString(runText(child.Run))
+		}
+		if child.Link != nil {
+			for _, r := range child.Link.Runs() {
+				sb.WriteString(runText(r))
+			}
+		}
+		if child.Ins != nil {
+			// Tracked insertions are part of the text, tracked deletions hold deleted text only
+			for _, r := range child.Ins.Runs {
+				sb.WriteString(runText(r))
+			}
+		}
+	}
+	return sb.String()
+}
+
+// cellText renders the paragraphs and nested tables of the cell, one line per paragraph.
+func cellText(c *ctypes.Cell) string {
+	var lines []string
+	for _, block := range c.Contents {
+		if block.Paragraph != nil {
+			lines = append(lines, paragraphText(block.Paragraph))
+		}
+		if block.Table != nil {
+			tw := textWriter{}
+			tw.writeTable(block.Table)
+			lines = append(lines, strings.TrimRight(tw.sb.String(), "\n"))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// runText renders the content of a run: text, tabs, breaks, symbols and hyphens.
+// Deleted text and field codes are not part of the rendered text.
+func runText(r *ctypes.Run) string {
+	var sb strings.Builder
+	for _, child := range r.Children {
+		switch {
+		case child.Text != nil:
+			sb.WriteString(child.Text.Text)
+		case child.Tab != nil, child.PTab != nil:
+			sb.WriteString("\t")
+		case child.Break != nil, child.CarrRtn != nil:
+			sb.WriteString("\n")
+		case child.NoBreakHyphen != nil:
+			sb.WriteString("\u2011")
+		case child.SoftHyphen != nil:
+			sb.WriteString("\u00ad")
+		case child.Sym != nil && child.Sym.Char != nil:
+			if code, err := strconv.ParseUint(*child.Sym.Char, 16, 32); err == nil {
+				var font string
+				if child.Sym.Font != nil {
+					font = *child.Sym.Font
+				}
+				char, _ := symbolFontChar(font, rune(code))
+				sb.WriteRune(char)
+			}
+		}
+	}
+	return sb.String()
+}
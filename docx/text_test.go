@@ -0,0 +1,71 @@
+package docx
+
+import (
+	"testing"
+
+	"godocx/wml/ctypes"
+	"godocx/wml/stypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunText(t *testing.T) {
+	p := &Paragraph{}
+	run := p.AddText("a")
+	run.ct.Children = append(run.ct.Children,
+		ctypes.RunChild{Tab: &ctypes.Empty{}},
+		ctypes.RunChild{Text: ctypes.TextFromString("b")},
+		ctypes.RunChild{Sym: ctypes.NewSym("Symbol", "F0B7")},
+		ctypes.RunChild{Sym: ctypes.NewSym("Wingdings", "F0FC")},
+		ctypes.RunChild{DelText: ctypes.TextFromString("deleted")},
+	)
+	run.AddBreak(stypes.BreakTypeTextWrapping)
+
+	assert.Equal(t, "a\tb•✔\n", run.Text())
+	assert.Equal(t, "a\tb•✔\n", p.Text())
+}
+
+func TestDocumentText(t *testing.T) {
+	rd := setupRootDoc(t)
+	rd.Numbering = NewNumberingManager(rd)
+	rd.AddParagraph("Title")
+
+	numID := rd.NewListInstance(1)
+	rd.AddParagraph("First").Numbering(numID, 0)
+	rd.AddParagraph("Nested").Numbering(numID, 1)
+	rd.AddParagraph("Second").Numbering(numID, 0)
+
+	bullets := rd.NewListInstance(2)
+	rd.AddParagraph("Bullet").Numbering(bullets, 0)
+
+	tbl := rd.AddTable()
+	row := tbl.AddRow()
+	row.AddCell().AddParagraph("A1")
+	cell := row.AddCell()
+	cell.AddParagraph("B1")
+	cell.AddParagraph("more")
+	row = tbl.AddRow()
+	row.AddCell().AddParagraph("A2")
+	row.AddCell().AddParagraph("B2")
+
+	assert.Equal(t, "B1\nmore", cell.Text())
+
+	text, err := rd.Text(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Title\nFirst\nNested\nSecond\nBullet\nA1\tB1 more\nA2\tB2\n", text)
+
+	text, err = rd.Text(&TextOptions{ListLabels: true})
+	require.NoError(t, err)
+	assert.Equal(t, "Title\n1.\tFirst\na.\tNested\n2.\tSecond\n•\tBullet\nA1\tB1 more\nA2\tB2\n", text)
+}
+
+func TestFormatListNumber(t *testing.T) {
+	assert.Equal(t, "xiv", formatListNumber(14, "lowerRoman"))
+	assert.Equal(t, "MCMXC", formatListNumber(1990, "upperRoman"))
+	assert.Equal(t, "AA", formatListNumber(27, "upperLetter"))
+	assert.Equal(t, "c", formatListNumber(3, "lowerLetter"))
+	assert.Equal(t, "07", formatListNumber(7, "decimalZero"))
+	assert.Equal(t, "22nd", formatListNumber(22, "ordinal"))
+	assert.Equal(t, "5", formatListNumber(5, "decimal"))
+}
@@ -0,0 +1,243 @@
+package docx
+
+import (
+	"iter"
+
+	"godocx/wml/ctypes"
+)
+
+// Element is a node of the document tree visited by RootDoc.Walk: *Body, *Table, *Row, *Cell,
+// *Paragraph, *Hyperlink or *Run.
+type Element interface {
+	element()
+}
+
+func (*Body) element()      {}
+func (*Table) element()     {}
+func (*Row) element()       {}
+func (*Cell) element()      {}
+func (*Paragraph) element() {}
+func (*Hyperlink) element() {}
+func (*Run) element()       {}
+
+// WalkAction tells RootDoc.Walk how to continue after visiting an element.
+type WalkAction int
+
+const (
+	WalkContinue WalkAction = iota // Visit the children of the element, then its next sibling
+	WalkSkip                       // Skip the children of the element
+	WalkStop                       // Stop the walk
+)
+
+// Visitor is called by RootDoc.Walk for each element of the document tree. parents holds the
+// enclosing elements from the body down to the parent of the element; the slice is reused
+// between calls and must not be retained.
+type Visitor func(el Element, parents []Element) WalkAction
+
+// Walk visits the elements of the document body depth first in document order: the body, its
+// paragraphs and tables, the rows and cells of the tables, the paragraphs and nested tables of
+// the cells, the runs and hyperlinks of the paragraphs and the runs of the hyperlinks. The runs
+// of tracked insertions are visited as runs of the paragraph or hyperlink holding them.
+//
+// Elements may be modified in place while walking, but elements must not be added or removed.
+// The paragraphs and tables of the body are the handles held by Body.Children, the other
+// elements are new handles sharing the underlying complex types of the document.
+//
+// Example:
+//
+//	document.Walk(func(el docx.Element, parents []docx.Element) docx.WalkAction {
+//		if p, ok := el.(*docx.Paragraph); ok {
+//			fmt.Println(len(parents), p.Text())
+//			return docx.WalkSkip
+//		}
+//		return docx.WalkContinue
+//	})
+func (rd *RootDoc) Walk(visit Visitor) {
+	w := walker{root: rd, visit: visit}
+	body := rd.Document.Body
+	w.enter(body, func() {
+		for _, child := range body.Children {
+			w.blockChild(child.Para, child.Table)
+		}
+	})
+}
+
+// walker holds the state of a walk.
+type walker struct {
+	root    *RootDoc
+	visit   Visitor
+	parents []Element
+	stopped bool
+}
+
+// enter visits an element then, unless told otherwise, its children.
+func (w *walker) enter(el Element, children func()) {
+	if w.stopped {
+		return
+	}
+	switch w.visit(el, w.parents) {
+	case WalkStop:
+		w.stopped = true
+		return
+	case WalkSkip:
+		return
+	}
+	if children == nil {
+		return
+	}
+	w.parents = append(w.parents, el)
+	children()
+	w.parents = w.parents[:len(w.parents)-1]
+}
+
+func (w *walker) blockChild(p *Paragraph, t *Table) {
+	if p != nil {
+		w.paragraph(p)
+	}
+	if t != nil {
+		w.table(t)
+	}
+}
+
+func (w *walker) table(t *Table) {
+	w.enter(t, func() {
+		for row := range t.Rows() {
+			w.enter(row, func() {
+				for cell := range row.Cells() {
+					w.enter(cell, func() {
+						for _, block := range cell.GetCT().Contents {
+							w.blockChild(w.wrapPara(block.Paragraph), w.wrapTable(block.Table))
+						}
+					})
+				}
+			})
+		}
+	})
+}
+
+func (w *walker) paragraph(p *Paragraph) {
+	w.enter(p, func() {
+		for _, child := range p.GetCT().Children {
+			if child.Run != nil {
+				w.enter(newRun(w.root, p.GetCT(), child.Run), nil)
+			}
+			if child.Ins != nil {
+				for _, run := range child.Ins.Runs {
+					w.enter(newRun(w.root, p.GetCT(), run), nil)
+				}
+			}
+			if child.Link != nil {
+				link := newHyperlink(w.root, child.Link)
+				w.enter(link, func() {
+					for _, run := range child.Link.Runs() {
+						w.enter(newRun(w.root, p.GetCT(), run), nil)
+					}
+				})
+			}
+		}
+	})
+}
+
+func (w *walker) wrapPara(ct *ctypes.Paragraph) *Paragraph {
+	if ct == nil {
+		return nil
+	}
+	return &Paragraph{root: w.root, ref: ct}
+}
+
+func (w *walker) wrapTable(ct *ctypes.Table) *Table {
+	if ct == nil {
+		return nil
+	}
+	return &Table{root: w.root, ref: ct}
+}
+
+// Paragraphs returns an iterator over the paragraphs of the document body in document order,
+// including the paragraphs of table cells.
+//
+// Example:
+//
+//	for p := range document.Paragraphs() {
+//		fmt.Println(p.Text())
+//	}
+func (rd *RootDoc) Paragraphs() iter.Seq[*Paragraph] {
+	return func(yield func(*Paragraph) bool) {
+		rd.Walk(func(el Element, _ []Element) WalkAction {
+			p, ok := el.(*Paragraph)
+			if !ok {
+				return WalkContinue
+			}
+			if !yield(p) {
+				return WalkStop
+			}
+			return WalkSkip
+		})
+	}
+}
+
+// Tables returns an iterator over the tables of the document body in document order, nested
+// tables following the table holding them.
+func (rd *RootDoc) Tables() iter.Seq[*Table] {
+	return func(yield func(*Table) bool) {
+		rd.Walk(func(el Element, _ []Element) WalkAction {
+			switch el := el.(type) {
+			case *Table:
+				if !yield(el) {
+					return WalkStop
+				}
+			case *Paragraph:
+				return WalkSkip
+			}
+			return WalkContinue
+		})
+	}
+}
+
+// Rows returns an iterator over the rows of the table.
+func (t *Table) Rows() iter.Seq[*Row] {
+	return func(yield func(*Row) bool) {
+		for _, rc := range t.GetCT().RowContents {
+			if rc.Row == nil {
+				continue
+			}
+			if !yield(&Row{root: t.root, ref: rc.Row}) {
+				return
+			}
+		}
+	}
+}
+
+// Cells returns an iterator over the cells of the row.
+func (r *Row) Cells() iter.Seq[*Cell] {
+	return func(yield func(*Cell) bool) {
+		for _, cc := range r.GetCT().Contents {
+			if cc.Cell == nil {
+				continue
+			}
+			if !yield(&Cell{root: r.root, ref: cc.Cell}) {
+				return
+			}
+		}
+	}
+}
+
+// Runs returns an iterator over the runs of the paragraph, including the runs of its hyperlinks
+// and tracked insertions.
+func (p *Paragraph) Runs() iter.Seq[*Run] {
+	return func(yield func(*Run) bool) {
+		for _, child := range p.GetCT().Children {
+			runs := []*ctypes.Run{child.Run}
+			if child.Link != nil {
+				runs = child.Link.Runs()
+			}
+			if child.Ins != nil {
+				runs = child.Ins.Runs
+			}
+			for _, run := range runs {
+				if run != nil && !yield(newRun(p.root, p.GetCT(), run)) {
+					return
+				}
+			}
+		}
+	}
+}
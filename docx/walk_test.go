@@ -0,0 +1,125 @@
+package docx
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"godocx/wml/ctypes"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func walkTestDoc(t *testing.T) *RootDoc {
+	rd := setupRootDoc(t)
+	p := rd.AddParagraph("Intro ")
+	p.AddLink("link", "https://example.com")
+
+	tbl := rd.AddTable()
+	row := tbl.AddRow()
+	row.AddCell().AddParagraph("A1")
+	cell := row.AddCell()
+	cell.AddParagraph("B1")
+	nested := NewTable(rd)
+	nested.AddRow().AddCell().AddParagraph("Inner")
+	cell.GetCT().Contents = append(cell.GetCT().Contents, ctypes.TCBlockContent{Table: nested.GetCT()})
+	tbl.AddRow().AddCell().AddParagraph("A2")
+
+	rd.AddParagraph("Outro")
+	return rd
+}
+
+func TestWalk(t *testing.T) {
+	rd := walkTestDoc(t)
+
+	var trace []string
+	rd.Walk(func(el Element, parents []Element) WalkAction {
+		name := strings.TrimPrefix(fmt.Sprintf("%T", el), "*docx.")
+		if p, ok := el.(*Paragraph); ok {
+			name += ":" + p.Text()
+		}
+		trace = append(trace, fmt.Sprintf("%d %s", len(parents), name))
+		return WalkContinue
+	})
+	assert.Equal(t, []string{
+		"0 Body",
+		"1 Paragraph:Intro link", "2 Run", "2 Hyperlink", "3 Run",
+		"1 Table", "2 Row",
+		"3 Cell", "4 Paragraph:A1", "5 Run",
+		"3 Cell", "4 Paragraph:B1", "5 Run",
+		"4 Table", "5 Row", "6 Cell", "7 Paragraph:Inner", "8 Run",
+		"2 Row", "3 Cell", "4 Paragraph:A2", "5 Run",
+		"1 Paragraph:Outro", "2 Run",
+	}, trace)
+
+	// Skipping tables and stopping at the second paragraph
+	trace = nil
+	rd.Walk(func(el Element, parents []Element) WalkAction {
+		switch el := el.(type) {
+		case *Table:
+			return WalkSkip
+		case *Paragraph:
+			trace = append(trace, el.Text())
+			if len(trace) == 2 {
+				return WalkStop
+			}
+		}
+		return WalkContinue
+	})
+	assert.Equal(t, []string{"Intro link", "Outro"}, trace)
+
+	// Parents hold the enclosing elements
+	rd.Walk(func(el Element, parents []Element) WalkAction {
+		if p, ok := el.(*Paragraph); ok && p.Text() == "Inner" {
+			require.Len(t, parents, 7)
+			assert.IsType(t, &Body{}, parents[0])
+			assert.Same(t, rd.Document.Body.Children[1].Table, parents[1])
+			assert.IsType(t, &Cell{}, parents[6])
+			return WalkStop
+		}
+		return WalkContinue
+	})
+}
+
+func TestIterators(t *testing.T) {
+	rd := walkTestDoc(t)
+
+	var texts []string
+	for p := range rd.Paragraphs() {
+		texts = append(texts, p.Text())
+	}
+	assert.Equal(t, []string{"Intro link", "A1", "B1", "Inner", "A2", "Outro"}, texts)
+
+	// Handles modify the document
+	for p := range rd.Paragraphs() {
+		if p.Text() == "Inner" {
+			p.AddText("!")
+			break
+		}
+	}
+	text, err := rd.Text(nil)
+	require.NoError(t, err)
+	assert.Contains(t, text, "Inner!")
+
+	var tables []*Table
+	for tbl := range rd.Tables() {
+		tables = append(tables, tbl)
+	}
+	require.Len(t, tables, 2)
+	assert.Same(t, rd.Document.Body.Children[1].Table, tables[0])
+
+	var cells []string
+	for row := range tables[0].Rows() {
+		for cell := range row.Cells() {
+			cells = append(cells, cell.Text())
+		}
+	}
+	assert.Equal(t, []string{"A1", "B1\nInner!", "A2"}, cells)
+
+	var runs []string
+	for run := range rd.Document.Body.Children[0].Para.Runs() {
+		runs = append(runs, run.Text())
+	}
+	assert.Equal(t, []string{"Intro ", "link"}, runs)
+}
@@ -52,6 +52,17 @@ func (rd *RootDoc) writeToZip(zw *zip.Writer) error {
 		files []string
 	)
 
+	// Built-in styles referenced by the content are defined before writing the styles
+	rd.addMissingBuiltinStyles()
+
+	// Persist the numbering definitions into numbering.xml if they changed, before the
+	// relationships and content types a new numbering part is added to
+	if rd.Numbering != nil {
+		if err := rd.Numbering.applyToFileMap(); err != nil {
+			return err
+		}
+	}
+
 	// Build a local deterministic snapshot rather than mutating rd.FileMap while writing
 	snapshot := make(map[string][]byte)
 
@@ -79,22 +90,23 @@ func (rd *RootDoc) writeToZip(zw *zip.Writer) error {
 	}
 	snapshot[rd.Document.relativePath] = docContent
 
+	// Header and footer parts that have been changed are written from their structure
+	for _, hf := range rd.hdrFtr {
+		hfContent, err := hf.changed()
+		if err != nil {
+			return err
+		}
+		if hfContent != nil {
+			snapshot[hf.Path] = hfContent
+		}
+	}
+
 	docStyleBytes, err := marshal(rd.DocStyles)
 	if err != nil {
 		return err
 	}
 	snapshot[rd.DocStyles.RelativePath] = docStyleBytes
 
-	// Persist numbering instances into numbering.xml if any
-	if rd.Numbering != nil {
-		// Apply numbering into a temporary buffer based on either existing or minimal content
-		// by temporarily swapping root to a shallow copy with only the snapshot layer.
-		// Minimal change: read existing numbering.xml if present from rd.FileMap, else let manager create it.
-		if err := rd.Numbering.applyToFileMap(); err != nil {
-			return err
-		}
-	}
-
 	// Collect files from original map
 	rd.FileMap.Range(func(path, content any) bool {
 		p := path.(string)
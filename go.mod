@@ -1,9 +1,10 @@
 module godocx
 
-go 1.25
+go 1.25.0
 
 require (
 	github.com/stretchr/testify v1.11.1
+	golang.org/x/net v0.57.0
 )
 
 require (
@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"reflect"
+)
+
+// DeepCopy returns a deep copy of the given value.
+//
+// Pointers, slices, maps and interfaces are followed and duplicated so that the
+// result shares no mutable state with the input. Unexported struct fields are
+// copied by value only (they cannot be set through reflection), which is
+// sufficient for the complex types in this module as they keep their state in
+// exported fields.
+func DeepCopy[T any](src T) T {
+	v := reflect.ValueOf(&src).Elem()
+	dst := reflect.New(v.Type()).Elem()
+	deepCopyValue(dst, v, map[uintptr]reflect.Value{})
+	return dst.Interface().(T)
+}
+
+func deepCopyValue(dst, src reflect.Value, seen map[uintptr]reflect.Value) {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		if cp, ok := seen[src.Pointer()]; ok && cp.Type() == src.Type() {
+			dst.Set(cp)
+			return
+		}
+		cp := reflect.New(src.Type().Elem())
+		seen[src.Pointer()] = cp
+		deepCopyValue(cp.Elem(), src.Elem(), seen)
+		dst.Set(cp)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := src.Elem()
+		cp := reflect.New(elem.Type()).Elem()
+		deepCopyValue(cp, elem, seen)
+		dst.Set(cp)
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		cp := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		for i := 0; i < src.Len(); i++ {
+			deepCopyValue(cp.Index(i), src.Index(i), seen)
+		}
+		dst.Set(cp)
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			deepCopyValue(dst.Index(i), src.Index(i), seen)
+		}
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		cp := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			val := reflect.New(iter.Value().Type()).Elem()
+			deepCopyValue(val, iter.Value(), seen)
+			cp.SetMapIndex(iter.Key(), val)
+		}
+		dst.Set(cp)
+	case reflect.Struct:
+		// Shallow copy first so that unexported fields carry across
+		dst.Set(src)
+		for i := 0; i < src.NumField(); i++ {
+			if !dst.Field(i).CanSet() {
+				continue
+			}
+			field := reflect.New(src.Field(i).Type()).Elem()
+			deepCopyValue(field, src.Field(i), seen)
+			dst.Field(i).Set(field)
+		}
+	default:
+		dst.Set(src)
+	}
+}
+
+// Overlay copies the fields of src that are set, i.e. not the zero value of their type, over
+// the fields of dst. Copied values are deep copies, dst shares no mutable state with src.
+// Nothing is copied when either pointer is nil.
+func Overlay[T any](dst, src *T) {
+	if dst == nil || src == nil {
+		return
+	}
+	d, s := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem()
+	if s.Kind() != reflect.Struct {
+		if !s.IsZero() {
+			deepCopyValue(d, s, map[uintptr]reflect.Value{})
+		}
+		return
+	}
+	for i := 0; i < s.NumField(); i++ {
+		if !d.Field(i).CanSet() || s.Field(i).IsZero() {
+			continue
+		}
+		field := reflect.New(s.Field(i).Type()).Elem()
+		deepCopyValue(field, s.Field(i), map[uintptr]reflect.Value{})
+		d.Field(i).Set(field)
+	}
+}
@@ -0,0 +1,43 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"strconv"
+)
+
+// BookmarkStart is the start of a bookmark (w:bookmarkStart)
+type BookmarkStart struct {
+	ID       int    `xml:"id,attr"`
+	Name     string `xml:"name,attr"`
+	ColFirst *int   `xml:"colFirst,attr,omitempty"` // First table column of a bookmark spanning table cells
+	ColLast  *int   `xml:"colLast,attr,omitempty"`  // Last table column of a bookmark spanning table cells
+}
+
+func (b BookmarkStart) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "w:bookmarkStart"}
+	start.Attr = []xml.Attr{
+		{Name: xml.Name{Local: "w:id"}, Value: strconv.Itoa(b.ID)},
+		{Name: xml.Name{Local: "w:name"}, Value: b.Name},
+	}
+
+	if b.ColFirst != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:colFirst"}, Value: strconv.Itoa(*b.ColFirst)})
+	}
+	if b.ColLast != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:colLast"}, Value: strconv.Itoa(*b.ColLast)})
+	}
+
+	return e.EncodeElement("", start)
+}
+
+// BookmarkEnd is the end of a bookmark (w:bookmarkEnd)
+type BookmarkEnd struct {
+	ID int `xml:"id,attr"`
+}
+
+func (b BookmarkEnd) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name = xml.Name{Local: "w:bookmarkEnd"}
+	start.Attr = []xml.Attr{{Name: xml.Name{Local: "w:id"}, Value: strconv.Itoa(b.ID)}}
+
+	return e.EncodeElement("", start)
+}
@@ -0,0 +1,39 @@
+package ctypes
+
+import (
+	"encoding/xml"
+
+	"godocx/wml/stypes"
+)
+
+// FldChar is a complex field character: the start of a field, the separator between its code
+// and its result, or its end. The code is held by the instrText elements between the start and
+// the separator.
+type FldChar struct {
+	FldCharType stypes.FldCharType `xml:"fldCharType,attr"`
+	FldLock     *stypes.OnOff      `xml:"fldLock,attr,omitempty"` // Field Should Not Be Recalculated
+	Dirty       *stypes.OnOff      `xml:"dirty,attr,omitempty"`   // Field Result Invalidated
+}
+
+// NewFldChar creates a new FldChar element with the given type.
+func NewFldChar(fldCharType stypes.FldCharType) *FldChar {
+	return &FldChar{
+		FldCharType: fldCharType,
+	}
+}
+
+// MarshalXML implements the xml.Marshaler interface.
+func (f FldChar) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:fldChar"
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:fldCharType"}, Value: string(f.FldCharType)})
+
+	if f.FldLock != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:fldLock"}, Value: string(*f.FldLock)})
+	}
+
+	if f.Dirty != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:dirty"}, Value: string(*f.Dirty)})
+	}
+
+	return e.EncodeElement("", start)
+}
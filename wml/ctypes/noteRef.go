@@ -0,0 +1,24 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"strconv"
+
+	"godocx/wml/stypes"
+)
+
+// NoteRef is a reference to a footnote or an endnote (w:footnoteReference, w:endnoteReference)
+type NoteRef struct {
+	ID                int           `xml:"id,attr"`
+	CustomMarkFollows *stypes.OnOff `xml:"customMarkFollows,attr,omitempty"`
+}
+
+func (n NoteRef) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Attr = []xml.Attr{{Name: xml.Name{Local: "w:id"}, Value: strconv.Itoa(n.ID)}}
+
+	if n.CustomMarkFollows != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:customMarkFollows"}, Value: string(*n.CustomMarkFollows)})
+	}
+
+	return e.EncodeElement("", start)
+}
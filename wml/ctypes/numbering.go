@@ -0,0 +1,528 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+
+	"godocx/common/constants"
+	"godocx/wml/stypes"
+)
+
+var defaultNumberingNSAttrs = []xml.Attr{
+	{Name: xml.Name{Local: "xmlns:w"}, Value: "http://schemas.openxmlformats.org/wordprocessingml/2006/main"},
+	{Name: xml.Name{Local: "xmlns:r"}, Value: "http://schemas.openxmlformats.org/officeDocument/2006/relationships"},
+	{Name: xml.Name{Local: "xmlns:o"}, Value: "urn:schemas-microsoft-com:office:office"},
+	{Name: xml.Name{Local: "xmlns:v"}, Value: "urn:schemas-microsoft-com:vml"},
+	{Name: xml.Name{Local: "xmlns:w10"}, Value: "urn:schemas-microsoft-com:office:word"},
+	{Name: xml.Name{Local: "xmlns:mc"}, Value: "http://schemas.openxmlformats.org/markup-compatibility/2006"},
+	{Name: xml.Name{Local: "xmlns:w14"}, Value: "http://schemas.microsoft.com/office/word/2010/wordml"},
+	{Name: xml.Name{Local: "mc:Ignorable"}, Value: "w14"},
+}
+
+// Numbering Definitions: the content of the numbering part
+type Numbering struct {
+	Attr []xml.Attr
+
+	// Sequence:
+
+	//1. Picture Numbering Symbol Definitions
+	PicBullets []*NumPicBullet `xml:"numPicBullet,omitempty"`
+
+	//2. Abstract Numbering Definitions
+	AbstractNums []*AbstractNum `xml:"abstractNum,omitempty"`
+
+	//3. Numbering Definition Instances
+	Nums []*Num `xml:"num,omitempty"`
+}
+
+// AbstractNumByID returns the abstract numbering definition with the given ID, nil if there is none.
+func (n *Numbering) AbstractNumByID(id int) *AbstractNum {
+	for _, abstract := range n.AbstractNums {
+		if abstract.ID == id {
+			return abstract
+		}
+	}
+	return nil
+}
+
+// NumByID returns the numbering definition instance with the given ID, nil if there is none.
+func (n *Numbering) NumByID(id int) *Num {
+	for _, num := range n.Nums {
+		if num.ID == id {
+			return num
+		}
+	}
+	return nil
+}
+
+// PicBulletByID returns the picture numbering symbol with the given ID, nil if there is none.
+func (n *Numbering) PicBulletByID(id int) *NumPicBullet {
+	for _, bullet := range n.PicBullets {
+		if bullet.ID == id {
+			return bullet
+		}
+	}
+	return nil
+}
+
+// NextAbstractNumID returns the ID following the highest abstract numbering definition ID.
+func (n *Numbering) NextAbstractNumID() int {
+	next := 0
+	for _, abstract := range n.AbstractNums {
+		next = max(next, abstract.ID+1)
+	}
+	return next
+}
+
+// NextNumID returns the ID following the highest numbering definition instance ID, at least 1.
+func (n *Numbering) NextNumID() int {
+	next := 1
+	for _, num := range n.Nums {
+		next = max(next, num.ID+1)
+	}
+	return next
+}
+
+// NextPicBulletID returns the ID following the highest picture numbering symbol ID.
+func (n *Numbering) NextPicBulletID() int {
+	next := 0
+	for _, bullet := range n.PicBullets {
+		next = max(next, bullet.ID+1)
+	}
+	return next
+}
+
+func (n *Numbering) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:numbering"
+
+	if len(n.Attr) == 0 {
+		start.Attr = append(start.Attr, defaultNumberingNSAttrs...)
+	} else {
+		start.Attr = n.Attr
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	//1. Picture Numbering Symbol Definitions
+	for _, bullet := range n.PicBullets {
+		if err := bullet.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:numPicBullet"}}); err != nil {
+			return fmt.Errorf("numPicBullet: %w", err)
+		}
+	}
+
+	//2. Abstract Numbering Definitions
+	for _, abstract := range n.AbstractNums {
+		if err := abstract.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:abstractNum"}}); err != nil {
+			return fmt.Errorf("abstractNum: %w", err)
+		}
+	}
+
+	//3. Numbering Definition Instances
+	for _, num := range n.Nums {
+		if err := num.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:num"}}); err != nil {
+			return fmt.Errorf("num: %w", err)
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (n *Numbering) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	n.Attr = make([]xml.Attr, 0, len(start.Attr))
+	for _, attr := range start.Attr {
+		ns := attr.Name.Space
+		if ns != "xmlns" && ns != "" {
+			// Namespaces are decoded as their URL, attributes are written back with their prefix
+			local, ok := constants.NSToLocal[ns]
+			if !ok {
+				continue
+			}
+			ns = local
+		}
+		name := attr.Name.Local
+		if ns != "" {
+			name = ns + ":" + name
+		}
+		n.Attr = append(n.Attr, xml.Attr{Name: xml.Name{Local: name}, Value: attr.Value})
+	}
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := token.(type) {
+		case xml.StartElement:
+			switch elem.Name.Local {
+			case "numPicBullet":
+				bullet := &NumPicBullet{}
+				if err := d.DecodeElement(bullet, &elem); err != nil {
+					return err
+				}
+				n.PicBullets = append(n.PicBullets, bullet)
+			case "abstractNum":
+				abstract := &AbstractNum{}
+				if err := d.DecodeElement(abstract, &elem); err != nil {
+					return err
+				}
+				n.AbstractNums = append(n.AbstractNums, abstract)
+			case "num":
+				num := &Num{}
+				if err := d.DecodeElement(num, &elem); err != nil {
+					return err
+				}
+				n.Nums = append(n.Nums, num)
+			default:
+				if err := d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// Picture Numbering Symbol Definition
+type NumPicBullet struct {
+	// Picture Numbering Symbol ID
+	ID int `xml:"numPicBulletId,attr"`
+
+	// Picture of the symbol: the w:pict or w:drawing element, kept as XML
+	Content string `xml:",innerxml"`
+}
+
+func (b *NumPicBullet) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:numPicBullet"
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:numPicBulletId"}, Value: strconv.Itoa(b.ID)})
+	content := struct {
+		Content string `xml:",innerxml"`
+	}{b.Content}
+	return e.EncodeElement(content, start)
+}
+
+// Abstract Numbering Definition: the levels of a list, shared by its instances
+type AbstractNum struct {
+	// Abstract Numbering Definition ID
+	ID int `xml:"abstractNumId,attr"`
+
+	// Sequence:
+
+	//1. Abstract Numbering Definition Identifier
+	Nsid *CTString `xml:"nsid,omitempty"`
+
+	//2. Abstract Numbering Definition Type
+	MultiLevelType *GenSingleStrVal[stypes.MultiLevelType] `xml:"multiLevelType,omitempty"`
+
+	//3. Numbering Template Code
+	Tmpl *CTString `xml:"tmpl,omitempty"`
+
+	//4. Abstract Numbering Definition Name
+	Name *CTString `xml:"name,omitempty"`
+
+	//5. Numbering Style Definition
+	StyleLink *CTString `xml:"styleLink,omitempty"`
+
+	//6. Numbering Style Reference
+	NumStyleLink *CTString `xml:"numStyleLink,omitempty"`
+
+	//7. Numbering Level Definitions
+	Levels []*Level `xml:"lvl,omitempty"`
+}
+
+// Level returns the definition of the level, nil if there is none.
+func (a *AbstractNum) Level(ilvl int) *Level {
+	for _, level := range a.Levels {
+		if level.ILvl == ilvl {
+			return level
+		}
+	}
+	return nil
+}
+
+func (a *AbstractNum) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:abstractNum"
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:abstractNumId"}, Value: strconv.Itoa(a.ID)})
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	//1. Abstract Numbering Definition Identifier
+	if a.Nsid != nil {
+		if err := a.Nsid.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:nsid"}}); err != nil {
+			return fmt.Errorf("AbstractNum Nsid: %w", err)
+		}
+	}
+
+	//2. Abstract Numbering Definition Type
+	if a.MultiLevelType != nil {
+		if err := a.MultiLevelType.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:multiLevelType"}}); err != nil {
+			return fmt.Errorf("AbstractNum MultiLevelType: %w", err)
+		}
+	}
+
+	//3. Numbering Template Code
+	if a.Tmpl != nil {
+		if err := a.Tmpl.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:tmpl"}}); err != nil {
+			return fmt.Errorf("AbstractNum Tmpl: %w", err)
+		}
+	}
+
+	//4. Abstract Numbering Definition Name
+	if a.Name != nil {
+		if err := a.Name.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:name"}}); err != nil {
+			return fmt.Errorf("AbstractNum Name: %w", err)
+		}
+	}
+
+	//5. Numbering Style Definition
+	if a.StyleLink != nil {
+		if err := a.StyleLink.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:styleLink"}}); err != nil {
+			return fmt.Errorf("AbstractNum StyleLink: %w", err)
+		}
+	}
+
+	//6. Numbering Style Reference
+	if a.NumStyleLink != nil {
+		if err := a.NumStyleLink.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:numStyleLink"}}); err != nil {
+			return fmt.Errorf("AbstractNum NumStyleLink: %w", err)
+		}
+	}
+
+	//7. Numbering Level Definitions
+	for _, level := range a.Levels {
+		if err := level.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:lvl"}}); err != nil {
+			return fmt.Errorf("AbstractNum Levels: %w", err)
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// Numbering Level Definition
+type Level struct {
+	// Numbering Level
+	ILvl int `xml:"ilvl,attr"`
+
+	// Template Code
+	Tplc string `xml:"tplc,attr,omitempty"`
+
+	// Tentative Numbering: the level is not used by the document yet
+	Tentative *stypes.OnOff `xml:"tentative,attr,omitempty"`
+
+	// Sequence:
+
+	//1. Starting Value
+	Start *DecimalNum `xml:"start,omitempty"`
+
+	//2. Numbering Format
+	NumFmt *GenSingleStrVal[stypes.NumFmt] `xml:"numFmt,omitempty"`
+
+	//3. Restart Numbering Level Symbol
+	LvlRestart *DecimalNum `xml:"lvlRestart,omitempty"`
+
+	//4. Paragraph Style's Associated Numbering Level
+	PStyle *CTString `xml:"pStyle,omitempty"`
+
+	//5. Display All Levels Using Arabic Numerals
+	IsLgl *OnOff `xml:"isLgl,omitempty"`
+
+	//6. Content Between Numbering Symbol and Paragraph Text
+	Suffix *GenSingleStrVal[stypes.LevelSuffix] `xml:"suff,omitempty"`
+
+	//7. Numbering Level Text
+	LvlText *CTString `xml:"lvlText,omitempty"`
+
+	//8. Picture Numbering Symbol Definition Reference
+	PicBulletID *DecimalNum `xml:"lvlPicBulletId,omitempty"`
+
+	//9. Justification
+	Justification *GenSingleStrVal[stypes.Justification] `xml:"lvlJc,omitempty"`
+
+	//10. Numbering Level Associated Paragraph Properties
+	ParaProp *ParagraphProp `xml:"pPr,omitempty"`
+
+	//11. Numbering Symbol Run Properties
+	RunProp *RunProperty `xml:"rPr,omitempty"`
+}
+
+func (l *Level) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:lvl"
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:ilvl"}, Value: strconv.Itoa(l.ILvl)})
+	if l.Tplc != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:tplc"}, Value: l.Tplc})
+	}
+	if l.Tentative != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:tentative"}, Value: string(*l.Tentative)})
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	//1. Starting Value
+	if l.Start != nil {
+		if err := l.Start.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:start"}}); err != nil {
+			return fmt.Errorf("Level Start: %w", err)
+		}
+	}
+
+	//2. Numbering Format
+	if l.NumFmt != nil {
+		if err := l.NumFmt.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:numFmt"}}); err != nil {
+			return fmt.Errorf("Level NumFmt: %w", err)
+		}
+	}
+
+	//3. Restart Numbering Level Symbol
+	if l.LvlRestart != nil {
+		if err := l.LvlRestart.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:lvlRestart"}}); err != nil {
+			return fmt.Errorf("Level LvlRestart: %w", err)
+		}
+	}
+
+	//4. Paragraph Style's Associated Numbering Level
+	if l.PStyle != nil {
+		if err := l.PStyle.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:pStyle"}}); err != nil {
+			return fmt.Errorf("Level PStyle: %w", err)
+		}
+	}
+
+	//5. Display All Levels Using Arabic Numerals
+	if l.IsLgl != nil {
+		if err := l.IsLgl.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:isLgl"}}); err != nil {
+			return fmt.Errorf("Level IsLgl: %w", err)
+		}
+	}
+
+	//6. Content Between Numbering Symbol and Paragraph Text
+	if l.Suffix != nil {
+		if err := l.Suffix.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:suff"}}); err != nil {
+			return fmt.Errorf("Level Suffix: %w", err)
+		}
+	}
+
+	//7. Numbering Level Text
+	if l.LvlText != nil {
+		if err := l.LvlText.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:lvlText"}}); err != nil {
+			return fmt.Errorf("Level LvlText: %w", err)
+		}
+	}
+
+	//8. Picture Numbering Symbol Definition Reference
+	if l.PicBulletID != nil {
+		if err := l.PicBulletID.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:lvlPicBulletId"}}); err != nil {
+			return fmt.Errorf("Level PicBulletID: %w", err)
+		}
+	}
+
+	//9. Justification
+	if l.Justification != nil {
+		if err := l.Justification.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:lvlJc"}}); err != nil {
+			return fmt.Errorf("Level Justification: %w", err)
+		}
+	}
+
+	//10. Numbering Level Associated Paragraph Properties
+	if l.ParaProp != nil {
+		if err := l.ParaProp.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:pPr"}}); err != nil {
+			return fmt.Errorf("Level ParaProp: %w", err)
+		}
+	}
+
+	//11. Numbering Symbol Run Properties
+	if l.RunProp != nil {
+		if err := l.RunProp.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:rPr"}}); err != nil {
+			return fmt.Errorf("Level RunProp: %w", err)
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// Numbering Definition Instance: a list, numbered after an abstract numbering definition
+type Num struct {
+	// Numbering Definition Instance ID, referenced by the numbering properties of paragraphs
+	ID int `xml:"numId,attr"`
+
+	// Sequence:
+
+	//1. Abstract Numbering Definition Reference
+	AbstractNumID DecimalNum `xml:"abstractNumId"`
+
+	//2. Numbering Level Definition Overrides
+	LvlOverrides []*LvlOverride `xml:"lvlOverride,omitempty"`
+}
+
+// Override returns the override of the level, nil if there is none.
+func (n *Num) Override(ilvl int) *LvlOverride {
+	for _, override := range n.LvlOverrides {
+		if override.ILvl == ilvl {
+			return override
+		}
+	}
+	return nil
+}
+
+func (n *Num) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:num"
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:numId"}, Value: strconv.Itoa(n.ID)})
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if err := n.AbstractNumID.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:abstractNumId"}}); err != nil {
+		return fmt.Errorf("Num AbstractNumID: %w", err)
+	}
+
+	for _, override := range n.LvlOverrides {
+		if err := override.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:lvlOverride"}}); err != nil {
+			return fmt.Errorf("Num lvlOverride: %w", err)
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// Numbering Level Definition Override
+type LvlOverride struct {
+	// Numbering Level ID
+	ILvl int `xml:"ilvl,attr"`
+
+	// Sequence:
+
+	//1. Numbering Level Starting Value Override
+	StartOverride *DecimalNum `xml:"startOverride,omitempty"`
+
+	//2. Numbering Level Override Definition
+	Level *Level `xml:"lvl,omitempty"`
+}
+
+func (o *LvlOverride) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start.Name.Local = "w:lvlOverride"
+	start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:ilvl"}, Value: strconv.Itoa(o.ILvl)})
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if o.StartOverride != nil {
+		if err := o.StartOverride.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:startOverride"}}); err != nil {
+			return fmt.Errorf("LvlOverride StartOverride: %w", err)
+		}
+	}
+
+	if o.Level != nil {
+		if err := o.Level.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:lvl"}}); err != nil {
+			return fmt.Errorf("LvlOverride Level: %w", err)
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
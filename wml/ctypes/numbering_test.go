@@ -0,0 +1,126 @@
+package ctypes
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"godocx/wml/stypes"
+)
+
+func TestNumbering_MarshalXML(t *testing.T) {
+	tentative := stypes.OnOffOne
+	numbering := Numbering{
+		Attr: []xml.Attr{{Name: xml.Name{Local: "xmlns:w"}, Value: "http://schemas.openxmlformats.org/wordprocessingml/2006/main"}},
+		PicBullets: []*NumPicBullet{
+			{ID: 0, Content: `<w:pict></w:pict>`},
+		},
+		AbstractNums: []*AbstractNum{
+			{
+				ID:             3,
+				Nsid:           NewCTString("1A2B3C4D"),
+				MultiLevelType: NewGenSingleStrVal(stypes.MultiLevelTypeHybridMultilevel),
+				Levels: []*Level{
+					{
+						ILvl:          0,
+						Start:         NewDecimalNum(1),
+						NumFmt:        NewGenSingleStrVal(stypes.NumFmtDecimal),
+						Suffix:        NewGenSingleStrVal(stypes.LevelSuffixSpace),
+						LvlText:       NewCTString("%1."),
+						Justification: NewGenSingleStrVal(stypes.JustificationLeft),
+					},
+					{
+						ILvl:        1,
+						Tentative:   &tentative,
+						NumFmt:      NewGenSingleStrVal(stypes.NumFmtBullet),
+						PicBulletID: NewDecimalNum(0),
+					},
+				},
+			},
+		},
+		Nums: []*Num{
+			{ID: 1, AbstractNumID: DecimalNum{Val: 3}},
+			{ID: 2, AbstractNumID: DecimalNum{Val: 3}, LvlOverrides: []*LvlOverride{{ILvl: 0, StartOverride: NewDecimalNum(5)}}},
+		},
+	}
+	expected := `<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:numPicBullet w:numPicBulletId="0"><w:pict></w:pict></w:numPicBullet>` +
+		`<w:abstractNum w:abstractNumId="3"><w:nsid w:val="1A2B3C4D"></w:nsid><w:multiLevelType w:val="hybridMultilevel"></w:multiLevelType>` +
+		`<w:lvl w:ilvl="0"><w:start w:val="1"></w:start><w:numFmt w:val="decimal"></w:numFmt><w:suff w:val="space"></w:suff><w:lvlText w:val="%1."></w:lvlText><w:lvlJc w:val="left"></w:lvlJc></w:lvl>` +
+		`<w:lvl w:ilvl="1" w:tentative="1"><w:numFmt w:val="bullet"></w:numFmt><w:lvlPicBulletId w:val="0"></w:lvlPicBulletId></w:lvl></w:abstractNum>` +
+		`<w:num w:numId="1"><w:abstractNumId w:val="3"></w:abstractNumId></w:num>` +
+		`<w:num w:numId="2"><w:abstractNumId w:val="3"></w:abstractNumId><w:lvlOverride w:ilvl="0"><w:startOverride w:val="5"></w:startOverride></w:lvlOverride></w:num>` +
+		`</w:numbering>`
+
+	var result strings.Builder
+	encoder := xml.NewEncoder(&result)
+	if err := numbering.MarshalXML(encoder, xml.StartElement{}); err != nil {
+		t.Fatalf("Error marshaling XML: %v", err)
+	}
+	encoder.Flush()
+
+	if result.String() != expected {
+		t.Errorf("Expected XML:\n%s\nGot:\n%s", expected, result.String())
+	}
+}
+
+func TestNumbering_UnmarshalXML(t *testing.T) {
+	input := `<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006" mc:Ignorable="w14">
+		<w:abstractNum w:abstractNumId="0">
+			<w:nsid w:val="FFFFFF7C"/>
+			<w:multiLevelType w:val="singleLevel"/>
+			<w:lvl w:ilvl="0">
+				<w:start w:val="3"/>
+				<w:numFmt w:val="upperRoman"/>
+				<w:pStyle w:val="ListNumber"/>
+				<w:lvlText w:val="%1)"/>
+				<w:lvlJc w:val="end"/>
+				<w:pPr><w:ind w:left="720" w:hanging="360"/></w:pPr>
+				<w:rPr><w:b/></w:rPr>
+			</w:lvl>
+		</w:abstractNum>
+		<w:num w:numId="4">
+			<w:abstractNumId w:val="0"/>
+			<w:lvlOverride w:ilvl="0"><w:startOverride w:val="1"/></w:lvlOverride>
+		</w:num>
+		<w:numIdMacAtCleanup w:val="2"/>
+	</w:numbering>`
+
+	var numbering Numbering
+	if err := xml.Unmarshal([]byte(input), &numbering); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if len(numbering.Attr) != 3 || numbering.Attr[2].Name.Local != "mc:Ignorable" {
+		t.Errorf("Expected the namespace attributes to be kept, got %v", numbering.Attr)
+	}
+
+	abstract := numbering.AbstractNumByID(0)
+	if abstract == nil || abstract.MultiLevelType.Val != stypes.MultiLevelTypeSingleLevel {
+		t.Fatalf("Expected abstract numbering 0 of type singleLevel, got %+v", abstract)
+	}
+	level := abstract.Level(0)
+	if level == nil {
+		t.Fatalf("Expected level 0")
+	}
+	if level.Start.Val != 3 || level.NumFmt.Val != stypes.NumFmtUpperRoman || level.LvlText.Val != "%1)" {
+		t.Errorf("Unexpected level values: %+v", level)
+	}
+	if level.PStyle.Val != "ListNumber" || level.Justification.Val != stypes.JustificationEnd {
+		t.Errorf("Unexpected level style or justification: %+v", level)
+	}
+	if level.ParaProp == nil || *level.ParaProp.Indent.Left != 720 || level.RunProp == nil || level.RunProp.Bold == nil {
+		t.Errorf("Expected level paragraph and run properties, got %+v", level)
+	}
+
+	num := numbering.NumByID(4)
+	if num == nil || num.AbstractNumID.Val != 0 {
+		t.Fatalf("Expected instance 4 of abstract numbering 0, got %+v", num)
+	}
+	if override := num.Override(0); override == nil || override.StartOverride.Val != 1 {
+		t.Errorf("Expected start override of level 0, got %+v", override)
+	}
+	if numbering.NextNumID() != 5 || numbering.NextAbstractNumID() != 1 {
+		t.Errorf("Unexpected next IDs %d and %d", numbering.NextNumID(), numbering.NextAbstractNumID())
+	}
+}
@@ -38,6 +38,22 @@ func (n *OnOff) Disable() {
 	n.Val = &o
 }
 
+// Bool reports whether the property is turned on. An element without a value is on and a
+// missing element (nil) is off.
+func (n *OnOff) Bool() bool {
+	if n == nil {
+		return false
+	}
+	if n.Val == nil {
+		return true
+	}
+	switch *n.Val {
+	case stypes.OnOffFalse, stypes.OnOffZero, stypes.OnOffOff:
+		return false
+	}
+	return true
+}
+
 // MarshalXML implements the xml.Marshaler interface for the Bold type.
 // It encodes the instance into XML using the "w:XMLName" element with a "w:val" attribute.
 func (n OnOff) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
@@ -82,3 +82,19 @@ func TestOnOff_UnmarshalXML(t *testing.T) {
 		})
 	}
 }
+
+func TestOnOff_Bool(t *testing.T) {
+	var missing *OnOff
+	if missing.Bool() {
+		t.Error("nil OnOff should be off")
+	}
+	if !(&OnOff{}).Bool() {
+		t.Error("OnOff without value should be on")
+	}
+	if OnOffFromBool(false).Bool() {
+		t.Error("OnOff false should be off")
+	}
+	if !OnOffFromBool(true).Bool() {
+		t.Error("OnOff true should be on")
+	}
+}
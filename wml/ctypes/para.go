@@ -25,17 +25,118 @@ type Paragraph struct {
 }
 
 type ParagraphChild struct {
-	Link *Hyperlink // w:hyperlink
-	Run  *Run       // i.e w:r
+	Link          *Hyperlink      // w:hyperlink
+	Run           *Run            // i.e w:r
+	BookmarkStart *BookmarkStart  // w:bookmarkStart
+	BookmarkEnd   *BookmarkEnd    // w:bookmarkEnd
+	Ins           *RunTrackChange // w:ins
+	Del           *RunTrackChange // w:del
 }
 
 type Hyperlink struct {
 	XMLName  xml.Name `xml:"http://schemas.openxmlformats.org/wordprocessingml/2006/main hyperlink,omitempty"`
 	ID       string   `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	Anchor   string   // Hyperlink target within the document (bookmark name)
 	Run      *Run
 	Children []ParagraphChild
 }
 
+func (h Hyperlink) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
+	start.Name = xml.Name{Local: "w:hyperlink"}
+	start.Attr = nil
+
+	if h.ID != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "r:id"}, Value: h.ID})
+	}
+	if h.Anchor != "" {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:anchor"}, Value: h.Anchor})
+	}
+
+	if err = e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	if h.Run != nil {
+		if err = h.Run.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+	}
+
+	for _, cElem := range h.Children {
+		if cElem.Run != nil {
+			if err = cElem.Run.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+		if err = marshalRunTrackChanges(e, cElem); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+func (h *Hyperlink) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "id":
+			h.ID = attr.Value
+		case "anchor":
+			h.Anchor = attr.Value
+		}
+	}
+
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			switch elem.Name.Local {
+			case "r":
+				r := NewRun()
+				if err = d.DecodeElement(r, &elem); err != nil {
+					return err
+				}
+
+				h.Children = append(h.Children, ParagraphChild{Run: r})
+			case "ins", "del":
+				change := &RunTrackChange{}
+				if err = d.DecodeElement(change, &elem); err != nil {
+					return err
+				}
+
+				h.Children = append(h.Children, trackChangeChild(elem.Name.Local, change))
+			default:
+				if err = d.Skip(); err != nil {
+					return err
+				}
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// Runs returns the runs of the hyperlink in document order, including tracked insertions.
+func (h *Hyperlink) Runs() []*Run {
+	var runs []*Run
+	if h.Run != nil {
+		runs = append(runs, h.Run)
+	}
+	for _, c := range h.Children {
+		if c.Run != nil {
+			runs = append(runs, c.Run)
+		}
+		if c.Ins != nil {
+			runs = append(runs, c.Ins.Runs...)
+		}
+	}
+	return runs
+}
+
 func (p Paragraph) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
 	start.Name.Local = "w:p"
 
@@ -84,6 +185,22 @@ func (p Paragraph) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error
 				return err
 			}
 		}
+
+		if cElem.BookmarkStart != nil {
+			if err = cElem.BookmarkStart.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+
+		if cElem.BookmarkEnd != nil {
+			if err = cElem.BookmarkEnd.MarshalXML(e, xml.StartElement{}); err != nil {
+				return err
+			}
+		}
+
+		if err = marshalRunTrackChanges(e, cElem); err != nil {
+			return err
+		}
 	}
 
 	// Closing </w:p> element
@@ -124,6 +241,34 @@ loop:
 				}
 
 				p.Children = append(p.Children, ParagraphChild{Run: r})
+			case "hyperlink":
+				link := &Hyperlink{}
+				if err = d.DecodeElement(link, &elem); err != nil {
+					return err
+				}
+
+				p.Children = append(p.Children, ParagraphChild{Link: link})
+			case "bookmarkStart":
+				bookmark := &BookmarkStart{}
+				if err = d.DecodeElement(bookmark, &elem); err != nil {
+					return err
+				}
+
+				p.Children = append(p.Children, ParagraphChild{BookmarkStart: bookmark})
+			case "bookmarkEnd":
+				bookmark := &BookmarkEnd{}
+				if err = d.DecodeElement(bookmark, &elem); err != nil {
+					return err
+				}
+
+				p.Children = append(p.Children, ParagraphChild{BookmarkEnd: bookmark})
+			case "ins", "del":
+				change := &RunTrackChange{}
+				if err = d.DecodeElement(change, &elem); err != nil {
+					return err
+				}
+
+				p.Children = append(p.Children, trackChangeChild(elem.Name.Local, change))
 			case "pPr":
 				p.Property = &ParagraphProp{}
 				if err = d.DecodeElement(p.Property, &elem); err != nil {
@@ -142,6 +287,30 @@ loop:
 	return nil
 }
 
+// marshalRunTrackChanges encodes the tracked insertion or deletion of the child, if any.
+func marshalRunTrackChanges(e *xml.Encoder, child ParagraphChild) error {
+	if child.Ins != nil {
+		if err := child.Ins.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:ins"}}); err != nil {
+			return err
+		}
+	}
+	if child.Del != nil {
+		if err := child.Del.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:del"}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// trackChangeChild returns the paragraph child holding a tracked change decoded from a w:ins
+// or w:del element.
+func trackChangeChild(local string, change *RunTrackChange) ParagraphChild {
+	if local == "del" {
+		return ParagraphChild{Del: change}
+	}
+	return ParagraphChild{Ins: change}
+}
+
 func (p *Paragraph) AddText(text string) *Run {
 	t := TextFromString(text)
 
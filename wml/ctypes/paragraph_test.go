@@ -48,3 +48,99 @@ func TestParagraphXML(t *testing.T) {
 		t.Errorf("Original and unmarshaled paragraphs are not equal.")
 	}
 }
+
+func TestParagraphHyperlinkUnmarshal(t *testing.T) {
+	xmlStr := `<w:p xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<w:r><w:t>See </w:t></w:r>` +
+		`<w:hyperlink r:id="rId9" w:anchor="intro"><w:r><w:t>the</w:t></w:r><w:r><w:t> site</w:t></w:r></w:hyperlink>` +
+		`</w:p>`
+
+	var p Paragraph
+	if err := xml.Unmarshal([]byte(xmlStr), &p); err != nil {
+		t.Fatalf("Error unmarshaling paragraph: %v", err)
+	}
+
+	if len(p.Children) != 2 || p.Children[1].Link == nil {
+		t.Fatalf("Expected a run and a hyperlink, got %+v", p.Children)
+	}
+
+	link := p.Children[1].Link
+	if link.ID != "rId9" || link.Anchor != "intro" {
+		t.Errorf("Unexpected hyperlink attributes: id=%q anchor=%q", link.ID, link.Anchor)
+	}
+	if runs := link.Runs(); len(runs) != 2 || runs[1].Children[0].Text.Text != " site" {
+		t.Errorf("Unexpected hyperlink runs: %+v", runs)
+	}
+
+	output, err := xml.Marshal(link)
+	if err != nil {
+		t.Fatalf("Error marshaling hyperlink: %v", err)
+	}
+	expected := `<w:hyperlink r:id="rId9" w:anchor="intro"><w:r><w:t>the</w:t></w:r><w:r><w:t> site</w:t></w:r></w:hyperlink>`
+	if string(output) != expected {
+		t.Errorf("Expected XML:\n%s\nBut got:\n%s", expected, output)
+	}
+}
+
+func TestParagraphBookmarks(t *testing.T) {
+	xmlStr := `<w:p xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:bookmarkStart w:id="3" w:name="Pricing"/><w:r><w:t>Prices</w:t></w:r><w:bookmarkEnd w:id="3"/>` +
+		`</w:p>`
+
+	var p Paragraph
+	if err := xml.Unmarshal([]byte(xmlStr), &p); err != nil {
+		t.Fatalf("Error unmarshaling paragraph: %v", err)
+	}
+
+	if len(p.Children) != 3 || p.Children[0].BookmarkStart == nil || p.Children[2].BookmarkEnd == nil {
+		t.Fatalf("Expected a bookmark around a run, got %+v", p.Children)
+	}
+	if start := p.Children[0].BookmarkStart; start.ID != 3 || start.Name != "Pricing" {
+		t.Errorf("Unexpected bookmark start: %+v", start)
+	}
+
+	output, err := xml.Marshal(p)
+	if err != nil {
+		t.Fatalf("Error marshaling paragraph: %v", err)
+	}
+	expected := `<w:p><w:bookmarkStart w:id="3" w:name="Pricing"></w:bookmarkStart><w:r><w:t>Prices</w:t></w:r><w:bookmarkEnd w:id="3"></w:bookmarkEnd></w:p>`
+	if string(output) != expected {
+		t.Errorf("Expected %s, got %s", expected, output)
+	}
+}
+
+func TestParagraphTrackChanges(t *testing.T) {
+	xmlStr := `<w:p xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">` +
+		`<w:pPr><w:rPr><w:ins w:id="3" w:author="Ann"/></w:rPr></w:pPr>` +
+		`<w:r><w:t>Pay </w:t></w:r>` +
+		`<w:del w:id="1" w:author="Ann" w:date="2024-01-02T00:00:00Z"><w:r><w:delText>ten</w:delText></w:r></w:del>` +
+		`<w:ins w:id="2" w:author="Ann"><w:r><w:t>five</w:t></w:r></w:ins>` +
+		`</w:p>`
+
+	var p Paragraph
+	if err := xml.Unmarshal([]byte(xmlStr), &p); err != nil {
+		t.Fatalf("Error unmarshaling paragraph: %v", err)
+	}
+
+	if len(p.Children) != 3 || p.Children[1].Del == nil || p.Children[2].Ins == nil {
+		t.Fatalf("Expected a deletion and an insertion, got %+v", p.Children)
+	}
+	if del := p.Children[1].Del; del.ID != 1 || del.Author != "Ann" || del.Date == nil || len(del.Runs) != 1 {
+		t.Errorf("Unexpected deletion: %+v", del)
+	}
+	if p.Property.RunProperty == nil || p.Property.RunProperty.Ins == nil || p.Property.RunProperty.Ins.ID != 3 {
+		t.Errorf("Expected an inserted paragraph mark, got %+v", p.Property.RunProperty)
+	}
+
+	output, err := xml.Marshal(p)
+	if err != nil {
+		t.Fatalf("Error marshaling paragraph: %v", err)
+	}
+	expected := `<w:p><w:pPr><w:rPr><w:ins w:id="3" w:author="Ann"></w:ins></w:rPr></w:pPr>` +
+		`<w:r><w:t>Pay </w:t></w:r>` +
+		`<w:del w:id="1" w:author="Ann" w:date="2024-01-02T00:00:00Z"><w:r><w:delText>ten</w:delText></w:r></w:del>` +
+		`<w:ins w:id="2" w:author="Ann"><w:r><w:t>five</w:t></w:r></w:ins></w:p>`
+	if string(output) != expected {
+		t.Errorf("Expected %s, got %s", expected, output)
+	}
+}
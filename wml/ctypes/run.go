@@ -39,6 +39,9 @@ type RunChild struct {
 	//Deleted Field Code
 	DelInstrText *Text `xml:"delInstrText,omitempty"`
 
+	//Complex Field Character
+	FldChar *FldChar `xml:"fldChar,omitempty"`
+
 	//Non Breaking Hyphen Character
 	NoBreakHyphen *Empty `xml:"noBreakHyphen,omitempty"`
 
@@ -95,12 +98,15 @@ type RunChild struct {
 
 	//TODO:
 	// 	w:object    Inline Embedded Object
-	// w:fldChar    Complex Field Character
 	// w:ruby    Phonetic Guide
-	// w:footnoteReference    Footnote Reference
-	// w:endnoteReference    Endnote Reference
 	// w:commentReference    Comment Content Reference Mark
 
+	//Footnote Reference
+	FootnoteReference *NoteRef `xml:"footnoteReference,omitempty"`
+
+	//Endnote Reference
+	EndnoteReference *NoteRef `xml:"endnoteReference,omitempty"`
+
 	//Comment Content Reference Mark
 	CmntRef *Markup `xml:"commentReference,omitempty"`
 
@@ -205,6 +211,63 @@ loop:
 				r.Children = append(r.Children, RunChild{
 					Break: &br,
 				})
+			case "delText", "instrText", "delInstrText":
+				txt := NewText()
+				if err = d.DecodeElement(txt, &elem); err != nil {
+					return err
+				}
+
+				switch elem.Name.Local {
+				case "delText":
+					r.Children = append(r.Children, RunChild{DelText: txt})
+				case "instrText":
+					r.Children = append(r.Children, RunChild{InstrText: txt})
+				default:
+					r.Children = append(r.Children, RunChild{DelInstrText: txt})
+				}
+			case "fldChar":
+				fldChar := &FldChar{}
+				if err = d.DecodeElement(fldChar, &elem); err != nil {
+					return err
+				}
+
+				r.Children = append(r.Children, RunChild{FldChar: fldChar})
+			case "sym":
+				sym := &Sym{}
+				if err = d.DecodeElement(sym, &elem); err != nil {
+					return err
+				}
+
+				r.Children = append(r.Children, RunChild{Sym: sym})
+			case "footnoteReference", "endnoteReference":
+				ref := &NoteRef{}
+				if err = d.DecodeElement(ref, &elem); err != nil {
+					return err
+				}
+
+				if elem.Name.Local == "footnoteReference" {
+					r.Children = append(r.Children, RunChild{FootnoteReference: ref})
+				} else {
+					r.Children = append(r.Children, RunChild{EndnoteReference: ref})
+				}
+			case "cr", "noBreakHyphen", "softHyphen", "footnoteRef", "endnoteRef":
+				empty := &Empty{}
+				if err = d.DecodeElement(empty, &elem); err != nil {
+					return err
+				}
+
+				switch elem.Name.Local {
+				case "cr":
+					r.Children = append(r.Children, RunChild{CarrRtn: empty})
+				case "noBreakHyphen":
+					r.Children = append(r.Children, RunChild{NoBreakHyphen: empty})
+				case "footnoteRef":
+					r.Children = append(r.Children, RunChild{FootnoteRef: empty})
+				case "endnoteRef":
+					r.Children = append(r.Children, RunChild{EndnoteRef: empty})
+				default:
+					r.Children = append(r.Children, RunChild{SoftHyphen: empty})
+				}
 			case "drawing":
 				drawingElem := &dml.Drawing{}
 				if err = d.DecodeElement(drawingElem, &elem); err != nil {
@@ -275,6 +338,8 @@ func (r *Run) MarshalChild(e *xml.Encoder) error {
 			err = child.InstrText.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:instrText"}})
 		case child.DelInstrText != nil:
 			err = child.DelInstrText.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:delInstrText"}})
+		case child.FldChar != nil:
+			err = child.FldChar.MarshalXML(e, xml.StartElement{})
 		case child.NoBreakHyphen != nil:
 			err = child.NoBreakHyphen.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:noBreakHyphen"}})
 		case child.SoftHyphen != nil:
@@ -319,6 +384,10 @@ func (r *Run) MarshalChild(e *xml.Encoder) error {
 			err = child.PTab.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:ptab"}})
 		case child.CmntRef != nil:
 			err = child.CmntRef.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:commentReference"}})
+		case child.FootnoteReference != nil:
+			err = child.FootnoteReference.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:footnoteReference"}})
+		case child.EndnoteReference != nil:
+			err = child.EndnoteReference.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:endnoteReference"}})
 
 		}
 
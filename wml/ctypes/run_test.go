@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"godocx/internal"
+	"godocx/wml/stypes"
 )
 
 func TestSym_MarshalXML(t *testing.T) {
@@ -98,3 +99,67 @@ func TestSym_UnmarshalXML(t *testing.T) {
 		})
 	}
 }
+
+func TestRun_NoteReferences(t *testing.T) {
+	input := `<w:r><w:footnoteReference w:id="2"></w:footnoteReference><w:endnoteReference w:id="3"></w:endnoteReference><w:footnoteRef></w:footnoteRef></w:r>`
+
+	var run Run
+	if err := xml.Unmarshal([]byte(input), &run); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+	if len(run.Children) != 3 {
+		t.Fatalf("Expected 3 children, got %d", len(run.Children))
+	}
+	if run.Children[0].FootnoteReference == nil || run.Children[0].FootnoteReference.ID != 2 {
+		t.Errorf("Expected footnote reference 2, got %+v", run.Children[0])
+	}
+	if run.Children[1].EndnoteReference == nil || run.Children[1].EndnoteReference.ID != 3 {
+		t.Errorf("Expected endnote reference 3, got %+v", run.Children[1])
+	}
+	if run.Children[2].FootnoteRef == nil {
+		t.Errorf("Expected footnote reference mark, got %+v", run.Children[2])
+	}
+
+	output, err := xml.Marshal(run)
+	if err != nil {
+		t.Fatalf("Error marshaling XML: %v", err)
+	}
+	if !strings.Contains(string(output), `<w:footnoteReference w:id="2"></w:footnoteReference><w:endnoteReference w:id="3"></w:endnoteReference>`) {
+		t.Errorf("Unexpected XML %s", output)
+	}
+}
+
+func TestRun_FieldRoundTrip(t *testing.T) {
+	input := `<w:p><w:r><w:fldChar w:fldCharType="begin" w:dirty="true"></w:fldChar></w:r>` +
+		`<w:r><w:instrText xml:space="preserve"> PAGE \* MERGEFORMAT </w:instrText></w:r>` +
+		`<w:r><w:fldChar w:fldCharType="separate"></w:fldChar></w:r>` +
+		`<w:r><w:t>2</w:t></w:r>` +
+		`<w:r><w:fldChar w:fldCharType="end" w:fldLock="1"></w:fldChar></w:r>` +
+		`<w:r><w:delInstrText> TOC </w:delInstrText></w:r></w:p>`
+
+	var para Paragraph
+	if err := xml.Unmarshal([]byte(input), &para); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+	if len(para.Children) != 6 {
+		t.Fatalf("Expected 6 runs, got %d", len(para.Children))
+	}
+	begin := para.Children[0].Run.Children[0].FldChar
+	if begin == nil || begin.FldCharType != stypes.FldCharTypeBegin || begin.Dirty == nil || *begin.Dirty != stypes.OnOffTrue {
+		t.Errorf("Expected a dirty begin field character, got %+v", begin)
+	}
+
+	output, err := xml.Marshal(para)
+	if err != nil {
+		t.Fatalf("Error marshaling XML: %v", err)
+	}
+	expected := `<w:r><w:fldChar w:fldCharType="begin" w:dirty="true"></w:fldChar></w:r>` +
+		`<w:r><w:instrText xml:space="preserve"> PAGE \* MERGEFORMAT </w:instrText></w:r>` +
+		`<w:r><w:fldChar w:fldCharType="separate"></w:fldChar></w:r>` +
+		`<w:r><w:t>2</w:t></w:r>` +
+		`<w:r><w:fldChar w:fldCharType="end" w:fldLock="1"></w:fldChar></w:r>` +
+		`<w:r><w:delInstrText> TOC </w:delInstrText></w:r>`
+	if !strings.Contains(string(output), expected) {
+		t.Errorf("Unexpected XML %s", output)
+	}
+}
@@ -9,6 +9,10 @@ import (
 
 // RunProperty represents the properties of a run of text within a paragraph.
 type RunProperty struct {
+	// Inserted and Deleted Paragraph marks, only set in the run properties of a paragraph mark
+	Ins *TrackChange `xml:"ins,omitempty"`
+	Del *TrackChange `xml:"del,omitempty"`
+
 	//1. Referenced Character Style
 	Style *CTString `xml:"rStyle,omitempty"`
 
@@ -145,6 +149,17 @@ func (rp RunProperty) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		return err
 	}
 
+	if rp.Ins != nil {
+		if err = rp.Ins.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:ins"}}); err != nil {
+			return fmt.Errorf("ins: %w", err)
+		}
+	}
+	if rp.Del != nil {
+		if err = rp.Del.MarshalXML(e, xml.StartElement{Name: xml.Name{Local: "w:del"}}); err != nil {
+			return fmt.Errorf("del: %w", err)
+		}
+	}
+
 	// 1. Referenced Character Style
 	if rp.Style != nil {
 		if err = rp.Style.MarshalXML(e, xml.StartElement{
@@ -65,3 +65,19 @@ func TestSpacing_MarshalXML(t *testing.T) {
 		})
 	}
 }
+
+func TestSpacing_UnmarshalXML(t *testing.T) {
+	input := `<w:spacing w:before="120" w:after="240" w:line="360" w:lineRule="auto"></w:spacing>`
+
+	var result Spacing
+	if err := xml.Unmarshal([]byte(input), &result); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+
+	if result.Line == nil || *result.Line != 360 {
+		t.Errorf("Expected line 360, got %v", result.Line)
+	}
+	if result.After == nil || *result.After != 240 {
+		t.Errorf("Expected after 240, got %v", result.After)
+	}
+}
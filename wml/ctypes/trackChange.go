@@ -24,3 +24,70 @@ func (t TrackChange) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 
 	return e.EncodeElement("", start)
 }
+
+// RunTrackChange represents a tracked insertion (w:ins) or deletion (w:del) of runs. The text of
+// deleted runs is held by DelText rather than Text.
+type RunTrackChange struct {
+	TrackChange
+	Runs []*Run
+}
+
+func (t RunTrackChange) MarshalXML(e *xml.Encoder, start xml.StartElement) (err error) {
+	start.Attr = []xml.Attr{
+		{Name: xml.Name{Local: "w:id"}, Value: strconv.Itoa(t.ID)},
+		{Name: xml.Name{Local: "w:author"}, Value: t.Author},
+	}
+	if t.Date != nil {
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "w:date"}, Value: *t.Date})
+	}
+
+	if err = e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, r := range t.Runs {
+		if err = r.MarshalXML(e, xml.StartElement{}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+func (t *RunTrackChange) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	for _, attr := range start.Attr {
+		switch attr.Name.Local {
+		case "id":
+			if t.ID, err = strconv.Atoi(attr.Value); err != nil {
+				return err
+			}
+		case "author":
+			t.Author = attr.Value
+		case "date":
+			date := attr.Value
+			t.Date = &date
+		}
+	}
+
+	for {
+		currentToken, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch elem := currentToken.(type) {
+		case xml.StartElement:
+			if elem.Name.Local != "r" {
+				if err = d.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			r := NewRun()
+			if err = d.DecodeElement(r, &elem); err != nil {
+				return err
+			}
+			t.Runs = append(t.Runs, r)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package stypes
+
+import (
+	"encoding/xml"
+	"errors"
+)
+
+// FldCharType is the type of a complex field character.
+type FldCharType string
+
+const (
+	FldCharTypeBegin    FldCharType = "begin"    // Start Character
+	FldCharTypeSeparate FldCharType = "separate" // Separator Character
+	FldCharTypeEnd      FldCharType = "end"      // End Character
+	FldCharTypeInvalid  FldCharType = ""
+)
+
+func FldCharTypeFromStr(value string) (FldCharType, error) {
+	switch value {
+	case "begin":
+		return FldCharTypeBegin, nil
+	case "separate":
+		return FldCharTypeSeparate, nil
+	case "end":
+		return FldCharTypeEnd, nil
+	default:
+		return FldCharTypeInvalid, errors.New("invalid FldCharType value")
+	}
+}
+
+func (d *FldCharType) UnmarshalXMLAttr(attr xml.Attr) error {
+	val, err := FldCharTypeFromStr(attr.Value)
+	if err != nil {
+		return err
+	}
+
+	*d = val
+
+	return nil
+}
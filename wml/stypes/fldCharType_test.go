@@ -0,0 +1,53 @@
+package stypes
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestFldCharTypeFromStr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected FldCharType
+	}{
+		{"begin", FldCharTypeBegin},
+		{"separate", FldCharTypeSeparate},
+		{"end", FldCharTypeEnd},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := FldCharTypeFromStr(tt.input)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, result)
+			}
+		})
+	}
+
+	if _, err := FldCharTypeFromStr("middle"); err == nil || err.Error() != "invalid FldCharType value" {
+		t.Errorf("Expected an invalid FldCharType error, got %v", err)
+	}
+}
+
+func TestFldCharType_UnmarshalXMLAttr(t *testing.T) {
+	type Element struct {
+		XMLName     xml.Name    `xml:"element"`
+		FldCharType FldCharType `xml:"fldCharType,attr"`
+	}
+
+	var elem Element
+	if err := xml.Unmarshal([]byte(`<element fldCharType="separate"></element>`), &elem); err != nil {
+		t.Fatalf("Error unmarshaling XML: %v", err)
+	}
+	if elem.FldCharType != FldCharTypeSeparate {
+		t.Errorf("Expected %s but got %s", FldCharTypeSeparate, elem.FldCharType)
+	}
+
+	if err := xml.Unmarshal([]byte(`<element fldCharType="middle"></element>`), &elem); err == nil {
+		t.Fatalf("Expected error for invalid value, but got none")
+	}
+}
@@ -8,6 +8,8 @@ import (
 type Justification string
 
 const (
+	JustificationStart          Justification = "start"          // Align to the Start of the Line
+	JustificationEnd            Justification = "end"            // Align to the End of the Line
 	JustificationLeft           Justification = "left"           // Align Left
 	JustificationCenter         Justification = "center"         // Align Center
 	JustificationRight          Justification = "right"          // Align Right
@@ -22,6 +24,10 @@ const (
 
 func JustificationFromStr(value string) (Justification, error) {
 	switch value {
+	case "start":
+		return JustificationStart, nil
+	case "end":
+		return JustificationEnd, nil
 	case "left":
 		return JustificationLeft, nil
 	case "center":
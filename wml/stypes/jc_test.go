@@ -20,6 +20,8 @@ func TestJustificationFromStr_ValidValues(t *testing.T) {
 		{"highKashida", JustificationHighKashida},
 		{"lowKashida", JustificationLowKashida},
 		{"thaiDistribute", JustificationThaiDistribute},
+		{"start", JustificationStart},
+		{"end", JustificationEnd},
 	}
 
 	for _, tt := range tests {
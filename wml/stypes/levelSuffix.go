@@ -0,0 +1,40 @@
+package stypes
+
+import (
+	"encoding/xml"
+	"errors"
+)
+
+// LevelSuffix is the content between the numbering symbol of a list level and the paragraph text.
+type LevelSuffix string
+
+const (
+	LevelSuffixTab     LevelSuffix = "tab"     // Tab Between Numbering and Text
+	LevelSuffixSpace   LevelSuffix = "space"   // Space Between Numbering and Text
+	LevelSuffixNothing LevelSuffix = "nothing" // Nothing Between Numbering and Text
+	LevelSuffixInvalid LevelSuffix = ""
+)
+
+// LevelSuffixFromStr converts a string to LevelSuffix.
+func LevelSuffixFromStr(value string) (LevelSuffix, error) {
+	switch value {
+	case "tab":
+		return LevelSuffixTab, nil
+	case "space":
+		return LevelSuffixSpace, nil
+	case "nothing":
+		return LevelSuffixNothing, nil
+	default:
+		return LevelSuffixInvalid, errors.New("invalid LevelSuffix value")
+	}
+}
+
+// UnmarshalXMLAttr unmarshals an XML attribute into LevelSuffix.
+func (l *LevelSuffix) UnmarshalXMLAttr(attr xml.Attr) error {
+	val, err := LevelSuffixFromStr(attr.Value)
+	if err != nil {
+		return err
+	}
+	*l = val
+	return nil
+}
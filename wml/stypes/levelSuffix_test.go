@@ -0,0 +1,49 @@
+package stypes
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestLevelSuffixFromStr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected LevelSuffix
+	}{
+		{"tab", LevelSuffixTab},
+		{"space", LevelSuffixSpace},
+		{"nothing", LevelSuffixNothing},
+		{"invalid", LevelSuffixInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := LevelSuffixFromStr(tt.input)
+			if tt.expected == LevelSuffixInvalid && err == nil {
+				t.Fatalf("Expected error for input %s but got none", tt.input)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestLevelSuffix_UnmarshalXMLAttr(t *testing.T) {
+	type Element struct {
+		XMLName xml.Name    `xml:"element"`
+		Suffix  LevelSuffix `xml:"val,attr"`
+	}
+
+	var elem Element
+	if err := xml.Unmarshal([]byte(`<element val="space"></element>`), &elem); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if elem.Suffix != LevelSuffixSpace {
+		t.Errorf("Expected %s but got %s", LevelSuffixSpace, elem.Suffix)
+	}
+
+	if err := xml.Unmarshal([]byte(`<element val="invalid"></element>`), &elem); err == nil {
+		t.Fatalf("Expected error for invalid value but got none")
+	}
+}
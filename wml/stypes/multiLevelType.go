@@ -0,0 +1,40 @@
+package stypes
+
+import (
+	"encoding/xml"
+	"errors"
+)
+
+// MultiLevelType is the type of an abstract numbering definition.
+type MultiLevelType string
+
+const (
+	MultiLevelTypeSingleLevel      MultiLevelType = "singleLevel"      // Single Level Numbering Definition
+	MultiLevelTypeMultilevel       MultiLevelType = "multilevel"       // Multilevel Numbering Definition
+	MultiLevelTypeHybridMultilevel MultiLevelType = "hybridMultilevel" // Hybrid Multilevel Numbering Definition
+	MultiLevelTypeInvalid          MultiLevelType = ""
+)
+
+// MultiLevelTypeFromStr converts a string to MultiLevelType.
+func MultiLevelTypeFromStr(value string) (MultiLevelType, error) {
+	switch value {
+	case "singleLevel":
+		return MultiLevelTypeSingleLevel, nil
+	case "multilevel":
+		return MultiLevelTypeMultilevel, nil
+	case "hybridMultilevel":
+		return MultiLevelTypeHybridMultilevel, nil
+	default:
+		return MultiLevelTypeInvalid, errors.New("invalid MultiLevelType value")
+	}
+}
+
+// UnmarshalXMLAttr unmarshals an XML attribute into MultiLevelType.
+func (m *MultiLevelType) UnmarshalXMLAttr(attr xml.Attr) error {
+	val, err := MultiLevelTypeFromStr(attr.Value)
+	if err != nil {
+		return err
+	}
+	*m = val
+	return nil
+}
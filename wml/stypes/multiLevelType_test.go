@@ -0,0 +1,49 @@
+package stypes
+
+import (
+	"encoding/xml"
+	"testing"
+)
+
+func TestMultiLevelTypeFromStr(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected MultiLevelType
+	}{
+		{"singleLevel", MultiLevelTypeSingleLevel},
+		{"multilevel", MultiLevelTypeMultilevel},
+		{"hybridMultilevel", MultiLevelTypeHybridMultilevel},
+		{"invalid", MultiLevelTypeInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := MultiLevelTypeFromStr(tt.input)
+			if tt.expected == MultiLevelTypeInvalid && err == nil {
+				t.Fatalf("Expected error for input %s but got none", tt.input)
+			}
+			if result != tt.expected {
+				t.Errorf("Expected %s but got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMultiLevelType_UnmarshalXMLAttr(t *testing.T) {
+	type Element struct {
+		XMLName xml.Name       `xml:"element"`
+		Type    MultiLevelType `xml:"val,attr"`
+	}
+
+	var elem Element
+	if err := xml.Unmarshal([]byte(`<element val="hybridMultilevel"></element>`), &elem); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if elem.Type != MultiLevelTypeHybridMultilevel {
+		t.Errorf("Expected %s but got %s", MultiLevelTypeHybridMultilevel, elem.Type)
+	}
+
+	if err := xml.Unmarshal([]byte(`<element val="invalid"></element>`), &elem); err == nil {
+		t.Fatalf("Expected error for invalid value but got none")
+	}
+}
@@ -68,6 +68,7 @@ const (
 	NumFmtThaiLetters                  NumFmt = "thaiLetters"
 	NumFmtThaiNumbers                  NumFmt = "thaiNumbers"
 	NumFmtThaiCounting                 NumFmt = "thaiCounting"
+	NumFmtCustom                       NumFmt = "custom"
 )
 
 // NumFmtFromStr converts a string value to NumFmt type.
@@ -193,6 +194,8 @@ func NumFmtFromStr(value string) (NumFmt, error) {
 		return NumFmtThaiNumbers, nil
 	case "thaiCounting":
 		return NumFmtThaiCounting, nil
+	case "custom":
+		return NumFmtCustom, nil
 	default:
 		return "", errors.New("Invalid Numbering Format")
 	}
@@ -70,6 +70,7 @@ func TestNumFmtFromStr_ValidValues(t *testing.T) {
 		{"thaiLetters", NumFmtThaiLetters},
 		{"thaiNumbers", NumFmtThaiNumbers},
 		{"thaiCounting", NumFmtThaiCounting},
+		{"custom", NumFmtCustom},
 	}
 
 	for _, tt := range tests {